@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias [name]",
+	Short: "Print a gh alias that launches rivet on a repository",
+	Long: `Print a 'gh alias set' line defining a shortcut that opens rivet on a
+repository, and on the runs of a workflow with --workflow. Paste it in a shell
+to create the alias, or share it with teammates.
+
+The repository defaults to the one rivet would view from here. Without a
+name, the alias is named after the repository and workflow, such as
+rivet-cli-build.`,
+	Example: `  rivet alias --workflow build.yml
+  rivet alias ci --repo owner/repo`,
+	RunE: runAlias,
+	Args: cobra.MaximumNArgs(1),
+}
+
+func init() {
+	aliasCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/repo format)")
+	aliasCmd.Flags().StringVar(&openWorkflow, "workflow", "", "Workflow file whose runs the alias opens (e.g. build.yml)")
+
+	rootCmd.AddCommand(aliasCmd)
+}
+
+func runAlias(_ *cobra.Command, args []string) error {
+	var cfg *config.Config
+	if p, err := initializePaths(); err == nil {
+		if configPaths := p.GetConfigPaths(); len(configPaths) > 0 {
+			cfg, err = config.LoadMerged(configPaths)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+		}
+	}
+
+	target, _ := determineActiveRepository(cfg, loadGlobalState())
+	if target == "" {
+		return fmt.Errorf("no repository configured. Use --repo owner/repo")
+	}
+	target, err := github.NormalizeRepo(target)
+	if err != nil {
+		return err
+	}
+
+	if cfg != nil {
+		if err := validateOpenWorkflow(cfg, openWorkflow); err != nil {
+			return err
+		}
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	fmt.Println(github.AliasCommand(name, target, openWorkflow))
+	return nil
+}
@@ -2,17 +2,24 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"github.com/Cloudsky01/gh-rivet/internal/config"
 	"github.com/Cloudsky01/gh-rivet/internal/git"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/migration"
 	"github.com/Cloudsky01/gh-rivet/internal/paths"
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
 )
 
 var (
@@ -44,8 +51,10 @@ Configuration Precedence (lowest to highest):
 	configShowCmd = &cobra.Command{
 		Use:   "show",
 		Short: "Display merged configuration",
-		Long:  `Show the effective configuration after merging all sources.`,
-		RunE:  runConfigShow,
+		Long: `Show the effective configuration after merging all sources.
+
+When stdout is a terminal the output is shown in $GH_PAGER, $PAGER, or less.`,
+		RunE: runConfigShow,
 	}
 
 	configEditCmd = &cobra.Command{
@@ -55,6 +64,67 @@ Configuration Precedence (lowest to highest):
 		RunE:  runConfigEdit,
 	}
 
+	configRevealCmd = &cobra.Command{
+		Use:   "reveal",
+		Short: "Open the user configuration directory",
+		Long: `Open the directory holding the user configuration file in the system file
+manager. Where there is no file manager, such as over SSH, the path is printed
+instead.`,
+		RunE: runConfigReveal,
+	}
+
+	configDiffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Compare configuration tiers",
+		Long: `Show what each configuration tier contributes to the merged result.
+
+Each key set by a tier is marked as effective (✓) or overridden (✗) by a
+higher-precedence tier.`,
+		RunE: runConfigDiff,
+	}
+
+	configEnrichCmd = &cobra.Command{
+		Use:   "enrich",
+		Short: "Fill in workflow display names from the workflow files",
+		Long: `Name each configured workflow that has no display name after the name:
+field of its workflow file.
+
+Names are read from .github/workflows inside a repository that has one,
+when it is a checkout of the configured repository, otherwise from the
+GitHub API for the configured repository. Names are written to your user
+configuration, or to the file given with --config; the repository default
+shared with your team is never changed. Workflows whose name cannot be read
+keep showing their file name.`,
+		RunE: runConfigEnrich,
+	}
+
+	configRestoreCmd = &cobra.Command{
+		Use:   "restore [number]",
+		Short: "List or restore configuration backups",
+		Long: fmt.Sprintf(`Config files are backed up before a destructive write: init --force or
+--reset, config reset, import, config migrate and restore itself. Everyday
+saves, such as pinning in the TUI, are not backed up. The %d most recent
+backups are kept. Without arguments, list the backups, newest first. With
+a number from that list, restore the backup to the file it was taken from,
+after a confirmation. The file being replaced is backed up too.`, config.MaxBackups),
+		RunE: runConfigRestore,
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	configMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Move a legacy .rivet.yaml into the configuration tiers",
+		Long: `Move the legacy config found by 'rivet config path' into the configuration
+tiers. With --to user it becomes your project user config (your user config
+outside a repository). With --to team it becomes the repository default in
+.github/.rivet.yaml, and its pins move to your project user config.
+
+--dry-run shows the files that would be created, modified and deleted and
+the resulting tiers, without writing anything. Without --to or --dry-run a
+menu asks what to do.`,
+		RunE: runConfigMigrate,
+	}
+
 	configResetCmd = &cobra.Command{
 		Use:   "reset",
 		Short: "Reset user configuration",
@@ -68,12 +138,36 @@ func init() {
 	configCmd.AddCommand(configPathCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configRevealCmd)
+	configCmd.AddCommand(configDiffCmd)
+	configCmd.AddCommand(configEnrichCmd)
 	configCmd.AddCommand(configResetCmd)
+	configCmd.AddCommand(configRestoreCmd)
+	configCmd.AddCommand(configMigrateCmd)
+
+	configRestoreCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation (required without a TTY)")
 
 	// Add --config flag to config show subcommand
 	configShowCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to configuration file (default: auto-detect)")
+	configShowCmd.Flags().BoolVar(&showProvenance, "provenance", false, "Annotate each setting with the source it came from")
+	configShowCmd.Flags().BoolVar(&noPager, "no-pager", false, "Do not pipe output into a pager")
+
+	configMigrateCmd.Flags().StringVar(&migrateTo, "to", "", "Where the config goes: user or team")
+	configMigrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show the changes without writing anything")
+	configMigrateCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation (required without a TTY)")
+
+	configEnrichCmd.Flags().StringVarP(&configPath, "config", "c", "", "Only update this configuration file")
+	configEnrichCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/repo format) to read names from")
+	configEnrichCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+	configEnrichCmd.Flags().IntVar(&timeoutSeconds, "timeout", 30, "GitHub API timeout in seconds")
 }
 
+var (
+	showProvenance bool
+	migrateTo      string
+	migrateDryRun  bool
+)
+
 func runConfigPath(_ *cobra.Command, _ []string) error {
 	// Detect project root
 	projectRoot, _ := git.GetGitRepositoryRoot()
@@ -130,15 +224,16 @@ func runConfigShow(cmd *cobra.Command, _ []string) error {
 	var cfg *config.Config
 	var loadPath string
 	var err error
+	var p *paths.Paths
 
 	// If an explicit config path provided, use it
-	if cmd.Flags().Changed("config") && configPath != "" {
+	explicit := cmd.Flags().Changed("config") && configPath != ""
+	if explicit {
 		cfg, err = config.LoadMerged([]string{configPath})
 		loadPath = configPath
 	} else {
 		// Use precedence system
 		projectRoot, _ := git.GetGitRepositoryRoot()
-		var p *paths.Paths
 		if projectRoot != "" {
 			p, err = paths.NewWithProject(projectRoot)
 		} else {
@@ -161,22 +256,136 @@ func runConfigShow(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	fmt.Println("Configuration")
-	fmt.Println("════════════════════════════════════════════════════════════")
-	fmt.Printf("Path:   %s\n", loadPath)
-	fmt.Println()
-
 	// Marshal and display config
-	data, err := yaml.Marshal(cfg)
+	var data []byte
+	if showProvenance {
+		label := func(path string) string {
+			if explicit {
+				return paths.SourceCLIFlag.String()
+			}
+			return tierLabel(p, path)
+		}
+		data, err = marshalWithProvenance(cfg, label)
+	} else {
+		data, err = yaml.Marshal(cfg)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	fmt.Println(string(data))
+	var out strings.Builder
+	out.WriteString("Configuration\n")
+	out.WriteString("════════════════════════════════════════════════════════════\n")
+	fmt.Fprintf(&out, "Path:   %s\n\n", loadPath)
+	out.WriteString(string(data))
+	out.WriteString("\n")
+
+	return writePaged(out.String())
+}
+
+// marshalWithProvenance renders the config as YAML with a trailing comment on
+// each top-level field, preference and group naming the source that set it.
+func marshalWithProvenance(cfg *config.Config, label func(path string) string) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(cfg); err != nil {
+		return nil, err
+	}
+
+	annotate := func(node *yaml.Node, key string) {
+		if source, ok := cfg.SourceOf(key); ok {
+			node.LineComment = "from " + label(source)
+		}
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+
+		switch keyNode.Value {
+		case "preferences":
+			for j := 0; j+1 < len(valueNode.Content); j += 2 {
+				prefKey, prefValue := valueNode.Content[j], valueNode.Content[j+1]
+				if prefKey.Value == "customSettings" {
+					for k := 0; k+1 < len(prefValue.Content); k += 2 {
+						annotate(prefValue.Content[k], "preferences.customSettings."+prefValue.Content[k].Value)
+					}
+					continue
+				}
+				annotate(prefKey, "preferences."+prefKey.Value)
+			}
+		case "groups":
+			annotate(keyNode, "groups")
+			for _, groupNode := range valueNode.Content {
+				for j := 0; j+1 < len(groupNode.Content); j += 2 {
+					if groupNode.Content[j].Value == "id" {
+						annotate(groupNode.Content[j+1], "groups."+groupNode.Content[j+1].Value)
+					}
+				}
+			}
+		default:
+			annotate(keyNode, keyNode.Value)
+		}
+	}
+
+	return yaml.Marshal(&root)
+}
+
+func runConfigDiff(_ *cobra.Command, _ []string) error {
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+
+	configPaths := p.GetConfigPaths()
+	if len(configPaths) == 0 {
+		return fmt.Errorf("no configuration found. Run 'rivet init' first")
+	}
+
+	merged, err := config.LoadMerged(configPaths)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	fmt.Println("Configuration Tiers (lowest to highest precedence)")
+	fmt.Println("════════════════════════════════════════════════════════════")
+
+	for _, path := range configPaths {
+		tier, err := config.LoadFromPath(path)
+		if err != nil {
+			return fmt.Errorf("failed to load config from %s: %w", path, err)
+		}
+
+		fmt.Println()
+		label := tierLabel(p, path)
+		fmt.Printf("%s%s: %s\n", strings.ToUpper(label[:1]), label[1:], path)
+
+		keys := tier.DefinedKeys()
+		if len(keys) == 0 {
+			fmt.Println("  (no settings)")
+			continue
+		}
+
+		for _, key := range keys {
+			source, _ := merged.SourceOf(key)
+			if source == path {
+				fmt.Printf("  ✓ %s\n", key)
+			} else {
+				fmt.Printf("  ✗ %-32s overridden by %s\n", key, tierLabel(p, source))
+			}
+		}
+	}
 
 	return nil
 }
 
+// tierLabel returns a human-readable name for the tier a config path belongs to
+func tierLabel(p *paths.Paths, path string) string {
+	source := p.GetConfigSource(path)
+	if source == paths.SourceUnknown {
+		return path
+	}
+	return source.String()
+}
+
 func runConfigEdit(cmd *cobra.Command, _ []string) error {
 	// Create paths
 	p, err := paths.New()
@@ -254,6 +463,141 @@ func runConfigEdit(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+func runConfigEnrich(_ *cobra.Command, _ []string) error {
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+
+	sources := p.GetConfigPaths()
+	if configPath != "" {
+		sources = []string{configPath}
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no configuration found. Run 'rivet init' first")
+	}
+	cfg, err := config.LoadMerged(sources)
+	if err != nil {
+		return err
+	}
+
+	files, current := cfg.WorkflowFiles()
+	if len(files) == 0 {
+		fmt.Println(infoStyle.Render("No workflows configured"))
+		return nil
+	}
+
+	repository, err := enrichRepository(cfg)
+	if err != nil {
+		return err
+	}
+
+	workflowDir := ""
+	checkout, _ := git.DetectRepository()
+	if projectRoot, _ := git.GetGitRepositoryRoot(); projectRoot != "" && sameRepository(repository, checkout) &&
+		fileExists(filepath.Join(projectRoot, localWorkflowDir)) {
+		workflowDir = filepath.Join(projectRoot, localWorkflowDir)
+	} else if err := checkGitHubCLI(); err != nil {
+		return err
+	}
+
+	names, err := workflowNames(files, workflowDir, repository)
+	if err != nil {
+		return err
+	}
+	// A name set in any tier wins over the workflow file's
+	for file, name := range current {
+		if name != file {
+			delete(names, file)
+		}
+	}
+
+	target := enrichTarget(p)
+	targetCfg := &config.Config{}
+	if fileExists(target) {
+		if targetCfg, err = config.LoadFromPath(target); err != nil {
+			return err
+		}
+	}
+
+	filled := targetCfg.AddWorkflowNames(cfg, names)
+	if filled == 0 {
+		fmt.Println(infoStyle.Render("Every workflow already has a name, or its workflow file has none"))
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := targetCfg.Save(target); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Named %d workflows in: %s", filled, target)))
+	return nil
+}
+
+// enrichTarget returns the config that config enrich writes names to. Names
+// are personal: they go to the user tier, or the file given with --config,
+// and never into the team's repository default.
+func enrichTarget(p *paths.Paths) string {
+	if configPath != "" {
+		return configPath
+	}
+	return personalConfigPath(p)
+}
+
+// enrichRepository returns the repository whose workflows cfg configures: the
+// --repo flag, then cfg's repository, then the checkout's remote
+func enrichRepository(cfg *config.Config) (string, error) {
+	repository := repo
+	if repository == "" {
+		repository = cfg.Repository
+	}
+	if repository == "" {
+		if err := selectRemote(); err != nil {
+			return "", err
+		}
+		repository, _ = git.DetectRepository()
+	}
+	if repository == "" {
+		return "", fmt.Errorf("repository must be specified with --repo flag (e.g., --repo owner/repo)")
+	}
+
+	normalized, err := github.NormalizeRepo(repository)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository format '%s'. Expected format: [HOST/]OWNER/REPO (e.g., github/cli)", repository)
+	}
+	return normalized, nil
+}
+
+// sameRepository reports whether a and b name the same repository, ignoring
+// case and the github.com host prefix
+func sameRepository(a, b string) bool {
+	a, errA := github.NormalizeRepo(a)
+	b, errB := github.NormalizeRepo(b)
+	return errA == nil && errB == nil && strings.EqualFold(a, b)
+}
+
+func runConfigReveal(_ *cobra.Command, _ []string) error {
+	p, err := paths.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize paths: %w", err)
+	}
+	if err := p.EnsureDirs(); err != nil {
+		return fmt.Errorf("failed to ensure config directory: %w", err)
+	}
+
+	err = paths.Reveal(p.UserConfigDir)
+	if errors.Is(err, paths.ErrNoFileManager) {
+		fmt.Println(p.UserConfigDir)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to open file manager: %w", err)
+	}
+
+	fmt.Printf("Opened %s\n", p.UserConfigDir)
+	return nil
+}
+
 func runConfigReset(_ *cobra.Command, _ []string) error {
 	// Create paths
 	p, err := paths.New()
@@ -285,6 +629,9 @@ func runConfigReset(_ *cobra.Command, _ []string) error {
 	}
 
 	// Delete config file
+	if err := config.BackupFile(p.BackupDir(), userConfigPath); err != nil {
+		return fmt.Errorf("failed to back up config file: %w", err)
+	}
 	if err := os.Remove(userConfigPath); err != nil {
 		return fmt.Errorf("failed to remove config file: %w", err)
 	}
@@ -293,6 +640,174 @@ func runConfigReset(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func runConfigMigrate(_ *cobra.Command, _ []string) error {
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+	legacyPath, found := p.FindLegacyConfig()
+	if !found {
+		fmt.Println(infoStyle.Render("No legacy configuration to migrate"))
+		return nil
+	}
+
+	if migrateTo == "" {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("cannot ask in non-interactive mode. Use --to user or --to team")
+		}
+		if err := wizard.AskSelect(
+			"Migrate configuration",
+			"Where should "+legacyPath+" go?",
+			[]huh.Option[string]{
+				huh.NewOption("User config - personal settings, including pins", "user"),
+				huh.NewOption("Team config - shared in .github/.rivet.yaml, pins move to your project config", "team"),
+			},
+			&migrateTo,
+		); err != nil {
+			return err
+		}
+	}
+	strategy, err := parseMigrationStrategy(migrateTo)
+	if err != nil {
+		return err
+	}
+
+	plan, err := migration.PlanMigration(legacyPath, p, strategy)
+	if err != nil {
+		return fmt.Errorf("cannot migrate: %w", err)
+	}
+	printMigrationPlan(p, plan)
+
+	if !migrateDryRun && !assumeYes {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("cannot confirm in non-interactive mode. Use --yes to migrate or --dry-run to preview")
+		}
+		action := ""
+		if err := wizard.AskSelect(
+			"Apply migration",
+			"Make the changes above?",
+			[]huh.Option[string]{
+				huh.NewOption("Migrate", "apply"),
+				huh.NewOption("Dry run - keep the preview, write nothing", "dry-run"),
+				huh.NewOption("Cancel", "cancel"),
+			},
+			&action,
+		); err != nil {
+			return err
+		}
+		switch action {
+		case "cancel":
+			fmt.Println("Migration cancelled.")
+			return nil
+		case "dry-run":
+			migrateDryRun = true
+		}
+	}
+
+	if migrateDryRun {
+		fmt.Println(infoStyle.Render("Dry run: nothing was written"))
+		return nil
+	}
+	if err := plan.Apply(); err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+	fmt.Println(successStyle.Render("✓ Migrated " + legacyPath))
+	return nil
+}
+
+// parseMigrationStrategy maps the --to value onto a migration strategy
+func parseMigrationStrategy(to string) (migration.Strategy, error) {
+	switch to {
+	case "user":
+		return migration.MigrateToUser, nil
+	case "team":
+		return migration.KeepAsTeam, nil
+	default:
+		return 0, fmt.Errorf("invalid --to '%s': use user or team", to)
+	}
+}
+
+// printMigrationPlan lists the file changes of plan and the tiers in effect
+// afterwards
+func printMigrationPlan(p *paths.Paths, plan *migration.Plan) {
+	fmt.Printf("Migrating %s\n\n", plan.Source)
+	for _, change := range []struct {
+		verb  string
+		paths []string
+	}{
+		{"create", plan.Created},
+		{"modify", plan.Modified},
+		{"delete", plan.Deleted},
+	} {
+		for _, path := range change.paths {
+			fmt.Printf("  %-7s %s\n", change.verb, path)
+		}
+	}
+	for _, pin := range plan.MovedPins {
+		fmt.Printf("  %-7s %s\n", "pin", strings.Join(pin.GroupPath, " / ")+" / "+pin.WorkflowName+" moves to your project user config")
+	}
+
+	fmt.Println()
+	fmt.Println("Resulting tiers (lowest to highest precedence):")
+	for _, path := range plan.Tiers {
+		fmt.Printf("  %-20s %s\n", tierLabel(p, path)+":", path)
+	}
+	fmt.Println()
+}
+
+func runConfigRestore(_ *cobra.Command, args []string) error {
+	p, err := paths.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize paths: %w", err)
+	}
+	backups, err := config.ListBackups(p.BackupDir())
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+	if len(backups) == 0 {
+		fmt.Println(infoStyle.Render("No configuration backups"))
+		return nil
+	}
+
+	if len(args) == 0 {
+		for i, b := range backups {
+			fmt.Printf("%3d  %s  %s\n", i+1, b.Created.Format("2006-01-02 15:04:05"), b.Source)
+		}
+		fmt.Println()
+		fmt.Println(infoStyle.Render("Run 'rivet config restore <number>' to restore one"))
+		return nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(backups) {
+		return fmt.Errorf("invalid backup number '%s': choose 1-%d from 'rivet config restore'", args[0], len(backups))
+	}
+	backup := backups[n-1]
+
+	if !assumeYes {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("cannot confirm in non-interactive mode. Use --yes to restore")
+		}
+		confirmed := false
+		if err := wizard.AskConfirm(
+			"Restore configuration",
+			fmt.Sprintf("Replace %s with the backup from %s?", backup.Source, backup.Created.Format("2006-01-02 15:04:05")),
+			&confirmed,
+		); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	if err := config.RestoreBackup(p.BackupDir(), backup); err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+	fmt.Println(successStyle.Render("✓ Restored " + backup.Source))
+	return nil
+}
+
 // Helper functions
 
 func fileExists(path string) bool {
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+func TestMarshalWithProvenance(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, "team.yaml")
+	userPath := filepath.Join(tmpDir, "user.yaml")
+
+	team := "repository: team/repo\npreferences:\n  refreshInterval: 30\ngroups:\n  - id: ci\n    name: CI\n"
+	user := "preferences:\n  refreshInterval: 10\n"
+	if err := os.WriteFile(teamPath, []byte(team), 0644); err != nil {
+		t.Fatalf("failed to write team config: %v", err)
+	}
+	if err := os.WriteFile(userPath, []byte(user), 0644); err != nil {
+		t.Fatalf("failed to write user config: %v", err)
+	}
+
+	cfg, err := config.LoadMerged([]string{teamPath, userPath})
+	if err != nil {
+		t.Fatalf("failed to load merged config: %v", err)
+	}
+
+	labels := map[string]string{teamPath: "team", userPath: "user"}
+	data, err := marshalWithProvenance(cfg, func(path string) string { return labels[path] })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := string(data)
+	for _, want := range []string{
+		"repository: team/repo # from team",
+		"refreshInterval: 10 # from user",
+		"id: ci # from team",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestSameRepository(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"owner/repo", "owner/repo", true},
+		{"Owner/Repo", "owner/repo", true},
+		{"github.com/owner/repo", "owner/repo", true},
+		{"ghe.example.com/owner/repo", "owner/repo", false},
+		{"owner/repo", "owner/fork", false},
+		{"owner/repo", "", false},
+	}
+	for _, tt := range tests {
+		if got := sameRepository(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameRepository(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestEnrichTarget(t *testing.T) {
+	tmpDir := t.TempDir()
+	p := &paths.Paths{
+		UserConfigDir:         filepath.Join(tmpDir, "config"),
+		RepoDefaultConfigPath: filepath.Join(tmpDir, ".github", paths.LegacyConfigFileName),
+		ProjectUserConfigPath: filepath.Join(tmpDir, ".git", "rivet", paths.ConfigFileName),
+	}
+
+	if got := enrichTarget(p); got != p.ProjectUserConfigPath {
+		t.Errorf("enrichTarget() = %q, want the project user config", got)
+	}
+
+	oldConfigPath := configPath
+	configPath = p.RepoDefaultConfigPath
+	t.Cleanup(func() { configPath = oldConfigPath })
+	if got := enrichTarget(p); got != p.RepoDefaultConfigPath {
+		t.Errorf("enrichTarget() with --config = %q, want %q", got, p.RepoDefaultConfigPath)
+	}
+}
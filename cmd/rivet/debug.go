@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+var debugMode bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Write a debug log (key events, gh commands, errors) to the state directory")
+	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		if err := applyConfigDir(); err != nil {
+			return err
+		}
+		if err := applyGHPath(); err != nil {
+			return err
+		}
+		return setupDebugLog()
+	}
+}
+
+// setupDebugLog sends slog output to the debug log file when --debug is set
+// and discards it otherwise, so logging never draws over the TUI
+func setupDebugLog() error {
+	if !debugMode {
+		slog.SetDefault(slog.New(slog.DiscardHandler))
+		return nil
+	}
+
+	p, err := paths.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize paths: %w", err)
+	}
+	logPath := p.DebugLogFile()
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return fmt.Errorf("failed to create debug log directory: %w", err)
+	}
+
+	// The file stays open for the life of the process
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open debug log: %w", err)
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	slog.Info("debug logging started", "version", version, "args", os.Args[1:])
+	fmt.Fprintln(os.Stderr, infoStyle.Render("Debug log: "+logPath))
+	return nil
+}
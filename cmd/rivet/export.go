@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+)
+
+const defaultExportPath = "rivet-config.yaml"
+
+var (
+	exportCmd = &cobra.Command{
+		Use:   "export [path]",
+		Short: "Export the configuration for sharing",
+		Long: `Write the merged configuration to a single file that can be shared with teammates.
+
+Personal preferences and pinned workflows are stripped from the export.
+Defaults to ` + defaultExportPath + ` in the current directory.`,
+		RunE: runExport,
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	importCmd = &cobra.Command{
+		Use:   "import <path>",
+		Short: "Install a shared configuration as the repository default",
+		Long:  `Validate an exported configuration and install it as the repository default (.github/.rivet.yaml).`,
+		RunE:  runImport,
+		Args:  cobra.ExactArgs(1),
+	}
+)
+
+func init() {
+	exportCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to configuration file (default: auto-detect)")
+	exportCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite an existing export file")
+	importCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite an existing repository default config")
+
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	var configPaths []string
+	if cmd.Flags().Changed("config") {
+		configPaths = []string{configPath}
+	} else {
+		p, err := initializePaths()
+		if err != nil {
+			return err
+		}
+		configPaths = p.GetConfigPaths()
+		if len(configPaths) == 0 {
+			return fmt.Errorf("no configuration found. Run 'rivet init' first")
+		}
+	}
+
+	cfg, err := config.LoadMerged(configPaths)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	exportPath := defaultExportPath
+	if len(args) > 0 {
+		exportPath = args[0]
+	}
+
+	if !force && fileExists(exportPath) {
+		return fmt.Errorf("%s already exists. Use --force to overwrite", exportPath)
+	}
+
+	if dir := filepath.Dir(exportPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create export directory: %w", err)
+		}
+	}
+
+	if err := cfg.Shareable().SaveWithHeader(exportPath, true); err != nil {
+		return fmt.Errorf("failed to export configuration: %w", err)
+	}
+
+	fmt.Println(successStyle.Render("✓ Configuration exported to: " + exportPath))
+	fmt.Println(infoStyle.Render("Personal preferences and pins were not included."))
+	fmt.Println(infoStyle.Render("Teammates can install it with: rivet import " + exportPath))
+	return nil
+}
+
+func runImport(_ *cobra.Command, args []string) error {
+	importPath := args[0]
+
+	cfg, err := config.LoadFromPath(importPath)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", importPath, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration in %s: %w", importPath, err)
+	}
+
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+	if p.RepoDefaultConfigPath == "" {
+		return fmt.Errorf("import requires running inside a git repository")
+	}
+
+	if !force && fileExists(p.RepoDefaultConfigPath) {
+		return fmt.Errorf("configuration file %s already exists. Use --force to overwrite", p.RepoDefaultConfigPath)
+	}
+
+	if err := config.BackupFile(p.BackupDir(), p.RepoDefaultConfigPath); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", p.RepoDefaultConfigPath, err)
+	}
+	if err := cfg.Shareable().SaveToRepoDefault(p); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Println(successStyle.Render("✓ Configuration imported to: " + p.RepoDefaultConfigPath))
+	fmt.Println(infoStyle.Render("Commit this file to share it with your team."))
+	return nil
+}
@@ -47,6 +47,9 @@ func printSuccessSummary(configPath string, cfg *config.Config) {
 }
 
 func handleMissingConfig() error {
+	if err := selectRemote(); err != nil {
+		return err
+	}
 	detectedRepo, _ := git.DetectRepository()
 
 	if detectedRepo != "" && wizard.IsTTY() {
@@ -96,6 +99,9 @@ func handleNoLocalWorkflows() error {
 	fmt.Println(infoStyle.Render("  • The .github/workflows directory doesn't exist"))
 	fmt.Println()
 
+	if err := selectRemote(); err != nil {
+		return err
+	}
 	detectedRepo, _ := git.DetectRepository()
 	if detectedRepo != "" {
 		fmt.Println(wizard.GetInfoStyle().Render("Detected repository: " + detectedRepo))
@@ -164,6 +170,9 @@ func handleNoWorkflows(p *paths.Paths, targetRepo string, useRemoteWorkflows boo
 	}
 
 	if targetRepo == "" {
+		if err := selectRemote(); err != nil {
+			return err
+		}
 		detectedRepo, _ := git.DetectRepository()
 		targetRepo = detectedRepo
 	}
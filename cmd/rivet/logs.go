@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/git"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// logRunSearchLimit is how many recent runs of a workflow --workflow searches
+const logRunSearchLimit = 50
+
+var (
+	logJob        string
+	logFailed     bool
+	logWorkflow   string
+	logConclusion string
+	logBranch     string
+
+	logsCmd = &cobra.Command{
+		Use:   "logs [run-id]",
+		Short: "Print a workflow run's log",
+		Long: `Print the log of a workflow run to stdout so it can be piped into grep or less.
+
+Instead of a run ID, --workflow picks the newest run of a workflow, narrowed
+with --conclusion and --branch (a name or a glob such as release/*). The
+command fails when none of the workflow's recent runs match.
+
+The repository is resolved like the TUI: --repo, then the active repository,
+then the configuration, then the git remote.
+
+Example: rivet logs 1234567890 --job build --failed | less
+         rivet logs --workflow ci.yml --conclusion failure --branch main`,
+		RunE: runLogs,
+		Args: cobra.MaximumNArgs(1),
+	}
+)
+
+func init() {
+	logsCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/repo format)")
+	logsCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+	logsCmd.Flags().StringVar(&remoteName, "remote", "", "Git remote to detect the repository from (default: origin)")
+	logsCmd.Flags().StringVarP(&logJob, "job", "j", "", "Only show the log of the job with this name")
+	logsCmd.Flags().BoolVar(&logFailed, "failed", false, "Only show the log of failed steps")
+	logsCmd.Flags().StringVarP(&logWorkflow, "workflow", "w", "", "Show the newest run of this workflow instead of a run ID")
+	logsCmd.Flags().StringVar(&logConclusion, "conclusion", "", "With --workflow, only runs with this conclusion (e.g., failure)")
+	logsCmd.Flags().StringVar(&logBranch, "branch", "", "With --workflow, only runs on this branch or glob")
+	logsCmd.Flags().IntVar(&timeoutSeconds, "timeout", 30, "GitHub API timeout in seconds")
+
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLogs(_ *cobra.Command, args []string) error {
+	runID := 0
+	switch {
+	case len(args) == 1 && logWorkflow != "":
+		return fmt.Errorf("give a run ID or --workflow, not both")
+	case len(args) == 1:
+		id, err := strconv.Atoi(args[0])
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid run ID '%s'", args[0])
+		}
+		runID = id
+	case logWorkflow == "":
+		return fmt.Errorf("give a run ID or --workflow")
+	}
+	if logWorkflow == "" && (logConclusion != "" || logBranch != "") {
+		return fmt.Errorf("--conclusion and --branch need --workflow")
+	}
+
+	if err := checkGitHubCLI(); err != nil {
+		return err
+	}
+
+	cfg, repository, err := resolveCommandRepository()
+	if err != nil {
+		return err
+	}
+
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	gh := github.NewClientWithTimeout(repository, timeout)
+	gh.SetHost(resolveHost(cfg, repository))
+
+	if logWorkflow != "" {
+		runs, err := gh.GetWorkflowRuns(logWorkflow, logRunSearchLimit)
+		if err != nil {
+			return err
+		}
+		run, err := newestMatchingRun(runs, models.RunFilter{Conclusion: logConclusion, Branch: logBranch})
+		if err != nil {
+			return fmt.Errorf("%s: %w", logWorkflow, err)
+		}
+		runID = run.DatabaseID
+		fmt.Fprintf(os.Stderr, "Run #%d on %s\n", run.DatabaseID, run.HeadBranch)
+	}
+
+	jobID := 0
+	if logJob != "" {
+		jobs, err := gh.GetRunJobs(runID)
+		if err != nil {
+			return err
+		}
+		job, err := findJob(jobs, logJob)
+		if err != nil {
+			return err
+		}
+		jobID = job.DatabaseID
+	}
+
+	return gh.WriteRunLog(context.Background(), runID, jobID, logFailed, os.Stdout)
+}
+
+// resolveCommandRepository finds the repository for commands that run
+// outside the TUI: --repo, the active repository or the configured one, then
+// the git remote. The merged config is returned when one exists.
+func resolveCommandRepository() (*config.Config, string, error) {
+	var cfg *config.Config
+	if p, err := initializePaths(); err == nil {
+		if configPaths := p.GetConfigPaths(); len(configPaths) > 0 {
+			cfg, _ = config.LoadMerged(configPaths)
+		}
+	}
+
+	repository, _ := determineActiveRepository(cfg, loadGlobalState())
+	if repository == "" {
+		if err := selectRemote(); err != nil {
+			return nil, "", err
+		}
+		repository, _ = git.DetectRepository()
+	}
+	if repository == "" {
+		return nil, "", fmt.Errorf("repository must be specified with --repo flag (e.g., --repo owner/repo)")
+	}
+
+	normalized, err := github.NormalizeRepo(repository)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid repository format '%s'. Expected format: [HOST/]OWNER/REPO (e.g., github/cli)", repository)
+	}
+	return cfg, normalized, nil
+}
+
+// newestMatchingRun returns the first of runs, sorted newest first, that
+// passes filter
+func newestMatchingRun(runs []models.GHRun, filter models.RunFilter) (*models.GHRun, error) {
+	matching := models.FilterRuns(runs, filter)
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("none of the last %d runs match", len(runs))
+	}
+	return &matching[0], nil
+}
+
+// findJob returns the job named name, ignoring case
+func findJob(jobs []models.GHJob, name string) (*models.GHJob, error) {
+	names := make([]string, 0, len(jobs))
+	for i := range jobs {
+		if strings.EqualFold(jobs[i].Name, name) {
+			return &jobs[i], nil
+		}
+		names = append(names, jobs[i].Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("run has no jobs")
+	}
+	return nil, fmt.Errorf("no job named '%s'\nAvailable jobs: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+func TestFindJob(t *testing.T) {
+	jobs := []models.GHJob{
+		{DatabaseID: 1, Name: "lint"},
+		{DatabaseID: 2, Name: "Build"},
+	}
+
+	job, err := findJob(jobs, "build")
+	if err != nil {
+		t.Fatalf("findJob() error = %v", err)
+	}
+	if job.DatabaseID != 2 {
+		t.Errorf("findJob() = %d, want 2", job.DatabaseID)
+	}
+
+	if _, err := findJob(jobs, "deploy"); err == nil {
+		t.Error("expected error for unknown job")
+	}
+	if _, err := findJob(nil, "build"); err == nil {
+		t.Error("expected error for run without jobs")
+	}
+}
+
+func TestNewestMatchingRun(t *testing.T) {
+	runs := []models.GHRun{
+		{DatabaseID: 3, Status: "in_progress", HeadBranch: "main"},
+		{DatabaseID: 2, Status: "completed", Conclusion: "failure", HeadBranch: "feature/x"},
+		{DatabaseID: 1, Status: "completed", Conclusion: "failure", HeadBranch: "main"},
+	}
+
+	run, err := newestMatchingRun(runs, models.RunFilter{Conclusion: "failure", Branch: "main"})
+	if err != nil {
+		t.Fatalf("newestMatchingRun() error = %v", err)
+	}
+	if run.DatabaseID != 1 {
+		t.Errorf("newestMatchingRun() = %d, want 1", run.DatabaseID)
+	}
+
+	if run, _ := newestMatchingRun(runs, models.RunFilter{}); run.DatabaseID != 3 {
+		t.Errorf("expected the newest run without a filter, got %d", run.DatabaseID)
+	}
+	if _, err := newestMatchingRun(runs, models.RunFilter{Conclusion: "cancelled"}); err == nil {
+		t.Error("expected an error when no run matches")
+	}
+}
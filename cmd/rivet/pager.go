@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
+)
+
+var noPager bool
+
+// pagerCommand returns the pager to use, following gh: GH_PAGER, then PAGER,
+// then less. An empty result (or "cat") means output is not paged.
+func pagerCommand() string {
+	pager, ok := os.LookupEnv("GH_PAGER")
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		pager = "less"
+	}
+	pager = strings.TrimSpace(pager)
+	if pager == "cat" {
+		return ""
+	}
+	return pager
+}
+
+// writePaged writes content to stdout through the pager when stdout is a
+// terminal and paging is enabled, and writes it directly otherwise
+func writePaged(content string) error {
+	pager := pagerCommand()
+	if noPager || pager == "" || !wizard.IsTTY() {
+		_, err := io.WriteString(os.Stdout, content)
+		return err
+	}
+
+	args := strings.Fields(pager)
+	pagerCmd := exec.Command(args[0], args[1:]...)
+	pagerCmd.Stdout = os.Stdout
+	pagerCmd.Stderr = os.Stderr
+	pagerCmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		// Quit if the content fits on one screen and keep colors, like gh
+		pagerCmd.Env = append(pagerCmd.Env, "LESS=FRX")
+	}
+
+	stdin, err := pagerCmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start pager: %w", err)
+	}
+	if err := pagerCmd.Start(); err != nil {
+		// Fall back to plain output if the pager is missing
+		_, err := io.WriteString(os.Stdout, content)
+		return err
+	}
+
+	// A pager that exits early (e.g. q in less) closes the pipe; that's fine
+	_, _ = io.WriteString(stdin, content)
+	stdin.Close()
+	return pagerCmd.Wait()
+}
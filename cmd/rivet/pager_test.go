@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		ghPager *string
+		pager   *string
+		want    string
+	}{
+		{name: "default", want: "less"},
+		{name: "PAGER", pager: strPtr("more"), want: "more"},
+		{name: "GH_PAGER wins", ghPager: strPtr("bat -p"), pager: strPtr("more"), want: "bat -p"},
+		{name: "empty disables", ghPager: strPtr(""), pager: strPtr("more"), want: ""},
+		{name: "cat disables", pager: strPtr("cat"), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setOrUnsetEnv(t, "GH_PAGER", tt.ghPager)
+			setOrUnsetEnv(t, "PAGER", tt.pager)
+
+			if got := pagerCommand(); got != tt.want {
+				t.Errorf("pagerCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// setOrUnsetEnv sets key to *value, or unsets it when value is nil, for the
+// duration of the test
+func setOrUnsetEnv(t *testing.T, key string, value *string) {
+	t.Helper()
+	t.Setenv(key, "")
+	if value == nil {
+		os.Unsetenv(key)
+		return
+	}
+	os.Setenv(key, *value)
+}
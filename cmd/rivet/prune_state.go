@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
+)
+
+var (
+	pruneAll bool
+
+	pruneStateCmd = &cobra.Command{
+		Use:   "prune-state",
+		Short: "Remove state files of repositories no longer configured",
+		Long: `List the per-repository state files in the state directory and remove the
+ones for repositories that are no longer configured, after a confirmation.
+
+rivet remembers the projects each repository was opened in. A repository is
+still configured when the configs of any of those projects, your user config
+or the config loaded from here name it, or it is the active repository chosen
+with 'rivet switch'. State of repositories not opened since rivet started
+remembering projects is kept, as it may belong to a project rivet does not
+know about. Use --all to remove every per-repository state file.`,
+		RunE: runPruneState,
+		Args: cobra.NoArgs,
+	}
+)
+
+func init() {
+	pruneStateCmd.Flags().BoolVar(&pruneAll, "all", false, "Remove every per-repository state file, including ones other projects may use")
+	pruneStateCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation (required without a TTY)")
+
+	rootCmd.AddCommand(pruneStateCmd)
+}
+
+func runPruneState(_ *cobra.Command, _ []string) error {
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+
+	files, err := p.RepositoryStateFiles()
+	if err != nil {
+		return fmt.Errorf("failed to list state files: %w", err)
+	}
+	if len(files) == 0 {
+		fmt.Println(infoStyle.Render("No state files in " + p.UserStateDir))
+		return nil
+	}
+
+	var stale []string
+	if pruneAll {
+		stale = staleStateFiles(files, nil)
+	} else {
+		gs := loadGlobalState()
+		known := knownStateFiles(files, knownRepositories(gs))
+		stale = staleStateFiles(known, configuredRepositories(p, gs))
+		if unknown := len(files) - len(known); unknown > 0 {
+			fmt.Println(infoStyle.Render(fmt.Sprintf("Keeping %d state files of repositories not opened since rivet started remembering projects; use --all to remove them", unknown)))
+		}
+	}
+	if len(stale) == 0 {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("None of the %d state files belong to a repository known to be unconfigured", len(files))))
+		return nil
+	}
+
+	fmt.Println(labelStyle.Render("State files to remove:"))
+	for _, path := range stale {
+		fmt.Println("  " + path)
+	}
+
+	if !assumeYes {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("cannot confirm in non-interactive mode. Use --yes to remove state files")
+		}
+		confirmed := false
+		if err := wizard.AskConfirm(
+			"Prune state",
+			fmt.Sprintf("Remove %d state files? Saved navigation for those repositories is lost.", len(stale)),
+			&confirmed,
+		); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	removed := 0
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		removed++
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Removed %d state files", removed)))
+	if kept := len(files) - removed; kept > 0 {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("%d state files of configured repositories remain", kept)))
+	}
+	return nil
+}
+
+// configuredRepositories returns the repositories named by the configs
+// loaded from here and by the configs of every project in gs, and the active
+// repository
+func configuredRepositories(p *paths.Paths, gs *state.GlobalState) []string {
+	configPaths := p.GetConfigPaths()
+	for root := range gs.Projects {
+		if root == "" || root == p.ProjectRoot {
+			continue
+		}
+		if project, err := paths.NewWithProject(root); err == nil {
+			configPaths = append(configPaths, project.GetConfigPaths()...)
+		}
+	}
+
+	var repos []string
+	for _, path := range configPaths {
+		if cfg, err := config.LoadFromPath(path); err == nil && cfg.Repository != "" {
+			repos = append(repos, cfg.Repository)
+		}
+	}
+	for _, active := range gs.ActiveRepositories {
+		repos = append(repos, active)
+	}
+	return repos
+}
+
+// knownRepositories returns every repository gs recorded as opened in a
+// project
+func knownRepositories(gs *state.GlobalState) []string {
+	var repos []string
+	for _, projectRepos := range gs.Projects {
+		repos = append(repos, projectRepos...)
+	}
+	return repos
+}
+
+// recordProject remembers that repo was opened in the current project, so
+// prune-state can later check that project's configs before removing its state
+func recordProject(repo string) {
+	p, err := paths.New()
+	if err != nil {
+		return
+	}
+	_ = state.RecordProject(p, currentProjectRoot(), repo)
+}
+
+// knownStateFiles returns the state files, keyed by owner/repo, of the
+// known repositories
+func knownStateFiles(files map[string]string, known []string) map[string]string {
+	keys := repositoryKeys(known)
+	subset := make(map[string]string)
+	for repo, path := range files {
+		if keys[strings.ToLower(repo)] {
+			subset[repo] = path
+		}
+	}
+	return subset
+}
+
+// staleStateFiles returns the paths of the state files, keyed by owner/repo,
+// that belong to none of the keep repositories. A host prefix on a kept
+// repository is ignored, like in the state file names.
+func staleStateFiles(files map[string]string, keep []string) []string {
+	kept := repositoryKeys(keep)
+
+	var stale []string
+	for repo, path := range files {
+		if !kept[strings.ToLower(repo)] {
+			stale = append(stale, path)
+		}
+	}
+	slices.Sort(stale)
+	return stale
+}
+
+// repositoryKeys returns the lowercase owner/repo of each repository
+func repositoryKeys(repos []string) map[string]bool {
+	keys := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		parts := strings.Split(repo, "/")
+		if len(parts) >= 2 {
+			keys[strings.ToLower(strings.Join(parts[len(parts)-2:], "/"))] = true
+		}
+	}
+	return keys
+}
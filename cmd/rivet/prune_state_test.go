@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+)
+
+func TestStaleStateFiles(t *testing.T) {
+	dir := t.TempDir()
+	p := &paths.Paths{UserStateDir: dir}
+	for _, name := range []string{
+		"team_app.state.yaml",
+		"me_old_tool.state.yaml",
+		"Other_Repo.state.yaml",
+		paths.StateFileName,
+		paths.GlobalStateFileName,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := p.RepositoryStateFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 || files["me/old_tool"] == "" {
+		t.Fatalf("expected the three per-repository files, got %v", files)
+	}
+
+	got := staleStateFiles(files, []string{"team/app", "ghe.example.com/other/repo"})
+	want := []string{filepath.Join(dir, "me_old_tool.state.yaml")}
+	if !slices.Equal(got, want) {
+		t.Errorf("staleStateFiles() = %v, want %v", got, want)
+	}
+
+	if got := staleStateFiles(files, nil); len(got) != 3 {
+		t.Errorf("expected every file to be stale with nothing kept, got %v", got)
+	}
+}
+
+func TestConfiguredRepositoriesOtherProjects(t *testing.T) {
+	tmpDir := t.TempDir()
+	paths.SetBaseDir(filepath.Join(tmpDir, "home"))
+	t.Cleanup(func() { paths.SetBaseDir("") })
+
+	projectConfig := func(name, repo string) string {
+		root := filepath.Join(tmpDir, name)
+		path := filepath.Join(root, ".github", paths.LegacyConfigFileName)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("repository: "+repo+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return root
+	}
+	here := projectConfig("here", "team/app")
+	other := projectConfig("other", "team/api")
+	gone := filepath.Join(tmpDir, "gone")
+
+	p, err := paths.NewWithProject(here)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := &state.GlobalState{
+		ActiveRepositories: map[string]string{here: "me/fork"},
+		Projects: map[string][]string{
+			here:  {"team/app"},
+			other: {"team/api"},
+			gone:  {"me/old_tool"},
+		},
+	}
+
+	configured := configuredRepositories(p, gs)
+	slices.Sort(configured)
+	if want := []string{"me/fork", "team/api", "team/app"}; !slices.Equal(configured, want) {
+		t.Errorf("configuredRepositories() = %v, want %v", configured, want)
+	}
+
+	files := map[string]string{
+		"team/api":    "team_api.state.yaml",
+		"me/old_tool": "me_old_tool.state.yaml",
+		"me/unseen":   "me_unseen.state.yaml",
+	}
+	known := knownStateFiles(files, knownRepositories(gs))
+	if got := staleStateFiles(known, configured); !slices.Equal(got, []string{"me_old_tool.state.yaml"}) {
+		t.Errorf("expected only the state of the removed project's repository to be stale, got %v", got)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/git"
+	"github.com/Cloudsky01/gh-rivet/internal/tui"
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
+)
+
+// remoteChosen records that the detection remote was already picked during
+// this invocation, so the user is asked at most once
+var remoteChosen bool
+
+// selectRemote picks the git remote used to detect the repository. The
+// --remote flag wins; otherwise, when several remotes point at a GitHub host
+// and a TTY is available, the user picks one in a small TUI list with origin
+// preselected. Remotes on other hosts, such as GitLab, are not offered.
+func selectRemote() error {
+	if remoteChosen {
+		return nil
+	}
+	remoteChosen = true
+
+	if remoteName != "" {
+		git.SetRemote(remoteName)
+		return nil
+	}
+
+	remotes, err := git.ListRemotes(enterpriseHosts()...)
+	if err != nil || len(remotes) < 2 || !wizard.IsTTY() {
+		return nil
+	}
+
+	choice, err := tui.PickRemote(remotes, git.DefaultRemote)
+	if err != nil {
+		return err
+	}
+	git.SetRemote(choice)
+	return nil
+}
+
+// enterpriseHosts returns the GitHub Enterprise Server hosts rivet was told
+// about: --host, $GH_HOST and preferences.host
+func enterpriseHosts() []string {
+	var hosts []string
+	for _, h := range []string{host, os.Getenv("GH_HOST")} {
+		if h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	if p, err := initializePaths(); err == nil {
+		if configPaths := p.GetConfigPaths(); len(configPaths) > 0 {
+			if cfg, err := config.LoadMerged(configPaths); err == nil && cfg.GetHost() != "" {
+				hosts = append(hosts, cfg.GetHost())
+			}
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/tui"
+)
+
+var (
+	recordPath string
+	replayPath string
+)
+
+func init() {
+	rootCmd.Flags().StringVar(&recordPath, "record", "", "Record key presses and terminal resizes to a session file for bug reports")
+	rootCmd.Flags().StringVar(&replayPath, "replay", "", "Replay a recorded session without the TUI and print the final screen")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+}
+
+// replaySession drives the TUI with a recorded session and prints the final
+// frame. Pins and navigation state go to a temporary directory so replaying
+// never changes the real config or state.
+func replaySession(cfg *config.Config, configPath string, gh *github.Client, opts tui.AppOptions) error {
+	session, err := tui.LoadSession(replayPath)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "rivet-replay-")
+	if err != nil {
+		return fmt.Errorf("failed to create replay directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	opts.StatePath = filepath.Join(tmpDir, "state.yaml")
+	opts.PinConfigPath = filepath.Join(tmpDir, "config.yaml")
+	opts.NoRestoreState = true
+
+	app := tui.NewApp(cfg, configPath, gh, opts)
+	fmt.Println(tui.Replay(app, session))
+	return nil
+}
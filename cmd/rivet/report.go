@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+// reportLogLines is how many lines of the debug log a report includes
+const reportLogLines = 50
+
+var reportCmd = &cobra.Command{
+	Use:   "report [path]",
+	Short: "Write a diagnostics report to attach to a bug report",
+	Long: `Write a markdown report of rivet's version, the GitHub CLI it runs, where
+each config setting comes from, and the tail of the debug log (see --debug),
+for attaching to a GitHub issue. The default path is rivet-report.md.
+
+Config values are left out: only which file or environment variable sets each
+key is listed. Tokens and passwords in the debug log are redacted and the
+home directory is shown as ~, but read the report before sharing it.`,
+	RunE: runReport,
+	Args: cobra.MaximumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReport(_ *cobra.Command, args []string) error {
+	out := "rivet-report.md"
+	if len(args) > 0 {
+		out = args[0]
+	}
+
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("# rivet diagnostics\n\n")
+	writeVersionSection(&b)
+	writeGHSection(&b)
+	writeConfigSection(&b, p)
+	writeLogSection(&b, p.DebugLogFile())
+
+	home, _ := os.UserHomeDir()
+	if err := os.WriteFile(out, []byte(redact(b.String(), home)), 0600); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Println(successStyle.Render("✓ Wrote " + out))
+	fmt.Println(infoStyle.Render("Check it for anything private before attaching it to an issue"))
+	return nil
+}
+
+func writeVersionSection(b *strings.Builder) {
+	b.WriteString("## Version\n\n")
+	fmt.Fprintf(b, "- rivet: %s (commit %s, built %s)\n", version, commit, date)
+	fmt.Fprintf(b, "- Go: %s %s/%s\n\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// writeGHSection reports the gh executable, its version and whether it is
+// logged in. The output of gh auth status names the account and token
+// scopes, so only its success is reported.
+func writeGHSection(b *strings.Builder) {
+	b.WriteString("## GitHub CLI\n\n")
+	gh := github.GHPath()
+	resolved, err := exec.LookPath(gh)
+	if err != nil {
+		fmt.Fprintf(b, "- gh: `%s` not found\n\n", gh)
+		return
+	}
+	fmt.Fprintf(b, "- gh: `%s`\n", resolved)
+
+	if output, err := exec.Command(gh, "--version").Output(); err == nil {
+		first, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+		fmt.Fprintf(b, "- Version: %s\n", first)
+	}
+	authenticated := "yes"
+	if err := exec.Command(gh, "auth", "status").Run(); err != nil {
+		authenticated = "no"
+	}
+	fmt.Fprintf(b, "- Authenticated: %s\n\n", authenticated)
+}
+
+// writeConfigSection lists the config tiers and the source of every key the
+// merged config sets, without their values
+func writeConfigSection(b *strings.Builder, p *paths.Paths) {
+	b.WriteString("## Configuration\n\n")
+	tiers := []struct {
+		label, path string
+	}{
+		{"Repository default", p.RepoDefaultConfigPath},
+		{"User config", p.UserConfigFile()},
+		{"Project user config", p.ProjectUserConfigPath},
+	}
+	for _, tier := range tiers {
+		if tier.path == "" {
+			fmt.Fprintf(b, "- %s: not in a git repository\n", tier.label)
+			continue
+		}
+		state := "missing"
+		if fileExists(tier.path) {
+			state = "present"
+		}
+		fmt.Fprintf(b, "- %s: `%s` (%s)\n", tier.label, tier.path, state)
+	}
+	b.WriteString("\n")
+
+	configPaths := p.GetConfigPaths()
+	if len(configPaths) == 0 {
+		b.WriteString("No config files found.\n\n")
+		return
+	}
+	cfg, err := config.LoadMerged(configPaths)
+	if err != nil {
+		fmt.Fprintf(b, "Config does not load: %v\n\n", err)
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(b, "Config does not validate: %v\n\n", err)
+	}
+
+	b.WriteString("| Setting | Source |\n|---|---|\n")
+	for _, key := range cfg.DefinedKeys() {
+		source, _ := cfg.SourceOf(key)
+		fmt.Fprintf(b, "| %s | %s |\n", key, tierLabel(p, source))
+	}
+	b.WriteString("\n")
+}
+
+func writeLogSection(b *strings.Builder, logPath string) {
+	fmt.Fprintf(b, "## Debug log\n\n")
+	lines, err := tailLines(logPath, reportLogLines)
+	if err != nil {
+		b.WriteString("No debug log. Run `rivet --debug`, reproduce the problem, then write the report again.\n")
+		return
+	}
+	fmt.Fprintf(b, "Last %d lines of `%s`:\n\n```\n%s\n```\n", len(lines), logPath, strings.Join(lines, "\n"))
+}
+
+// tailLines returns the last n lines of the file at path
+func tailLines(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
+
+var (
+	// tokenPattern matches GitHub tokens: classic ones such as ghp_ and
+	// gho_, and fine-grained personal access tokens
+	tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{16,}|github_pat_[A-Za-z0-9_]{16,})\b`)
+	// secretPattern matches values given for keys that name a secret, such
+	// as GH_TOKEN=abc or "Authorization: Bearer abc"
+	secretPattern = regexp.MustCompile(`(?i)(token|password|passwd|secret|authorization)(["']?\s*[=:]\s*)("[^"]*"|((bearer|basic|token)\s+)?\S+)`)
+)
+
+// redact removes tokens and secrets from text and shows the home directory
+// as ~
+func redact(text, home string) string {
+	text = tokenPattern.ReplaceAllString(text, "[REDACTED]")
+	text = secretPattern.ReplaceAllString(text, "$1$2[REDACTED]")
+	if home != "" && home != "/" {
+		text = strings.ReplaceAll(text, home, "~")
+	}
+	return text
+}
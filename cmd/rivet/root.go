@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,15 +29,30 @@ var (
 	date    = "unknown"
 )
 
+// ghPathEnv names the environment variable that overrides the ghPath preference
+const ghPathEnv = "RIVET_GH_PATH"
+
+// localWorkflowDir is where workflows are discovered, relative to the repository root
+const localWorkflowDir = ".github/workflows"
+
 var (
 	configPath      string
+	configDir       string
 	repo            string
+	host            string
+	remoteName      string
 	force           bool
 	reset           bool
+	assumeYes       bool
 	statePath       string
 	noState         bool
 	timeoutSeconds  int
 	refreshInterval int
+	since           string
+	layout          string
+	pinnedIndex     int
+	openWorkflow    string
+	noAutoOpen      bool
 
 	rootCmd = &cobra.Command{
 		Use:   "rivet",
@@ -65,12 +83,21 @@ Get started:  rivet init`,
 )
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", "", "Directory for the user config, with state and cache beneath it (overrides XDG directories)")
 	rootCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to configuration file (default: auto-detect)")
 	rootCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/repo format)")
+	rootCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+	rootCmd.Flags().StringVar(&remoteName, "remote", "", "Git remote to detect the repository from (default: origin)")
 	rootCmd.Flags().StringVar(&statePath, "state", "", "Path to state file")
 	rootCmd.Flags().BoolVar(&noState, "no-state", false, "Disable state persistence")
 	rootCmd.Flags().IntVar(&timeoutSeconds, "timeout", 30, "GitHub API timeout in seconds")
 	rootCmd.Flags().IntVar(&refreshInterval, "refresh-interval", 0, "Auto-refresh interval in seconds (0 = disabled, min 5)")
+	rootCmd.Flags().StringVar(&layout, "layout", "", "TUI layout: modern, or classic with a details panel (default: preferences.layout or modern)")
+	rootCmd.Flags().StringVar(&since, "since", "", "Show status badges only for workflows active within this window (e.g. 24h, 7d)")
+	rootCmd.Flags().IntVar(&pinnedIndex, "pinned", 0, "Open the runs of the Nth pinned workflow, counting from 1 in sidebar order")
+	rootCmd.Flags().StringVar(&openWorkflow, "workflow", "", "Open the runs of this workflow file (e.g. build.yml)")
+	rootCmd.Flags().BoolVar(&noAutoOpen, "no-auto", false, "Start in the group list even when the config has a single workflow")
+	rootCmd.MarkFlagsMutuallyExclusive("pinned", "workflow")
 
 	originalRootHelpFunc := rootCmd.HelpFunc()
 	originalInitHelpFunc := initCmd.HelpFunc()
@@ -89,10 +116,15 @@ func init() {
 
 	initCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to save configuration file (default: user config)")
 	initCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing config")
-	initCmd.Flags().BoolVar(&reset, "reset", false, "Delete existing config and create new one")
+	initCmd.Flags().BoolVar(&reset, "reset", false, "Delete the existing config at the save location and create a new one")
+	initCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip confirmation prompts (required for --reset without a TTY)")
 	initCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/repo) to fetch workflows from")
+	initCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+	initCmd.Flags().StringVar(&remoteName, "remote", "", "Git remote to detect the repository from (default: origin)")
 
 	updateRepoCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to configuration file (default: auto-detect)")
+	updateRepoCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+	updateRepoCmd.Flags().StringVar(&remoteName, "remote", "", "Git remote to detect the repository from (default: origin)")
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(updateRepoCmd)
@@ -100,11 +132,11 @@ func init() {
 }
 
 func checkGitHubCLI() error {
-	if _, err := exec.LookPath("gh"); err != nil {
+	if _, err := exec.LookPath(github.GHPath()); err != nil {
 		return fmt.Errorf("GitHub CLI not installed\nInstall: https://cli.github.com/ or 'brew install gh'")
 	}
 
-	cmd := exec.Command("gh", "auth", "status")
+	cmd := exec.Command(github.GHPath(), "auth", "status")
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("GitHub CLI not authenticated\nRun: gh auth login")
 	}
@@ -113,8 +145,11 @@ func checkGitHubCLI() error {
 }
 
 func runView(cmd *cobra.Command, _ []string) error {
-	if err := checkGitHubCLI(); err != nil {
-		return err
+	// Replay never runs gh, so it works without an authenticated CLI
+	if replayPath == "" {
+		if err := checkGitHubCLI(); err != nil {
+			return err
+		}
 	}
 
 	if cmd.Flags().Changed("config") {
@@ -125,7 +160,7 @@ func runView(cmd *cobra.Command, _ []string) error {
 		if err := cfg.Validate(); err != nil {
 			return fmt.Errorf("invalid configuration: %w", err)
 		}
-		return runViewWithConfig(cfg, configPath)
+		return runViewWithConfig(cfg, []string{configPath}, []string{configPath})
 	}
 
 	projectRoot, _ := git.GetGitRepositoryRoot()
@@ -149,31 +184,131 @@ func runView(cmd *cobra.Command, _ []string) error {
 		if err := cfg.Validate(); err != nil {
 			return fmt.Errorf("invalid configuration: %w", err)
 		}
-		primaryPath := configPaths[len(configPaths)-1]
-		return runViewWithConfig(cfg, primaryPath)
+		return runViewWithConfig(cfg, configPaths, personalConfigPaths(p))
 	}
 
 	return handleMissingConfig()
 }
 
-func runViewWithConfig(cfg *config.Config, configPath string) error {
+// personalConfigPath returns the user-tier config that personal changes such as
+// pins are written to: the project user config inside a git repository,
+// otherwise the user global config.
+func personalConfigPath(p *paths.Paths) string {
+	if p.ProjectUserConfigPath != "" {
+		return p.ProjectUserConfigPath
+	}
+	return p.UserConfigFile()
+}
+
+// personalConfigPaths returns the personal config tiers, lowest precedence
+// first: the user global config when it exists, then personalConfigPath.
+// Only the repository default config is shared, so a pin in any of these can
+// be removed.
+func personalConfigPaths(p *paths.Paths) []string {
+	target := personalConfigPath(p)
+	var tiers []string
+	if user := p.UserConfigFile(); user != target && fileExists(user) {
+		tiers = append(tiers, user)
+	}
+	return append(tiers, target)
+}
+
+// resolveHost picks the GitHub host for gh commands: the --host flag, then
+// preferences.host, then a host prefix on the repository. An empty result
+// means gh's default host.
+func resolveHost(cfg *config.Config, repo string) string {
+	if host != "" {
+		return host
+	}
+	if cfg != nil && cfg.GetHost() != "" {
+		return cfg.GetHost()
+	}
+	repoHost, _ := git.SplitRepository(repo)
+	return repoHost
+}
+
+// parseSince parses the --since window. It accepts Go durations plus a
+// day suffix, so both "36h" and "7d" work. An empty value disables it.
+func parseSince(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since value '%s'. Use a duration such as 24h or 7d", value)
+	}
+	return d, nil
+}
+
+// runViewWithConfig starts the TUI with cfg, merged from configPaths in order
+// of precedence. The last path is the primary config. Pins are toggled in
+// pinTiers, and new pins are written to the last of them.
+func runViewWithConfig(cfg *config.Config, configPaths []string, pinTiers []string) error {
+	configPath := configPaths[len(configPaths)-1]
+
+	explicitRepo := repo != ""
 	if repo == "" {
-		repo = cfg.Repository
+		var source string
+		repo, source = determineActiveRepository(cfg, loadGlobalState())
 		if repo != "" {
-			fmt.Println(infoStyle.Render("Using repository from config: " + repo))
+			fmt.Println(infoStyle.Render("Using " + source + ": " + repo))
+		}
+	}
+
+	if repo == "" && wizard.IsTTY() {
+		picked, err := promptMissingRepository(cfg, configPath)
+		if err != nil {
+			return err
 		}
+		repo = picked
 	}
 
 	if repo == "" {
 		return fmt.Errorf("repository must be specified with --repo flag (e.g., --repo owner/repo)")
 	}
 
-	if !git.RepositoryFormatRegex.MatchString(repo) {
-		return fmt.Errorf("invalid repository format '%s'. Expected format: OWNER/REPO (e.g., github/cli)", repo)
+	normalized, err := github.NormalizeRepo(repo)
+	if err != nil {
+		return fmt.Errorf("invalid repository format '%s'. Expected format: [HOST/]OWNER/REPO (e.g., github/cli)", repo)
 	}
+	repo = normalized
+	recordProject(repo)
 
 	timeout := time.Duration(timeoutSeconds) * time.Second
 	gh := github.NewClientWithTimeout(repo, timeout)
+	gh.SetHost(resolveHost(cfg, repo))
+
+	// Show the repository actually being viewed; the merged config is never
+	// written back, so this does not change any config file
+	cfg.Repository = repo
+
+	sinceWindow, err := parseSince(since)
+	if err != nil {
+		return err
+	}
+
+	if err := validatePinnedIndex(cfg, pinnedIndex); err != nil {
+		return err
+	}
+	if err := validateOpenWorkflow(cfg, openWorkflow); err != nil {
+		return err
+	}
+
+	if err := config.ValidateLayout(layout); err != nil {
+		return err
+	}
+	tuiLayout := layout
+	if tuiLayout == "" {
+		tuiLayout = cfg.GetLayout()
+	}
 
 	interval := refreshInterval
 	if interval == 0 && cfg.GetRefreshInterval() > 0 {
@@ -181,9 +316,30 @@ func runViewWithConfig(cfg *config.Config, configPath string) error {
 	}
 
 	opts := tui.AppOptions{
-		StatePath:       statePath,
-		NoRestoreState:  noState,
-		RefreshInterval: interval,
+		StatePath:           statePath,
+		NoRestoreState:      noState,
+		RefreshInterval:     interval,
+		ConfigPaths:         configPaths,
+		PinConfigPath:       pinTiers[len(pinTiers)-1],
+		PersonalConfigPaths: pinTiers,
+		Since:               sinceWindow,
+		Layout:              tuiLayout,
+		RecordPath:          recordPath,
+		OpenPinned:          pinnedIndex,
+		OpenWorkflow:        openWorkflow,
+		NoAutoOpen:          noAutoOpen,
+	}
+
+	if replayPath != "" {
+		return replaySession(cfg, configPath, gh, opts)
+	}
+
+	// An explicit --repo is what the user asked for, so only a repository
+	// picked some other way is compared with the current directory's
+	if !explicitRepo {
+		if local, err := git.DetectRepository(); err == nil {
+			opts.LocalRepository = local
+		}
 	}
 
 	app := tui.NewApp(cfg, configPath, gh, opts)
@@ -191,15 +347,95 @@ func runViewWithConfig(cfg *config.Config, configPath string) error {
 		return err
 	}
 
+	if next := app.SwitchRepository(); next != "" {
+		repo = next
+		return runViewWithConfig(cfg, configPaths, pinTiers)
+	}
+	return nil
+}
+
+// validatePinnedIndex checks that --pinned names one of the pinned
+// workflows. 0 means the flag was not given.
+func validatePinnedIndex(cfg *config.Config, index int) error {
+	if index == 0 {
+		return nil
+	}
+	count := len(cfg.GetAllPinnedWorkflows())
+	switch {
+	case count == 0:
+		return fmt.Errorf("invalid --pinned %d: no workflows are pinned. Press p on a workflow in the TUI to pin it", index)
+	case index < 0 || index > count:
+		return fmt.Errorf("invalid --pinned %d: expected 1-%d for the %d pinned workflows", index, count, count)
+	}
 	return nil
 }
 
+// validateOpenWorkflow checks that --workflow names a configured workflow
+// file. An empty workflow means the flag was not given.
+func validateOpenWorkflow(cfg *config.Config, workflow string) error {
+	if workflow == "" {
+		return nil
+	}
+	files, _ := cfg.WorkflowFiles()
+	if !slices.Contains(files, workflow) {
+		return fmt.Errorf("invalid --workflow %s: it is not in any group. Expected a workflow file such as build.yml", workflow)
+	}
+	return nil
+}
+
+// promptMissingRepository asks for the repository to view when the config
+// has none, suggesting the current directory's. The validated repository is
+// saved to the config at configPath, like update-repo. It returns an empty
+// string when the prompt is cancelled.
+func promptMissingRepository(cfg *config.Config, configPath string) (string, error) {
+	suggestion, _ := git.DetectRepository()
+
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	picked, err := tui.PromptRepository(suggestion, func(input string) (string, error) {
+		normalized, err := github.NormalizeRepo(input)
+		if err != nil {
+			return "", err
+		}
+		ghClient := github.NewClientWithTimeout("", timeout)
+		ghClient.SetHost(resolveHost(cfg, normalized))
+		exists, err := ghClient.RepositoryExists(context.Background(), normalized)
+		if err != nil {
+			return "", fmt.Errorf("failed to validate repository %s: %w", normalized, err)
+		}
+		if !exists {
+			return "", fmt.Errorf("repository %s not found", normalized)
+		}
+		return normalized, nil
+	})
+	if err != nil || picked == "" {
+		return "", err
+	}
+
+	saved, err := config.LoadFromPath(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config from %s: %w", configPath, err)
+	}
+	saved.Repository = picked
+	if err := saved.Save(configPath); err != nil {
+		return "", fmt.Errorf("failed to save configuration: %w", err)
+	}
+	fmt.Println(successStyle.Render("✓ Repository set to: " + picked))
+	fmt.Println(infoStyle.Render("Configuration saved to: " + configPath))
+	return picked, nil
+}
+
 func runInit(cmd *cobra.Command, _ []string) error {
 	p, err := initializePaths()
 	if err != nil {
 		return err
 	}
 
+	if repo == "" {
+		if err := selectRemote(); err != nil {
+			return err
+		}
+	}
+
 	explicitConfigPath := cmd != nil && cmd.Flags().Changed("config")
 	savePathHint := determineSavePathHint(p, explicitConfigPath)
 
@@ -216,13 +452,14 @@ func runInit(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	applyWorkflowNames(cfg, workflows, useRemoteWorkflows)
 
 	targetPath, location, err := determineConfigSaveTarget(p, explicitConfigPath, configPath, configType)
 	if err != nil {
 		return err
 	}
 
-	if err := validateConfigOverwrite(targetPath); err != nil {
+	if err := validateConfigOverwrite(p, targetPath); err != nil {
 		return err
 	}
 
@@ -234,6 +471,73 @@ func runInit(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// applyConfigDir relocates every user directory under --config-dir. It
+// runs before any command builds its paths.
+func applyConfigDir() error {
+	if configDir == "" {
+		return nil
+	}
+	dir, err := filepath.Abs(configDir)
+	if err != nil {
+		return fmt.Errorf("invalid --config-dir '%s': %w", configDir, err)
+	}
+	paths.SetBaseDir(dir)
+	return nil
+}
+
+// applyGHPath points every gh invocation at $RIVET_GH_PATH or the ghPath
+// preference, checking up front that it can be run
+func applyGHPath() error {
+	path, source := os.Getenv(ghPathEnv), "$"+ghPathEnv
+	if path == "" {
+		path, source = configuredGHPath(), "the ghPath preference"
+	}
+	if path == "" {
+		return nil
+	}
+	if err := github.ValidateGHPath(path); err != nil {
+		return fmt.Errorf("gh path '%s' from %s is not executable: %w", path, source, err)
+	}
+	github.SetGHPath(path)
+	return nil
+}
+
+// configuredGHPath reads the ghPath preference from --config or the merged
+// config tiers. Config errors are left for the command itself to report.
+func configuredGHPath() string {
+	configPaths := []string{configPath}
+	repoDefault := ""
+	if configPath == "" {
+		p, err := initializePaths()
+		if err != nil {
+			return ""
+		}
+		configPaths = p.GetConfigPaths()
+		repoDefault = p.RepoDefaultConfigPath
+	}
+	if len(configPaths) == 0 {
+		return ""
+	}
+	cfg, err := config.LoadMerged(configPaths)
+	if err != nil {
+		return ""
+	}
+	return trustedGHPath(cfg, repoDefault, os.Stderr)
+}
+
+// trustedGHPath returns the ghPath preference unless it comes from the
+// repository default config at repoDefault. That file is committed with the
+// repository, so honoring it would let anyone who can push run a program of
+// their choosing; it is ignored with a warning written to w instead.
+func trustedGHPath(cfg *config.Config, repoDefault string, w io.Writer) string {
+	path := cfg.GetGHPath()
+	if source, _ := cfg.SourceOf("preferences.ghPath"); path != "" && repoDefault != "" && source == repoDefault {
+		fmt.Fprintf(w, "Warning: ignoring ghPath from %s; set it in your user config or $%s instead\n", source, ghPathEnv)
+		return ""
+	}
+	return path
+}
+
 func initializePaths() (*paths.Paths, error) {
 	projectRoot, _ := git.GetGitRepositoryRoot()
 
@@ -278,15 +582,18 @@ func discoverWorkflows() ([]string, bool, error) {
 }
 
 func fetchRemoteWorkflows() ([]string, error) {
-	if err := git.ValidateRepositoryFormat(repo); err != nil {
+	normalized, err := github.NormalizeRepo(repo)
+	if err != nil {
 		return nil, err
 	}
+	repo = normalized
 
 	timeout := time.Duration(timeoutSeconds) * time.Second
 	ghClient := github.NewClientWithTimeout("", timeout)
+	ghClient.SetHost(resolveHost(nil, repo))
 	ctx := context.Background()
 
-	_, err := wizard.RunWithSpinner(ctx, fmt.Sprintf("Validating repository %s", repo), func() (any, error) {
+	_, err = wizard.RunWithSpinner(ctx, fmt.Sprintf("Validating repository %s", repo), func() (any, error) {
 		exists, err := ghClient.RepositoryExists(ctx, repo)
 		if err != nil {
 			return nil, err
@@ -311,14 +618,51 @@ func fetchRemoteWorkflows() ([]string, error) {
 }
 
 func discoverLocalWorkflows() ([]string, error) {
-	workflowDir := ".github/workflows"
-	workflows, err := wizard.DiscoverWorkflows(workflowDir)
+	workflows, err := wizard.DiscoverWorkflows(localWorkflowDir)
 	if err != nil {
 		return nil, handleNoLocalWorkflows()
 	}
 	return workflows, nil
 }
 
+// applyWorkflowNames names the configured workflows after the name: fields of
+// their workflow files. Names are cosmetic, so when they cannot be read the
+// file names are kept.
+func applyWorkflowNames(cfg *config.Config, workflows []string, useRemoteWorkflows bool) {
+	workflowDir, repository := localWorkflowDir, ""
+	if useRemoteWorkflows {
+		workflowDir, repository = "", repo
+	}
+
+	names, err := workflowNames(workflows, workflowDir, repository)
+	if err != nil {
+		fmt.Println(wizard.GetWarnStyle().Render(fmt.Sprintf("⚠ Could not read workflow names, showing file names: %v", err)))
+		return
+	}
+	cfg.SetWorkflowNames(names)
+}
+
+// workflowNames reads the name: field of each workflow in files from
+// workflowDir, or from the GitHub API for repository when workflowDir is empty
+func workflowNames(files []string, workflowDir, repository string) (map[string]string, error) {
+	if workflowDir != "" {
+		return wizard.WorkflowNames(workflowDir, files), nil
+	}
+
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	ghClient := github.NewClientWithTimeout("", timeout)
+	ghClient.SetHost(resolveHost(nil, repository))
+	ctx := context.Background()
+
+	result, err := wizard.RunWithSpinner(ctx, fmt.Sprintf("Fetching workflow names from %s", repository), func() (any, error) {
+		return ghClient.GetWorkflowNames(ctx, repository)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]string), nil
+}
+
 func runConfigWizard(workflows []string, savePathHint string, useRemoteWorkflows bool) (*config.Config, string, error) {
 	w := wizard.New(workflows, savePathHint)
 
@@ -335,14 +679,9 @@ func runConfigWizard(workflows []string, savePathHint string, useRemoteWorkflows
 	return cfg, w.GetConfigType(), nil
 }
 
-func validateConfigOverwrite(targetPath string) error {
+func validateConfigOverwrite(p *paths.Paths, targetPath string) error {
 	if reset {
-		if err := os.Remove(targetPath); err == nil {
-			fmt.Println(infoStyle.Render("Removed existing config: " + targetPath))
-		} else if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to remove existing config at %s: %w", targetPath, err)
-		}
-		return nil
+		return resetExistingConfigs(p, targetPath)
 	}
 
 	if !force {
@@ -356,7 +695,77 @@ func validateConfigOverwrite(targetPath string) error {
 	return nil
 }
 
+// otherConfigTiers returns the loaded config files other than the save
+// target. --reset leaves them in place; they are listed so the user knows
+// they still apply.
+func otherConfigTiers(p *paths.Paths, targetPath string) []string {
+	var others []string
+	for _, path := range p.GetConfigPaths() {
+		if path != targetPath {
+			others = append(others, path)
+		}
+	}
+	return others
+}
+
+func resetExistingConfigs(p *paths.Paths, targetPath string) error {
+	others := otherConfigTiers(p, targetPath)
+	if !fileExists(targetPath) {
+		printUntouchedTiers(p, others)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(wizard.GetWarnStyle().Render("⚠ --reset will delete the configuration file:"))
+	fmt.Println(infoStyle.Render(fmt.Sprintf("  • %s (%s)", targetPath, p.GetConfigSource(targetPath))))
+	printUntouchedTiers(p, others)
+	fmt.Println()
+
+	if !assumeYes {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("refusing to delete configuration without confirmation. Re-run with --yes to confirm")
+		}
+
+		confirmed := false
+		if err := wizard.AskConfirm(
+			"Delete existing configuration",
+			fmt.Sprintf("Delete %s?", targetPath),
+			&confirmed,
+		); err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("reset cancelled")
+		}
+	}
+
+	if err := config.BackupFile(p.BackupDir(), targetPath); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", targetPath, err)
+	}
+	if err := os.Remove(targetPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove existing config at %s: %w", targetPath, err)
+	}
+	fmt.Println(infoStyle.Render("Removed existing config: " + targetPath))
+
+	return nil
+}
+
+func printUntouchedTiers(p *paths.Paths, others []string) {
+	if len(others) == 0 {
+		return
+	}
+	fmt.Println(infoStyle.Render("These configuration files are left in place and still apply:"))
+	for _, path := range others {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("  • %s (%s)", path, p.GetConfigSource(path))))
+	}
+}
+
 func saveConfigToLocation(cfg *config.Config, targetPath string, location configSaveLocation, p *paths.Paths) error {
+	// --force overwrites an existing config; keep a copy to restore
+	if err := config.BackupFile(p.BackupDir(), targetPath); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", targetPath, err)
+	}
+
 	switch location {
 	case saveLocationTeam:
 		if err := cfg.SaveToRepoDefault(p); err != nil {
@@ -412,8 +821,11 @@ func runUpdateRepo(cmd *cobra.Command, args []string) error {
 	var newRepo string
 
 	if len(args) > 0 {
-		newRepo = strings.TrimSpace(args[0])
+		newRepo = args[0]
 	} else {
+		if err := selectRemote(); err != nil {
+			return err
+		}
 		detectedRepo, err := git.DetectRepository()
 		if err != nil || detectedRepo == "" {
 			return fmt.Errorf("could not detect repository from .git/config and no repository provided\nUsage: rivet update-repo owner/repo")
@@ -422,12 +834,14 @@ func runUpdateRepo(cmd *cobra.Command, args []string) error {
 		fmt.Println(infoStyle.Render("Detected repository: " + newRepo))
 	}
 
-	if err := git.ValidateRepositoryFormat(newRepo); err != nil {
+	newRepo, err = github.NormalizeRepo(newRepo)
+	if err != nil {
 		return err
 	}
 
 	timeout := time.Duration(timeoutSeconds) * time.Second
 	ghClient := github.NewClientWithTimeout("", timeout)
+	ghClient.SetHost(resolveHost(cfg, newRepo))
 	ctx := context.Background()
 	exists, err := ghClient.RepositoryExists(ctx, newRepo)
 	if err != nil || !exists {
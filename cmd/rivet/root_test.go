@@ -1,9 +1,13 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Cloudsky01/gh-rivet/internal/config"
 	"github.com/Cloudsky01/gh-rivet/internal/paths"
 )
 
@@ -79,3 +83,158 @@ func TestDetermineConfigSaveTarget_Explicit(t *testing.T) {
 		t.Fatalf("expected saveLocationExplicit, got %v", location)
 	}
 }
+
+func TestOtherConfigTiers(t *testing.T) {
+	tmpDir := t.TempDir()
+	p := &paths.Paths{
+		UserConfigDir:         filepath.Join(tmpDir, "config"),
+		RepoDefaultConfigPath: filepath.Join(tmpDir, ".github", paths.LegacyConfigFileName),
+		ProjectUserConfigPath: filepath.Join(tmpDir, ".git", "rivet", paths.ConfigFileName),
+	}
+
+	for _, path := range []string{p.RepoDefaultConfigPath, p.UserConfigFile()} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("repository: owner/repo\n"), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+
+	others := otherConfigTiers(p, p.UserConfigFile())
+	if len(others) != 1 || others[0] != p.RepoDefaultConfigPath {
+		t.Fatalf("expected only the team config to be left, got %v", others)
+	}
+}
+
+func TestResetExistingConfigs_KeepsOtherTiers(t *testing.T) {
+	tmpDir := t.TempDir()
+	p := &paths.Paths{
+		UserConfigDir:         filepath.Join(tmpDir, "config"),
+		UserCacheDir:          filepath.Join(tmpDir, "cache"),
+		RepoDefaultConfigPath: filepath.Join(tmpDir, ".github", paths.LegacyConfigFileName),
+		ProjectUserConfigPath: filepath.Join(tmpDir, ".git", "rivet", paths.ConfigFileName),
+	}
+
+	for _, path := range []string{p.RepoDefaultConfigPath, p.ProjectUserConfigPath} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("repository: owner/repo\n"), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+
+	oldAssumeYes := assumeYes
+	assumeYes = true
+	t.Cleanup(func() { assumeYes = oldAssumeYes })
+
+	if err := resetExistingConfigs(p, p.ProjectUserConfigPath); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if fileExists(p.ProjectUserConfigPath) {
+		t.Fatal("expected the target config to be removed")
+	}
+	if !fileExists(p.RepoDefaultConfigPath) {
+		t.Fatal("expected the team config to be left in place")
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "24h", want: 24 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "0h", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "xd", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePinnedIndex(t *testing.T) {
+	cfg := &config.Config{Groups: []config.Group{
+		{ID: "ci", Name: "CI", Workflows: []string{"a.yml", "b.yml"}, PinnedWorkflows: []string{"a.yml", "b.yml"}},
+	}}
+
+	for index, wantErr := range map[int]bool{0: false, 1: false, 2: false, 3: true, -1: true} {
+		if err := validatePinnedIndex(cfg, index); (err != nil) != wantErr {
+			t.Errorf("validatePinnedIndex(%d) error = %v, wantErr %v", index, err, wantErr)
+		}
+	}
+	if err := validatePinnedIndex(&config.Config{}, 1); err == nil {
+		t.Error("expected an error without pins")
+	}
+}
+
+func TestValidateOpenWorkflow(t *testing.T) {
+	cfg := &config.Config{Groups: []config.Group{
+		{ID: "ci", Name: "CI", Workflows: []string{"build.yml"}},
+	}}
+
+	for workflow, wantErr := range map[string]bool{"": false, "build.yml": false, "deploy.yml": true} {
+		if err := validateOpenWorkflow(cfg, workflow); (err != nil) != wantErr {
+			t.Errorf("validateOpenWorkflow(%q) error = %v, wantErr %v", workflow, err, wantErr)
+		}
+	}
+}
+
+func TestTrustedGHPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoDefault := filepath.Join(tmpDir, ".github", paths.LegacyConfigFileName)
+	userConfig := filepath.Join(tmpDir, "config", paths.ConfigFileName)
+	for path, content := range map[string]string{
+		repoDefault: "preferences:\n  ghPath: ./evil.sh\n",
+		userConfig:  "repository: owner/repo\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+
+	cfg, err := config.LoadMerged([]string{repoDefault, userConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var warning strings.Builder
+	if got := trustedGHPath(cfg, repoDefault, &warning); got != "" {
+		t.Errorf("expected ghPath from the repository config to be ignored, got %q", got)
+	}
+	if !strings.Contains(warning.String(), repoDefault) {
+		t.Errorf("expected a warning naming %s, got %q", repoDefault, warning.String())
+	}
+
+	if err := os.WriteFile(userConfig, []byte("preferences:\n  ghPath: /opt/gh\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err = config.LoadMerged([]string{repoDefault, userConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	warning.Reset()
+	if got := trustedGHPath(cfg, repoDefault, &warning); got != "/opt/gh" {
+		t.Errorf("expected ghPath from the user config, got %q", got)
+	}
+	if warning.Len() != 0 {
+		t.Errorf("expected no warning, got %q", warning.String())
+	}
+}
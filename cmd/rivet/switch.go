@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/git"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
+)
+
+var (
+	clearActive bool
+	openAfter   bool
+
+	switchCmd = &cobra.Command{
+		Use:   "switch [owner/repo]",
+		Short: "Switch the repository rivet views",
+		Long: `Set the active repository without modifying any configuration file.
+
+The next launch of rivet in the current project shows the active repository
+instead of the one in your config; other projects are not affected. Use
+--clear to go back to the configured repository, or 'rivet update-repo' to
+change the configuration permanently.`,
+		RunE: runSwitch,
+		Args: cobra.MaximumNArgs(1),
+	}
+)
+
+func init() {
+	switchCmd.Flags().BoolVar(&clearActive, "clear", false, "Return to the repository from the configuration")
+	switchCmd.Flags().BoolVarP(&openAfter, "open", "o", false, "Launch the TUI after switching")
+	switchCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Switch to repositories outside the configuration without asking")
+	switchCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+
+	rootCmd.AddCommand(switchCmd)
+}
+
+// determineActiveRepository picks the repository to view: the --repo flag,
+// then the repository chosen with `rivet switch` in the current project, then
+// the configured one. The second return value describes where the repository
+// came from.
+func determineActiveRepository(cfg *config.Config, globalState *state.GlobalState) (string, string) {
+	if repo != "" {
+		return repo, ""
+	}
+	if globalState != nil {
+		if active := globalState.ActiveRepository(currentProjectRoot()); active != "" {
+			return active, "active repository"
+		}
+	}
+	if cfg != nil && cfg.Repository != "" {
+		return cfg.Repository, "repository from config"
+	}
+	return "", ""
+}
+
+// currentProjectRoot returns the root of the repository rivet runs in, or ""
+// outside any repository. Global state that is per project is keyed by it.
+func currentProjectRoot() string {
+	root, _ := git.GetGitRepositoryRoot()
+	return root
+}
+
+// loadGlobalState reads the global state, treating errors as an empty state
+func loadGlobalState() *state.GlobalState {
+	p, err := paths.New()
+	if err != nil {
+		return &state.GlobalState{}
+	}
+	gs, err := state.LoadGlobal(p)
+	if err != nil {
+		return &state.GlobalState{}
+	}
+	return gs
+}
+
+func runSwitch(cmd *cobra.Command, args []string) error {
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+
+	gs, err := state.LoadGlobal(p)
+	if err != nil {
+		return fmt.Errorf("failed to load global state: %w", err)
+	}
+
+	var cfg *config.Config
+	if configPaths := p.GetConfigPaths(); len(configPaths) > 0 {
+		cfg, err = config.LoadMerged(configPaths)
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+	}
+
+	if clearActive {
+		if len(args) > 0 {
+			return fmt.Errorf("--clear does not take a repository")
+		}
+		if err := state.SetActiveRepository(p, currentProjectRoot(), ""); err != nil {
+			return err
+		}
+		if cfg != nil && cfg.Repository != "" {
+			fmt.Println(successStyle.Render("✓ Switched back to: " + cfg.Repository))
+		} else {
+			fmt.Println(successStyle.Render("✓ Active repository cleared"))
+		}
+		return launchAfterSwitch()
+	}
+
+	if len(args) == 0 {
+		active, source := determineActiveRepository(cfg, gs)
+		if active == "" {
+			return fmt.Errorf("no repository configured\nUsage: rivet switch owner/repo")
+		}
+		fmt.Println(labelStyle.Render("Active repository: ") + infoStyle.Render(active+" ("+source+")"))
+		return nil
+	}
+
+	newRepo, err := github.NormalizeRepo(args[0])
+	if err != nil {
+		return err
+	}
+
+	inConfig := cfg != nil && cfg.Repository == newRepo
+	if !inConfig && !assumeYes {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("%s is not the configured repository. Use --yes to switch anyway", newRepo)
+		}
+		confirmed := false
+		if err := wizard.AskConfirm(
+			"Switch repository",
+			fmt.Sprintf("%s is not in your configuration. Switch to it anyway?", newRepo),
+			&confirmed,
+		); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	if !inConfig {
+		timeout := time.Duration(timeoutSeconds) * time.Second
+		ghClient := github.NewClientWithTimeout("", timeout)
+		ghClient.SetHost(resolveHost(cfg, newRepo))
+		exists, err := ghClient.RepositoryExists(context.Background(), newRepo)
+		if err != nil || !exists {
+			return fmt.Errorf("failed to validate repository %s: %v", newRepo, err)
+		}
+	}
+
+	// Switching to the configured repository is the same as clearing the override
+	active := newRepo
+	if inConfig {
+		active = ""
+	}
+	if err := state.SetActiveRepository(p, currentProjectRoot(), active); err != nil {
+		return err
+	}
+
+	fmt.Println(successStyle.Render("✓ Switched to: " + newRepo))
+	if !inConfig {
+		fmt.Println(infoStyle.Render("Your configuration was not changed. Run 'rivet switch --clear' to go back."))
+	}
+
+	return launchAfterSwitch()
+}
+
+// launchAfterSwitch starts the TUI when --open was passed
+func launchAfterSwitch() error {
+	if !openAfter {
+		return nil
+	}
+	return runView(rootCmd, nil)
+}
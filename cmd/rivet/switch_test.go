@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+)
+
+func TestDetermineActiveRepository(t *testing.T) {
+	cfg := &config.Config{Repository: "team/app"}
+	switched := &state.GlobalState{ActiveRepositories: map[string]string{currentProjectRoot(): "me/fork"}}
+	otherProject := &state.GlobalState{ActiveRepositories: map[string]string{"/elsewhere": "me/fork"}}
+
+	tests := []struct {
+		name        string
+		flag        string
+		globalState *state.GlobalState
+		want        string
+	}{
+		{name: "config", globalState: &state.GlobalState{}, want: "team/app"},
+		{name: "switched", globalState: switched, want: "me/fork"},
+		{name: "switched in another project", globalState: otherProject, want: "team/app"},
+		{name: "flag wins", flag: "other/repo", globalState: switched, want: "other/repo"},
+		{name: "no global state", want: "team/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo = tt.flag
+			defer func() { repo = "" }()
+
+			got, _ := determineActiveRepository(cfg, tt.globalState)
+			if got != tt.want {
+				t.Errorf("determineActiveRepository() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
+)
+
+var (
+	unpinAll bool
+
+	unpinCmd = &cobra.Command{
+		Use:   "unpin --all",
+		Short: "Remove every pinned workflow",
+		Long: `Remove every workflow you pinned, after a confirmation.
+
+Pins are removed from your personal configs: the user config and, inside a
+git repository, the project user config. Pins set by the shared repository
+config stay.`,
+		RunE: runUnpin,
+		Args: cobra.NoArgs,
+	}
+)
+
+func init() {
+	unpinCmd.Flags().BoolVar(&unpinAll, "all", false, "Remove every personal pin")
+	unpinCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation (required without a TTY)")
+
+	rootCmd.AddCommand(unpinCmd)
+}
+
+func runUnpin(_ *cobra.Command, _ []string) error {
+	if !unpinAll {
+		return fmt.Errorf("specify --all to remove every pin")
+	}
+
+	p, err := initializePaths()
+	if err != nil {
+		return err
+	}
+	configPaths := p.GetConfigPaths()
+	if len(configPaths) == 0 {
+		return fmt.Errorf("no configuration found. Run 'rivet init' first")
+	}
+	cfg, err := config.LoadMerged(configPaths)
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.GetAllPinnedWorkflows()) == 0 {
+		fmt.Println(infoStyle.Render("No pinned workflows"))
+		return nil
+	}
+
+	if !assumeYes {
+		if !wizard.IsTTY() {
+			return fmt.Errorf("cannot confirm in non-interactive mode. Use --yes to clear pins")
+		}
+		confirmed := false
+		if err := wizard.AskConfirm(
+			"Clear all pins",
+			"Unpin every workflow you pinned? Pins from a shared config stay.",
+			&confirmed,
+		); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	userPaths := personalConfigPaths(p)
+	cleared, err := cfg.ClearPersonalPins(userPaths)
+	if err != nil {
+		return err
+	}
+
+	if cleared > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Cleared %d pinned workflows from: %s", cleared, strings.Join(userPaths, ", "))))
+	}
+	if remaining := len(cfg.GetAllPinnedWorkflows()); remaining > 0 {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("%d pins from a shared config remain", remaining)))
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/internal/wizard"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// watchRunLimit is how many recent repository runs each refresh scans for
+// the configured workflows' latest runs
+const watchRunLimit = 100
+
+// Terminal control sequences, only written when stdout is a terminal
+const (
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+var (
+	watchInterval int
+
+	watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "Show a self-updating status grid of the configured workflows",
+		Long: `Print the latest run of every configured workflow as a compact grid and
+reprint it on an interval until interrupted, for a wall monitor.
+
+Workflows without a run among the repository's most recent runs are shown
+as −. When stdout is not a terminal, each refresh is appended instead of
+redrawn, so the output can be piped to a file.
+
+Example: rivet watch --interval 60`,
+		RunE: runWatch,
+		Args: cobra.NoArgs,
+	}
+)
+
+func init() {
+	watchCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository (owner/repo format)")
+	watchCmd.Flags().StringVar(&host, "host", "", "GitHub Enterprise Server hostname (default: github.com)")
+	watchCmd.Flags().StringVar(&remoteName, "remote", "", "Git remote to detect the repository from (default: origin)")
+	watchCmd.Flags().IntVar(&watchInterval, "interval", 30, "Seconds between refreshes")
+	watchCmd.Flags().IntVar(&timeoutSeconds, "timeout", 30, "GitHub API timeout in seconds")
+
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch(_ *cobra.Command, _ []string) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("invalid --interval %d: must be at least 1 second", watchInterval)
+	}
+
+	if err := checkGitHubCLI(); err != nil {
+		return err
+	}
+
+	cfg, repository, err := resolveCommandRepository()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("no configuration found. Run 'rivet init' first")
+	}
+	files, names := cfg.WorkflowFiles()
+	if len(files) == 0 {
+		return fmt.Errorf("no workflows configured. Run 'rivet init' first")
+	}
+
+	gh := github.NewClientWithTimeout(repository, time.Duration(timeoutSeconds)*time.Second)
+	gh.SetHost(resolveHost(cfg, repository))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tty := wizard.IsTTY()
+	if tty {
+		fmt.Print(hideCursor)
+		defer fmt.Print(showCursor)
+	}
+
+	ticker := time.NewTicker(time.Duration(watchInterval) * time.Second)
+	defer ticker.Stop()
+
+	latest := map[string]*models.GHRun{}
+	for {
+		runs, err := gh.GetRecentRuns(watchRunLimit)
+		if err == nil {
+			// On errors the previous runs stay on screen with the error below
+			latest = models.LatestRuns(files, names, runs, time.Time{})
+		}
+
+		width := 80
+		if tty {
+			if w, _, sizeErr := term.GetSize(os.Stdout.Fd()); sizeErr == nil && w > 0 {
+				width = w
+			}
+			fmt.Print(clearScreen)
+		}
+		fmt.Print(renderWatchFrame(repository, files, names, latest, err, width, time.Now()))
+		if !tty {
+			fmt.Println()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// renderWatchFrame renders one refresh: a header, the status grid, and the
+// refresh error if there was one
+func renderWatchFrame(repository string, files []string, names map[string]string, latest map[string]*models.GHRun, err error, width int, now time.Time) string {
+	t := theme.Default()
+
+	var b strings.Builder
+	b.WriteString(t.Title.Render("rivet watch · " + repository))
+	b.WriteString(t.TextMuted.Render("  updated " + now.Format("15:04:05")))
+	b.WriteString("\n\n")
+	b.WriteString(renderWatchGrid(t, files, names, latest, width))
+	if err != nil {
+		b.WriteString("\n")
+		b.WriteString(t.StatusError.Render(fmt.Sprintf("Refresh failed: %v", err)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderWatchGrid lays the workflows out in as many equal columns as fit
+// width, each cell showing the latest run's status icon and the workflow name
+func renderWatchGrid(t *theme.Theme, files []string, names map[string]string, latest map[string]*models.GHRun, width int) string {
+	const (
+		maxNameWidth = 30
+		cellPadding  = 4 // icon, space, and the gap to the next column
+	)
+
+	nameWidth := 0
+	for _, wf := range files {
+		nameWidth = max(nameWidth, lipgloss.Width(names[wf]))
+	}
+	nameWidth = min(nameWidth, maxNameWidth)
+	columns := max(1, width/(nameWidth+cellPadding))
+
+	var b strings.Builder
+	for i, wf := range files {
+		icon, style := t.Icons.Neutral, t.TextDim
+		if run := latest[wf]; run != nil {
+			icon, style = t.StatusIcon(run.Status, run.Conclusion)
+		}
+
+		// Truncate by display width, as wide characters take two columns
+		name := ansi.Truncate(names[wf], nameWidth, "…")
+
+		b.WriteString(style.Render(icon))
+		b.WriteString(" ")
+		if i%columns == columns-1 || i == len(files)-1 {
+			b.WriteString(name)
+			b.WriteString("\n")
+		} else {
+			b.WriteString(name + strings.Repeat(" ", max(0, nameWidth-lipgloss.Width(name)+cellPadding-2)))
+		}
+	}
+	return b.String()
+}
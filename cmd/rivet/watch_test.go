@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+func TestRenderWatchGrid(t *testing.T) {
+	files := []string{"ci.yml", "deploy.yml", "nightly.yml"}
+	names := map[string]string{"ci.yml": "CI", "deploy.yml": "Deploy", "nightly.yml": "nightly.yml"}
+	latest := map[string]*models.GHRun{
+		"ci.yml":     {Status: "completed", Conclusion: "success"},
+		"deploy.yml": {Status: "completed", Conclusion: "failure"},
+	}
+
+	// nightly.yml is the widest name, so cells are 15 columns wide
+	got := renderWatchGrid(theme.Default(), files, names, latest, 30)
+	want := "✓ CI           ✗ Deploy\n− nightly.yml\n"
+	if got != want {
+		t.Errorf("renderWatchGrid() =\n%q\nwant\n%q", got, want)
+	}
+
+	got = renderWatchGrid(theme.Default(), files, names, latest, 10)
+	if lines := strings.Count(got, "\n"); lines != 3 {
+		t.Errorf("expected one workflow per line when narrow, got:\n%s", got)
+	}
+}
+
+func TestRenderWatchGridWideNames(t *testing.T) {
+	files := []string{"build.yml", "deploy.yml"}
+	names := map[string]string{
+		"build.yml":  "ビルドとテストとリリースとデプロイの確認",
+		"deploy.yml": "Deploy",
+	}
+
+	got := renderWatchGrid(theme.Default(), files, names, nil, 80)
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if width := lipgloss.Width(line); width > 80 {
+			t.Errorf("expected lines to fit 80 columns, got %d: %q", width, line)
+		}
+	}
+	if !strings.Contains(got, "…") || !strings.Contains(got, "Deploy") {
+		t.Errorf("expected the wide name truncated next to Deploy, got:\n%s", got)
+	}
+}
+
+func TestRenderWatchFrameShowsError(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+	frame := renderWatchFrame("owner/repo", []string{"ci.yml"}, map[string]string{"ci.yml": "CI"}, nil, errors.New("boom"), 80, now)
+
+	for _, want := range []string{"owner/repo", "updated 09:26:53", "− CI", "Refresh failed: boom"} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("expected %q in frame:\n%s", want, frame)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxBackups is how many config backups are kept; older ones are pruned
+const MaxBackups = 20
+
+// backupHeader starts every backup and is followed by the source path
+const backupHeader = "# rivet backup of "
+
+const backupTimeFormat = "20060102-150405.000000"
+
+// Backup is a saved copy of a config file
+type Backup struct {
+	Path    string // the backup file
+	Source  string // the config file it was copied from
+	Created time.Time
+}
+
+// BackupFile copies the config file at path into the backup directory dir
+// under a timestamped name and prunes the oldest backups beyond MaxBackups.
+// It is meant for destructive writes, such as a reset, restore, import or
+// migration, not for every save. It does nothing when the file does not
+// exist.
+func BackupFile(dir, path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	source, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// Backups taken within the same microsecond get the next free stamp
+	content := append([]byte(backupHeader+source+"\n"), data...)
+	for stamp := time.Now(); ; stamp = stamp.Add(time.Microsecond) {
+		name := stamp.Format(backupTimeFormat) + "-" + filepath.Base(path)
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		_, err = f.Write(content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		break
+	}
+
+	return pruneBackups(dir)
+}
+
+// ListBackups returns the backups in dir, newest first
+func ListBackups(dir string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || len(name) <= len(backupTimeFormat) {
+			continue
+		}
+		created, err := time.ParseInLocation(backupTimeFormat, name[:len(backupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		source, err := backupSource(path)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: path, Source: source, Created: created})
+	}
+
+	slices.SortFunc(backups, func(a, b Backup) int {
+		return b.Created.Compare(a.Created)
+	})
+	return backups, nil
+}
+
+// backupSource reads the source path from a backup's first line
+func backupSource(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, backupHeader) {
+		return "", fmt.Errorf("%s is not a config backup", path)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, backupHeader)), nil
+}
+
+// RestoreBackup writes a backup back to its source, backing up the current
+// file into dir first so the restore can itself be undone
+func RestoreBackup(dir string, b Backup) error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return err
+	}
+	_, content, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return fmt.Errorf("%s is not a config backup", b.Path)
+	}
+
+	if err := BackupFile(dir, b.Source); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", b.Source, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.Source), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return os.WriteFile(b.Source, content, 0644)
+}
+
+// pruneBackups removes the oldest backups in dir beyond MaxBackups
+func pruneBackups(dir string) error {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(len(backups), MaxBackups):] {
+		if err := os.Remove(b.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := &Config{Repository: "owner/original", Groups: []Group{{ID: "ci", Name: "CI"}}}
+	if err := original.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	originalData, _ := os.ReadFile(path)
+
+	if err := BackupFile(dir, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Everyday saves, such as a pin toggle, are not backed up
+	overwrite := &Config{Repository: "owner/other"}
+	if err := overwrite.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := ListBackups(dir)
+	if err != nil {
+		t.Fatalf("ListBackups() error = %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %d", len(backups))
+	}
+	if backups[0].Source != path {
+		t.Errorf("backup source = %s, want %s", backups[0].Source, path)
+	}
+
+	if err := RestoreBackup(dir, backups[0]); err != nil {
+		t.Fatalf("RestoreBackup() error = %v", err)
+	}
+	restored, _ := os.ReadFile(path)
+	if string(restored) != string(originalData) {
+		t.Errorf("restored config differs from the original:\n%s", restored)
+	}
+
+	// The overwritten version was backed up by the restore
+	if backups, _ := ListBackups(dir); len(backups) != 2 {
+		t.Errorf("expected the restore to back up the replaced file, got %d backups", len(backups))
+	}
+}
+
+func TestBackupsArePruned(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	for i := range MaxBackups + 3 {
+		if err := os.WriteFile(path, []byte{byte('a' + i)}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := BackupFile(dir, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := ListBackups(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != MaxBackups {
+		t.Fatalf("expected %d backups kept, got %d", MaxBackups, len(backups))
+	}
+	newest, _ := os.ReadFile(backups[0].Path)
+	if newest[len(newest)-1] != byte('a'+MaxBackups+2) {
+		t.Errorf("expected the newest backup first, got %q", newest)
+	}
+}
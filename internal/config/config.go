@@ -1,11 +1,17 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Cloudsky01/gh-rivet/internal/paths"
 	"gopkg.in/yaml.v3"
@@ -13,19 +19,47 @@ import (
 
 // Preferences contains user-specific settings that should not be shared
 type Preferences struct {
-	RefreshInterval int               `yaml:"refreshInterval,omitempty"` // in seconds, 0 = disabled
-	Theme           string            `yaml:"theme,omitempty"`           // Theme preference (e.g., "dark", "light")
-	Keybindings     string            `yaml:"keybindings,omitempty"`     // Keybinding style (e.g., "vim", "emacs")
-	CustomSettings  map[string]string `yaml:"customSettings,omitempty"`  // Extensible custom settings
+	RefreshInterval  int               `yaml:"refreshInterval,omitempty"`  // in seconds, 0 = disabled
+	Theme            string            `yaml:"theme,omitempty"`            // Theme preference (e.g., "dark", "light")
+	Keybindings      string            `yaml:"keybindings,omitempty"`      // Keybinding style (e.g., "vim", "emacs")
+	BranchHighlights []string          `yaml:"branchHighlights,omitempty"` // Branch glob patterns to emphasize (e.g., "main", "release/*")
+	Host             string            `yaml:"host,omitempty"`             // GitHub hostname, for GitHub Enterprise Server (e.g., "ghe.example.com")
+	Layout           string            `yaml:"layout,omitempty"`           // TUI layout: "modern" (default) or "classic"
+	TablePageSize    int               `yaml:"tablePageSize,omitempty"`    // Runs per table page, 0 = fit the panel height
+	AutoOpenMatch    bool              `yaml:"autoOpenMatch,omitempty"`    // Open the only remaining filter match on enter
+	GroupPinned      bool              `yaml:"groupPinned,omitempty"`      // List sidebar pins under collapsible group headers
+	RememberFilters  bool              `yaml:"rememberFilters,omitempty"`  // Restore each group's last filter when it is reopened
+	HideDescriptions bool              `yaml:"hideDescriptions,omitempty"` // List groups and workflows without their description line
+	IdleTimeout      int               `yaml:"idleTimeout,omitempty"`      // Minutes without input before quitting, 0 = disabled
+	TimeFormat       string            `yaml:"timeFormat,omitempty"`       // Run times: "relative", "iso", "short" or a Go layout (e.g., "Jan 2 15:04")
+	UTCTimes         bool              `yaml:"utcTimes,omitempty"`         // Show run times in UTC instead of the local time zone
+	OpenBehavior     string            `yaml:"openBehavior,omitempty"`     // What w and enter do on a run: "browser" (default), "logs" or "jobs"
+	SortMode         string            `yaml:"sortMode,omitempty"`         // Group and workflow order: "config" (default), "alpha" or "frequency"
+	GHPath           string            `yaml:"ghPath,omitempty"`           // gh executable to run, a name on PATH or a path (e.g., a wrapper)
+	FavoriteGroups   StringList        `yaml:"favoriteGroups,omitempty"`   // Group IDs listed first in the root group list
+	HiddenWorkflows  StringList        `yaml:"hiddenWorkflows,omitempty"`  // Workflow files left out of the group lists and search
+	CustomSettings   map[string]string `yaml:"customSettings,omitempty"`   // Extensible custom settings
+}
+
+// StringList is a list preference that replaces the list of lower tiers
+// whenever its key is set. An explicitly empty list ([]) is kept when saving,
+// unlike an unset one, so it still clears the lists of lower tiers.
+type StringList []string
+
+// IsZero reports whether the list is unset, for yaml's omitempty
+func (l StringList) IsZero() bool {
+	return l == nil
 }
 
 type Config struct {
-	Repository  string       `yaml:"repository"`
-	Preferences *Preferences `yaml:"preferences,omitempty"` // User preferences (optional)
-	Groups      []Group      `yaml:"groups,omitempty"`
+	Repository    string       `yaml:"repository"`
+	Preferences   *Preferences `yaml:"preferences,omitempty"` // User preferences (optional)
+	Groups        []Group      `yaml:"groups,omitempty"`
+	ReplaceGroups bool         `yaml:"replaceGroups,omitempty"` // Replace lower-tier groups instead of merging by ID
 
 	// Internal fields (not serialized)
-	configPath string `yaml:"-"` // Path to the last loaded config file
+	configPath string            `yaml:"-"` // Path to the last loaded config file
+	sources    map[string]string `yaml:"-"` // Merge key -> path of the config that set it
 }
 
 // GetRefreshInterval returns the refresh interval from preferences
@@ -44,6 +78,302 @@ func (c *Config) SetRefreshInterval(interval int) {
 	c.Preferences.RefreshInterval = interval
 }
 
+// GetBranchHighlights returns the branch patterns to emphasize in the runs table
+func (c *Config) GetBranchHighlights() []string {
+	if c.Preferences != nil {
+		return c.Preferences.BranchHighlights
+	}
+	return nil
+}
+
+// GetFavoriteGroups returns the IDs of the groups starred for quick access
+func (c *Config) GetFavoriteGroups() []string {
+	if c.Preferences != nil {
+		return c.Preferences.FavoriteGroups
+	}
+	return nil
+}
+
+// IsFavoriteGroup reports whether the group with id is starred
+func (c *Config) IsFavoriteGroup(id string) bool {
+	return slices.Contains(c.GetFavoriteGroups(), id)
+}
+
+// ToggleFavoriteGroup stars or unstars the group with id. Favorites are
+// personal, so the new list is saved to the user-tier config at userPath,
+// creating it if needed, and replaces the list from lower tiers. Returns
+// whether the group is a favorite after the toggle.
+func (c *Config) ToggleFavoriteGroup(userPath, id string) (bool, error) {
+	return c.togglePreferenceList(userPath, id, func(p *Preferences) *StringList {
+		return &p.FavoriteGroups
+	})
+}
+
+// GetHiddenWorkflows returns the workflow files hidden from the group lists
+// and search
+func (c *Config) GetHiddenWorkflows() []string {
+	if c.Preferences != nil {
+		return c.Preferences.HiddenWorkflows
+	}
+	return nil
+}
+
+// IsHiddenWorkflow reports whether the workflow file is hidden
+func (c *Config) IsHiddenWorkflow(workflow string) bool {
+	return slices.Contains(c.GetHiddenWorkflows(), workflow)
+}
+
+// ToggleHiddenWorkflow hides or shows the workflow file. Like favorites, the
+// new list is saved to the user-tier config at userPath, so a shared config
+// stays intact. Returns whether the workflow is hidden after the toggle.
+func (c *Config) ToggleHiddenWorkflow(userPath, workflow string) (bool, error) {
+	return c.togglePreferenceList(userPath, workflow, func(p *Preferences) *StringList {
+		return &p.HiddenWorkflows
+	})
+}
+
+// togglePreferenceList adds item to or removes it from the preference list
+// that field selects, saves the new list to the user-tier config at userPath
+// and applies it to c. Returns whether item is in the list afterwards.
+func (c *Config) togglePreferenceList(userPath, item string, field func(*Preferences) *StringList) (bool, error) {
+	var list StringList
+	if c.Preferences != nil {
+		list = slices.Clone(*field(c.Preferences))
+	}
+	added := !slices.Contains(list, item)
+	if added {
+		list = append(list, item)
+	} else {
+		list = slices.DeleteFunc(list, func(s string) bool { return s == item })
+	}
+
+	userCfg, err := LoadFromPath(userPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		userCfg = &Config{}
+	} else if err != nil {
+		return !added, err
+	}
+	if userCfg.Preferences == nil {
+		userCfg.Preferences = &Preferences{}
+	}
+	*field(userCfg.Preferences) = list
+
+	if err := os.MkdirAll(filepath.Dir(userPath), 0755); err != nil {
+		return !added, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := userCfg.Save(userPath); err != nil {
+		return !added, err
+	}
+
+	if c.Preferences == nil {
+		c.Preferences = &Preferences{}
+	}
+	*field(c.Preferences) = list
+	return added, nil
+}
+
+// TUI layouts selectable with preferences.layout or --layout
+const (
+	LayoutModern  = "modern"
+	LayoutClassic = "classic"
+)
+
+// ValidateLayout returns an error unless layout is empty or a known layout
+func ValidateLayout(layout string) error {
+	switch layout {
+	case "", LayoutModern, LayoutClassic:
+		return nil
+	}
+	return fmt.Errorf("invalid layout %q (expected %s or %s)", layout, LayoutModern, LayoutClassic)
+}
+
+// GetLayout returns the TUI layout from preferences, defaulting to modern
+func (c *Config) GetLayout() string {
+	if c.Preferences != nil && c.Preferences.Layout != "" {
+		return c.Preferences.Layout
+	}
+	return LayoutModern
+}
+
+// GetTablePageSize returns the runs table page size, 0 meaning fit the panel
+func (c *Config) GetTablePageSize() int {
+	if c.Preferences != nil {
+		return c.Preferences.TablePageSize
+	}
+	return 0
+}
+
+// Run time formats selectable with preferences.timeFormat, besides a Go
+// time layout
+const (
+	TimeFormatRelative = "relative"
+	TimeFormatISO      = "iso"
+	TimeFormatShort    = "short"
+)
+
+// ValidateTimeFormat returns an error unless format is empty, a preset, or
+// a Go time layout, which must contain at least one element such as 15:04
+func ValidateTimeFormat(format string) error {
+	switch format {
+	case "", TimeFormatRelative, TimeFormatISO, TimeFormatShort:
+		return nil
+	}
+	// A layout renders differently from itself for any time but its
+	// reference one
+	sample := time.Date(1999, time.November, 28, 21, 37, 48, 0, time.UTC)
+	if sample.Format(format) == format {
+		return fmt.Errorf("invalid timeFormat %q (expected %s, %s, %s or a Go time layout such as \"Jan 2 15:04\")",
+			format, TimeFormatRelative, TimeFormatISO, TimeFormatShort)
+	}
+	return nil
+}
+
+// TimeLayout returns the Go time layout of a time format: the layout of the
+// iso and short presets, or format itself. It is "" for the default and
+// relative formats, which have none.
+func TimeLayout(format string) string {
+	switch format {
+	case TimeFormatRelative:
+		return ""
+	case TimeFormatISO:
+		return "2006-01-02T15:04:05Z07:00"
+	case TimeFormatShort:
+		return "Jan 2 15:04"
+	}
+	return format
+}
+
+// GetTimeFormat returns the run time format from preferences, "" meaning
+// the default
+func (c *Config) GetTimeFormat() string {
+	if c.Preferences != nil {
+		return c.Preferences.TimeFormat
+	}
+	return ""
+}
+
+// GetUTCTimes reports whether run times are shown in UTC instead of the
+// local time zone
+func (c *Config) GetUTCTimes() bool {
+	return c.Preferences != nil && c.Preferences.UTCTimes
+}
+
+// Run actions selectable with preferences.openBehavior for w and enter in
+// the runs view
+const (
+	OpenBrowser = "browser"
+	OpenLogs    = "logs"
+	OpenJobs    = "jobs"
+)
+
+// ValidateOpenBehavior returns an error unless behavior is empty or a known
+// run action
+func ValidateOpenBehavior(behavior string) error {
+	switch behavior {
+	case "", OpenBrowser, OpenLogs, OpenJobs:
+		return nil
+	}
+	return fmt.Errorf("invalid openBehavior %q (expected %s, %s or %s)", behavior, OpenBrowser, OpenLogs, OpenJobs)
+}
+
+// GetOpenBehavior returns what opening a run does, defaulting to the browser
+func (c *Config) GetOpenBehavior() string {
+	if c.Preferences != nil && c.Preferences.OpenBehavior != "" {
+		return c.Preferences.OpenBehavior
+	}
+	return OpenBrowser
+}
+
+// Orders selectable with preferences.sortMode for the groups and workflows
+// of the group lists and search
+const (
+	SortConfig    = "config"
+	SortAlpha     = "alpha"
+	SortFrequency = "frequency"
+)
+
+// ValidateSortMode returns an error unless mode is empty or a known order
+func ValidateSortMode(mode string) error {
+	switch mode {
+	case "", SortConfig, SortAlpha, SortFrequency:
+		return nil
+	}
+	return fmt.Errorf("invalid sortMode %q (expected %s, %s or %s)", mode, SortConfig, SortAlpha, SortFrequency)
+}
+
+// GetSortMode returns how groups and workflows are ordered, defaulting to
+// the config order
+func (c *Config) GetSortMode() string {
+	if c.Preferences != nil && c.Preferences.SortMode != "" {
+		return c.Preferences.SortMode
+	}
+	return SortConfig
+}
+
+// GetAutoOpenMatch reports whether confirming a filter with a single match
+// opens it
+func (c *Config) GetAutoOpenMatch() bool {
+	return c.Preferences != nil && c.Preferences.AutoOpenMatch
+}
+
+// GetRememberFilters reports whether each group list's filter is kept and
+// restored when the group is opened again
+func (c *Config) GetRememberFilters() bool {
+	return c.Preferences != nil && c.Preferences.RememberFilters
+}
+
+// GetHideDescriptions reports whether the group list starts without item
+// descriptions
+func (c *Config) GetHideDescriptions() bool {
+	return c.Preferences != nil && c.Preferences.HideDescriptions
+}
+
+// GetGroupPinned reports whether the sidebar lists pinned workflows under
+// their groups instead of as one flat list
+func (c *Config) GetGroupPinned() bool {
+	return c.Preferences != nil && c.Preferences.GroupPinned
+}
+
+// GetIdleTimeout returns the minutes without input after which the TUI
+// quits, 0 meaning never
+func (c *Config) GetIdleTimeout() int {
+	if c.Preferences != nil {
+		return c.Preferences.IdleTimeout
+	}
+	return 0
+}
+
+// GetGHPath returns the gh executable from preferences, or "" for gh on PATH
+func (c *Config) GetGHPath() string {
+	if c.Preferences != nil {
+		return c.Preferences.GHPath
+	}
+	return ""
+}
+
+// GetHost returns the GitHub hostname from preferences, or "" for the default
+func (c *Config) GetHost() string {
+	if c.Preferences != nil {
+		return c.Preferences.Host
+	}
+	return ""
+}
+
+// MatchesBranch reports whether branch matches any of the given glob patterns.
+// Patterns use path.Match syntax, so "release/*" matches "release/1.0" but not
+// "release/1.0/hotfix".
+func MatchesBranch(branch string, patterns []string) bool {
+	if branch == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
 // GetConfigPath returns the path to this config file
 func (c *Config) GetConfigPath() string {
 	return c.configPath
@@ -71,11 +401,13 @@ type Group struct {
 	Jobs             []string   `yaml:"jobs,omitempty"`
 	Groups           []Group    `yaml:"groups,omitempty"`
 	PinnedWorkflows  []string   `yaml:"pinnedWorkflows,omitempty"`
+	DefaultWorkflow  string     `yaml:"defaultWorkflow,omitempty"` // Workflow whose runs open when the group is entered
 }
 
 // LoadMerged loads and merges configuration from multiple paths.
 // Paths should be provided in order of precedence (lowest to highest).
-// Later configs overwrite earlier ones.
+// Later configs overwrite earlier ones, and RIVET_PREFERENCES_* environment
+// variables overwrite them all (see ApplyEnv).
 func LoadMerged(paths []string) (*Config, error) {
 	config := &Config{}
 	loaded := false
@@ -98,6 +430,9 @@ func LoadMerged(paths []string) (*Config, error) {
 	if !loaded {
 		return nil, fmt.Errorf("no configuration files found")
 	}
+	if err := config.ApplyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
@@ -121,9 +456,11 @@ func LoadFromPath(path string) (*Config, error) {
 
 // Merge merges another config into this one.
 // Fields from 'other' take precedence over this config.
+// Every key that 'other' sets is recorded as coming from other's config path.
 func (c *Config) Merge(other *Config) {
 	if other.Repository != "" {
 		c.Repository = other.Repository
+		c.setSource("repository", other.configPath)
 	}
 
 	// Merge Preferences
@@ -133,12 +470,80 @@ func (c *Config) Merge(other *Config) {
 		}
 		if other.Preferences.RefreshInterval != 0 {
 			c.Preferences.RefreshInterval = other.Preferences.RefreshInterval
+			c.setSource("preferences.refreshInterval", other.configPath)
 		}
 		if other.Preferences.Theme != "" {
 			c.Preferences.Theme = other.Preferences.Theme
+			c.setSource("preferences.theme", other.configPath)
 		}
 		if other.Preferences.Keybindings != "" {
 			c.Preferences.Keybindings = other.Preferences.Keybindings
+			c.setSource("preferences.keybindings", other.configPath)
+		}
+		if len(other.Preferences.BranchHighlights) > 0 {
+			c.Preferences.BranchHighlights = other.Preferences.BranchHighlights
+			c.setSource("preferences.branchHighlights", other.configPath)
+		}
+		if other.Preferences.Host != "" {
+			c.Preferences.Host = other.Preferences.Host
+			c.setSource("preferences.host", other.configPath)
+		}
+		if other.Preferences.Layout != "" {
+			c.Preferences.Layout = other.Preferences.Layout
+			c.setSource("preferences.layout", other.configPath)
+		}
+		if other.Preferences.TablePageSize != 0 {
+			c.Preferences.TablePageSize = other.Preferences.TablePageSize
+			c.setSource("preferences.tablePageSize", other.configPath)
+		}
+		if other.Preferences.AutoOpenMatch {
+			c.Preferences.AutoOpenMatch = true
+			c.setSource("preferences.autoOpenMatch", other.configPath)
+		}
+		if other.Preferences.GroupPinned {
+			c.Preferences.GroupPinned = true
+			c.setSource("preferences.groupPinned", other.configPath)
+		}
+		if other.Preferences.RememberFilters {
+			c.Preferences.RememberFilters = true
+			c.setSource("preferences.rememberFilters", other.configPath)
+		}
+		if other.Preferences.HideDescriptions {
+			c.Preferences.HideDescriptions = true
+			c.setSource("preferences.hideDescriptions", other.configPath)
+		}
+		if other.Preferences.IdleTimeout != 0 {
+			c.Preferences.IdleTimeout = other.Preferences.IdleTimeout
+			c.setSource("preferences.idleTimeout", other.configPath)
+		}
+		if other.Preferences.TimeFormat != "" {
+			c.Preferences.TimeFormat = other.Preferences.TimeFormat
+			c.setSource("preferences.timeFormat", other.configPath)
+		}
+		if other.Preferences.UTCTimes {
+			c.Preferences.UTCTimes = true
+			c.setSource("preferences.utcTimes", other.configPath)
+		}
+		if other.Preferences.OpenBehavior != "" {
+			c.Preferences.OpenBehavior = other.Preferences.OpenBehavior
+			c.setSource("preferences.openBehavior", other.configPath)
+		}
+		if other.Preferences.SortMode != "" {
+			c.Preferences.SortMode = other.Preferences.SortMode
+			c.setSource("preferences.sortMode", other.configPath)
+		}
+		// An explicitly empty list overrides too, clearing the lower tiers
+		if other.Preferences.FavoriteGroups != nil {
+			c.Preferences.FavoriteGroups = other.Preferences.FavoriteGroups
+			c.setSource("preferences.favoriteGroups", other.configPath)
+		}
+		if other.Preferences.HiddenWorkflows != nil {
+			c.Preferences.HiddenWorkflows = other.Preferences.HiddenWorkflows
+			c.setSource("preferences.hiddenWorkflows", other.configPath)
+		}
+		if other.Preferences.GHPath != "" {
+			c.Preferences.GHPath = other.Preferences.GHPath
+			c.setSource("preferences.ghPath", other.configPath)
 		}
 		// Merge CustomSettings
 		if other.Preferences.CustomSettings != nil {
@@ -147,15 +552,140 @@ func (c *Config) Merge(other *Config) {
 			}
 			for k, v := range other.Preferences.CustomSettings {
 				c.Preferences.CustomSettings[k] = v
+				c.setSource("preferences.customSettings."+k, other.configPath)
 			}
 		}
 	}
 
-	// Groups are replaced, not merged, to avoid duplication and confusion
-	// If a config defines groups, it overrides previous groups completely
-	if len(other.Groups) > 0 {
+	// Groups are merged by ID so a higher tier can add or tweak a single group
+	// without redefining the whole set. Setting replaceGroups discards lower-tier
+	// groups entirely instead.
+	if other.ReplaceGroups {
 		c.Groups = other.Groups
+		for key := range c.sources {
+			if strings.HasPrefix(key, "groups.") {
+				delete(c.sources, key)
+			}
+		}
+	} else if len(other.Groups) > 0 {
+		c.Groups = mergeGroups(c.Groups, other.Groups)
+	}
+	for i := range other.Groups {
+		c.setSource("groups."+other.Groups[i].ID, other.configPath)
+	}
+}
+
+// mergeGroups merges override groups into base by ID.
+// Groups with a matching ID are merged field by field (see Group.merge);
+// groups that only exist in override are appended in order.
+func mergeGroups(base, override []Group) []Group {
+	result := make([]Group, len(base), len(base)+len(override))
+	copy(result, base)
+
+	for i := range override {
+		idx := slices.IndexFunc(result, func(g Group) bool { return g.ID == override[i].ID })
+		if idx < 0 {
+			result = append(result, override[i])
+			continue
+		}
+		result[idx].merge(&override[i])
+	}
+
+	return result
+}
+
+// merge merges another definition of the same group into this one:
+//   - name, description and defaultWorkflow are overridden when set
+//   - workflows, workflowPatterns and jobs are unioned, keeping base order first
+//   - workflowDefs are merged by file, with override names winning
+//   - pinnedWorkflows are unioned with override pins listed first, so personal
+//     pins from a higher tier are kept alongside team pins
+//   - nested groups are merged recursively by ID
+func (g *Group) merge(other *Group) {
+	if other.Name != "" {
+		g.Name = other.Name
+	}
+	if other.Description != "" {
+		g.Description = other.Description
+	}
+	if other.DefaultWorkflow != "" {
+		g.DefaultWorkflow = other.DefaultWorkflow
+	}
+
+	g.Workflows = unionStrings(g.Workflows, other.Workflows)
+	g.WorkflowPatterns = unionStrings(g.WorkflowPatterns, other.WorkflowPatterns)
+	g.Jobs = unionStrings(g.Jobs, other.Jobs)
+	g.WorkflowDefs = mergeWorkflowDefs(g.WorkflowDefs, other.WorkflowDefs)
+
+	if len(other.PinnedWorkflows) > 0 {
+		g.PinnedWorkflows = unionStrings(other.PinnedWorkflows, g.PinnedWorkflows)
+	}
+
+	if len(other.Groups) > 0 {
+		g.Groups = mergeGroups(g.Groups, other.Groups)
+	}
+}
+
+func mergeWorkflowDefs(base, override []Workflow) []Workflow {
+	if len(override) == 0 {
+		return base
+	}
+
+	result := slices.Clone(base)
+	for _, wf := range override {
+		idx := slices.IndexFunc(result, func(w Workflow) bool { return w.File == wf.File })
+		if idx < 0 {
+			result = append(result, wf)
+		} else if wf.Name != "" {
+			result[idx].Name = wf.Name
+		}
+	}
+	return result
+}
+
+// unionStrings returns a new slice with the items of a followed by the items
+// of b that are not already present
+func unionStrings(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+
+	result := slices.Clone(a)
+	for _, item := range b {
+		if !slices.Contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func (c *Config) setSource(key, path string) {
+	if c.sources == nil {
+		c.sources = make(map[string]string)
 	}
+	c.sources[key] = path
+}
+
+// SourceOf returns the path of the config file that set the given merge key,
+// or $NAME for a preference set by environment variable, and whether the
+// key was set at all.
+func (c *Config) SourceOf(key string) (string, bool) {
+	path, ok := c.sources[key]
+	return path, ok
+}
+
+// DefinedKeys returns the sorted merge keys that this config sets on its own,
+// such as "repository" or "preferences.theme".
+func (c *Config) DefinedKeys() []string {
+	probe := &Config{}
+	probe.Merge(c)
+
+	keys := make([]string, 0, len(probe.sources))
+	for key := range probe.sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func (c *Config) Save(path string) error {
@@ -199,18 +729,36 @@ func (c *Config) SaveWithHeader(path string, includeHeader bool) error {
 # Learn more: https://github.com/Cloudsky01/gh-rivet
 #
 # Configuration structure:
-# - repository: GitHub repository in owner/repo format
+# - repository: GitHub repository in owner/repo (or host/owner/repo) format
 # - preferences: User-specific settings (optional)
 #   - refreshInterval: Auto-refresh interval in seconds (0 = disabled)
 #   - theme: Color theme preference
 #   - keybindings: Keybinding style (vim, emacs, etc.)
+#   - branchHighlights: Branch glob patterns to emphasize in the runs table
+#   - host: GitHub Enterprise Server hostname (defaults to github.com)
+#   - layout: TUI layout, modern (default) or classic with a details panel
+#   - tablePageSize: Runs per table page (defaults to fitting the panel)
+#   - autoOpenMatch: Open the only remaining match when a filter is confirmed
+#   - groupPinned: List pinned workflows in the sidebar under their groups
+#   - rememberFilters: Restore each group's last filter when it is reopened
+#   - hideDescriptions: List groups and workflows without their descriptions
+#   - idleTimeout: Minutes without input before the TUI quits (0 = disabled)
+#   - timeFormat: Run times as relative, iso, short or a Go time layout
+#   - utcTimes: Show run times in UTC instead of the local time zone
+#   - openBehavior: What w and enter do on a run: browser, logs or jobs
+#   - sortMode: Order of groups and workflows: config, alpha or frequency
+#   - ghPath: gh executable to run instead of gh from PATH (ignored in .github/.rivet.yaml)
+#   - favoriteGroups: Group IDs listed first in the root group list
+#   - hiddenWorkflows: Workflow files left out of the group lists and search
 # - groups: Organize your workflows into groups
 #   - id: Unique identifier (auto-generated from name)
 #   - name: Display name shown in the TUI
 #   - description: Optional description
 #   - workflows: List of workflow filenames
 #   - pinnedWorkflows: Workflows to pin to the top
+#   - defaultWorkflow: One of the group's workflows to open when entering it
 #   - groups: Nested groups for hierarchical organization
+# - replaceGroups: Replace groups from lower-precedence configs instead of merging by id
 #
 # Configuration locations:
 #   User config: ~/.config/rivet/config.yaml (user-specific settings)
@@ -243,6 +791,27 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("configuration must have at least one group")
 	}
 
+	if c.Preferences != nil {
+		if err := ValidateLayout(c.Preferences.Layout); err != nil {
+			return err
+		}
+		if c.Preferences.TablePageSize < 0 {
+			return fmt.Errorf("tablePageSize must not be negative, got %d", c.Preferences.TablePageSize)
+		}
+		if c.Preferences.IdleTimeout < 0 {
+			return fmt.Errorf("idleTimeout must not be negative, got %d", c.Preferences.IdleTimeout)
+		}
+		if err := ValidateTimeFormat(c.Preferences.TimeFormat); err != nil {
+			return err
+		}
+		if err := ValidateOpenBehavior(c.Preferences.OpenBehavior); err != nil {
+			return err
+		}
+		if err := ValidateSortMode(c.Preferences.SortMode); err != nil {
+			return err
+		}
+	}
+
 	for _, group := range c.Groups {
 		if err := c.validateGroup(&group, ""); err != nil {
 			return err
@@ -266,6 +835,10 @@ func (c *Config) validateGroup(group *Group, path string) error {
 		return fmt.Errorf("group %s missing name", currentPath)
 	}
 
+	if group.DefaultWorkflow != "" && !group.HasWorkflow(group.DefaultWorkflow) {
+		return fmt.Errorf("defaultWorkflow %s of group %s is not one of its workflows", group.DefaultWorkflow, currentPath)
+	}
+
 	for _, pattern := range group.Jobs {
 		if _, err := regexp.Compile(pattern); err != nil {
 			return fmt.Errorf("invalid regex pattern in group %s: %s (%w)", currentPath, pattern, err)
@@ -281,6 +854,110 @@ func (c *Config) validateGroup(group *Group, path string) error {
 	return nil
 }
 
+// WorkflowFiles returns every configured workflow file once, in group order,
+// with its display name. Files without a workflowDefs name map to themselves.
+func (c *Config) WorkflowFiles() ([]string, map[string]string) {
+	var files []string
+	names := make(map[string]string)
+
+	var walk func(groups []Group)
+	walk = func(groups []Group) {
+		for i := range groups {
+			group := &groups[i]
+			for _, wf := range group.Workflows {
+				if _, seen := names[wf]; !seen {
+					files = append(files, wf)
+					names[wf] = wf
+				}
+			}
+			for _, def := range group.WorkflowDefs {
+				if _, seen := names[def.File]; !seen {
+					files = append(files, def.File)
+					names[def.File] = def.File
+				}
+				if def.Name != "" {
+					names[def.File] = def.Name
+				}
+			}
+			walk(group.Groups)
+		}
+	}
+	walk(c.Groups)
+
+	return files, names
+}
+
+// SetWorkflowNames gives every configured workflow without a display name
+// its name from names, keyed by file, and returns how many were filled in.
+// Workflows listed under workflows get a workflowDefs entry for the name.
+// Names that are empty or equal to the file are skipped.
+func (c *Config) SetWorkflowNames(names map[string]string) int {
+	var set func(groups []Group) int
+	set = func(groups []Group) int {
+		filled := 0
+		for i := range groups {
+			group := &groups[i]
+			for j := range group.WorkflowDefs {
+				def := &group.WorkflowDefs[j]
+				if name := names[def.File]; def.Name == "" && name != "" && name != def.File {
+					def.Name = name
+					filled++
+				}
+			}
+			for _, wf := range group.Workflows {
+				if name := names[wf]; name != "" && name != wf && group.GetWorkflowDef(wf) == nil {
+					group.WorkflowDefs = append(group.WorkflowDefs, Workflow{File: wf, Name: name})
+					filled++
+				}
+			}
+			filled += set(group.Groups)
+		}
+		return filled
+	}
+	return set(c.Groups)
+}
+
+// AddWorkflowNames names, in c, the workflows of merged that have no display
+// name, and returns how many were named. Each name is added as a workflowDefs
+// entry of the same group path in c, creating sparse groups where missing, so
+// c can be a higher tier that names workflows configured in lower ones.
+func (c *Config) AddWorkflowNames(merged *Config, names map[string]string) int {
+	var add func(groups []Group) int
+	add = func(groups []Group) int {
+		filled := 0
+		for i := range groups {
+			group := &groups[i]
+			var unnamed []string
+			for _, def := range group.WorkflowDefs {
+				if def.Name == "" {
+					unnamed = append(unnamed, def.File)
+				}
+			}
+			for _, wf := range group.Workflows {
+				if group.GetWorkflowDef(wf) == nil {
+					unnamed = append(unnamed, wf)
+				}
+			}
+			for _, wf := range unnamed {
+				name := names[wf]
+				if name == "" || name == wf {
+					continue
+				}
+				target := c.ensureGroupPath(merged.FindGroupPath(group))
+				if def := target.GetWorkflowDef(wf); def != nil {
+					def.Name = name
+				} else {
+					target.WorkflowDefs = append(target.WorkflowDefs, Workflow{File: wf, Name: name})
+				}
+				filled++
+			}
+			filled += add(group.Groups)
+		}
+		return filled
+	}
+	return add(merged.Groups)
+}
+
 func (g *Group) GetAllWorkflows() []string {
 	workflows := make([]string, 0)
 
@@ -306,6 +983,12 @@ func (g *Group) GetWorkflowDef(filename string) *Workflow {
 	return nil
 }
 
+// HasWorkflow reports whether file is one of the group's own workflows, not
+// counting its subgroups'
+func (g *Group) HasWorkflow(file string) bool {
+	return slices.Contains(g.Workflows, file) || g.GetWorkflowDef(file) != nil
+}
+
 func (g *Group) HasWorkflows() bool {
 	if len(g.Workflows) > 0 || len(g.WorkflowDefs) > 0 {
 		return true
@@ -341,6 +1024,372 @@ func (g *Group) TogglePin(workflowName string) {
 	}
 }
 
+// Shareable returns a copy of the config suitable for sharing with a team:
+// the repository and groups are kept, while personal preferences and pins are
+// stripped.
+func (c *Config) Shareable() *Config {
+	return &Config{
+		Repository: c.Repository,
+		Groups:     shareableGroups(c.Groups),
+	}
+}
+
+func shareableGroups(groups []Group) []Group {
+	if groups == nil {
+		return nil
+	}
+
+	result := make([]Group, len(groups))
+	for i, g := range groups {
+		g.PinnedWorkflows = nil
+		g.Groups = shareableGroups(g.Groups)
+		result[i] = g
+	}
+	return result
+}
+
+// ErrPinnedByTeam is returned when unpinning a workflow whose pin comes from
+// the shared repository config rather than one of the personal tiers
+var ErrPinnedByTeam = errors.New("workflow is pinned by a shared config")
+
+// TogglePersonalPin toggles the pin for workflow on the last group of
+// groupPath (a path through c.Groups). userPaths are the personal config
+// tiers, lowest precedence first: a new pin is written to the last of them,
+// creating the file and any missing groups as needed, and an unpin removes
+// the pin from every personal tier that holds it. The shared config is left
+// untouched. Returns whether the workflow is pinned after the toggle.
+func (c *Config) TogglePersonalPin(userPaths []string, groupPath []*Group, workflow string) (bool, error) {
+	if len(groupPath) == 0 {
+		return false, fmt.Errorf("no group selected")
+	}
+	group := groupPath[len(groupPath)-1]
+
+	tiers, err := loadPersonalTiers(userPaths)
+	if err != nil {
+		return false, err
+	}
+
+	pinned := group.IsPinned(workflow)
+	if !tiers.toggle(groupPath, workflow, pinned) {
+		return true, ErrPinnedByTeam
+	}
+	if err := tiers.save(); err != nil {
+		return pinned, err
+	}
+
+	group.TogglePin(workflow)
+	return !pinned, nil
+}
+
+// TogglePersonalPins toggles the pins of several workflows on the last group
+// of groupPath like TogglePersonalPin, saving each changed tier once.
+// Workflows pinned by the shared config are left pinned. Returns how many
+// workflows were pinned and unpinned.
+func (c *Config) TogglePersonalPins(userPaths []string, groupPath []*Group, workflows []string) (pinned, unpinned int, err error) {
+	if len(groupPath) == 0 {
+		return 0, 0, fmt.Errorf("no group selected")
+	}
+	group := groupPath[len(groupPath)-1]
+
+	tiers, err := loadPersonalTiers(userPaths)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var toggled []string
+	for _, workflow := range workflows {
+		if tiers.toggle(groupPath, workflow, group.IsPinned(workflow)) {
+			toggled = append(toggled, workflow)
+		}
+	}
+	if len(toggled) == 0 {
+		return 0, 0, nil
+	}
+	if err := tiers.save(); err != nil {
+		return 0, 0, err
+	}
+
+	for _, workflow := range toggled {
+		group.TogglePin(workflow)
+		if group.IsPinned(workflow) {
+			pinned++
+		} else {
+			unpinned++
+		}
+	}
+	return pinned, unpinned, nil
+}
+
+// personalTier is one personal config file loaded for a pin change
+type personalTier struct {
+	path    string
+	cfg     *Config
+	changed bool
+}
+
+type personalTiers []*personalTier
+
+// loadPersonalTiers loads the configs at paths, starting from an empty config
+// for a file that does not exist yet
+func loadPersonalTiers(paths []string) (personalTiers, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no personal config to save pins to")
+	}
+
+	tiers := make(personalTiers, 0, len(paths))
+	for _, path := range paths {
+		cfg, err := LoadFromPath(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			cfg = &Config{}
+		} else if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, &personalTier{path: path, cfg: cfg})
+	}
+	return tiers, nil
+}
+
+// toggle pins workflow in the highest tier, or unpins it from every tier
+// that holds it when pinned is set. It reports false when the pin is held by
+// no personal tier, so it cannot be removed.
+func (tiers personalTiers) toggle(groupPath []*Group, workflow string, pinned bool) bool {
+	if !pinned {
+		last := tiers[len(tiers)-1]
+		last.cfg.ensureGroupPath(groupPath).TogglePin(workflow)
+		last.changed = true
+		return true
+	}
+
+	found := false
+	for _, tier := range tiers {
+		group := tier.cfg.lookupGroupPath(groupPath)
+		if group != nil && group.IsPinned(workflow) {
+			group.TogglePin(workflow)
+			tier.changed = true
+			found = true
+		}
+	}
+	return found
+}
+
+func (tiers personalTiers) save() error {
+	for _, tier := range tiers {
+		if !tier.changed {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(tier.path), 0755); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := tier.cfg.Save(tier.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearAllPins removes every pinned workflow from every group and returns how
+// many pins were removed
+func (c *Config) ClearAllPins() int {
+	var clearPins func(groups []Group) int
+	clearPins = func(groups []Group) int {
+		cleared := 0
+		for i := range groups {
+			cleared += len(groups[i].PinnedWorkflows)
+			groups[i].PinnedWorkflows = nil
+			cleared += clearPins(groups[i].Groups)
+		}
+		return cleared
+	}
+	return clearPins(c.Groups)
+}
+
+// ClearPersonalPins removes every pin from the personal config tiers at
+// userPaths, saves them, and removes the same pins from c. Pins that come
+// from the shared config are not personal and stay. Returns how many of c's
+// pins were cleared, so a pin held by several tiers counts once.
+func (c *Config) ClearPersonalPins(userPaths []string) (int, error) {
+	before := len(c.GetAllPinnedWorkflows())
+	for _, userPath := range userPaths {
+		userCfg, err := LoadFromPath(userPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+
+		personal := pinTree(userCfg.Groups)
+		if userCfg.ClearAllPins() == 0 {
+			continue
+		}
+		if err := userCfg.Save(userPath); err != nil {
+			return 0, err
+		}
+		removePins(c.Groups, personal)
+	}
+	return before - len(c.GetAllPinnedWorkflows()), nil
+}
+
+// MovePinsToUser moves the pins in the team config at teamPath into the
+// user-tier config at userPath, creating it if needed, and strips them from
+// the team config. Pins are personal, so a team default should not carry
+// them. Returns the pins that were moved.
+func MovePinsToUser(teamPath, userPath string) ([]PinnedWorkflow, error) {
+	teamCfg, err := LoadFromPath(teamPath)
+	if err != nil {
+		return nil, err
+	}
+	pinned := teamCfg.GetAllPinnedWorkflows()
+	if len(pinned) == 0 {
+		return nil, nil
+	}
+
+	userCfg, err := LoadFromPath(userPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		userCfg = &Config{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, pin := range pinned {
+		userGroup := userCfg.ensureGroupPath(teamCfg.FindGroupPath(pin.Group))
+		if !userGroup.IsPinned(pin.WorkflowName) {
+			userGroup.TogglePin(pin.WorkflowName)
+		}
+	}
+
+	// Save the pins before removing them, so a failure never loses them
+	if err := os.MkdirAll(filepath.Dir(userPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := userCfg.Save(userPath); err != nil {
+		return nil, err
+	}
+	teamCfg.ClearAllPins()
+	if err := teamCfg.Save(teamPath); err != nil {
+		return nil, err
+	}
+
+	return pinned, nil
+}
+
+// pinTree copies only the IDs, pins and subgroups of groups
+func pinTree(groups []Group) []Group {
+	tree := make([]Group, len(groups))
+	for i, g := range groups {
+		tree[i] = Group{ID: g.ID, PinnedWorkflows: slices.Clone(g.PinnedWorkflows), Groups: pinTree(g.Groups)}
+	}
+	return tree
+}
+
+// removePins unpins from groups the workflows pinned on the groups with the
+// same IDs in pins, recursively
+func removePins(groups []Group, pins []Group) {
+	for _, p := range pins {
+		idx := slices.IndexFunc(groups, func(g Group) bool { return g.ID == p.ID })
+		if idx < 0 {
+			continue
+		}
+		group := &groups[idx]
+		group.PinnedWorkflows = slices.DeleteFunc(group.PinnedWorkflows, func(wf string) bool {
+			return slices.Contains(p.PinnedWorkflows, wf)
+		})
+		removePins(group.Groups, p.Groups)
+	}
+}
+
+// ensureGroupPath walks c.Groups along the IDs of path, creating sparse groups
+// (ID and name only) where missing, and returns the last group
+func (c *Config) ensureGroupPath(path []*Group) *Group {
+	groups := &c.Groups
+	var current *Group
+
+	for _, g := range path {
+		idx := slices.IndexFunc(*groups, func(existing Group) bool { return existing.ID == g.ID })
+		if idx < 0 {
+			*groups = append(*groups, Group{ID: g.ID, Name: g.Name})
+			idx = len(*groups) - 1
+		}
+		current = &(*groups)[idx]
+		groups = &current.Groups
+	}
+
+	return current
+}
+
+// lookupGroupPath walks c.Groups along the IDs of path like ensureGroupPath,
+// but returns nil when a group is missing instead of creating it
+func (c *Config) lookupGroupPath(path []*Group) *Group {
+	groups := c.Groups
+	var current *Group
+
+	for _, g := range path {
+		idx := slices.IndexFunc(groups, func(existing Group) bool { return existing.ID == g.ID })
+		if idx < 0 {
+			return nil
+		}
+		current = &groups[idx]
+		groups = current.Groups
+	}
+
+	return current
+}
+
+// FindGroupPath returns the path of groups from the root to target, or nil if
+// target is not part of this config
+func (c *Config) FindGroupPath(target *Group) []*Group {
+	var search func(groups []Group, parents []*Group) []*Group
+	search = func(groups []Group, parents []*Group) []*Group {
+		for i := range groups {
+			path := append(slices.Clone(parents), &groups[i])
+			if &groups[i] == target {
+				return path
+			}
+			if found := search(groups[i].Groups, path); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return search(c.Groups, nil)
+}
+
+// FindWorkflowGroupPath returns the path to the first group listing
+// workflow, searching depth first, or nil if no group lists it
+func (c *Config) FindWorkflowGroupPath(workflow string) []*Group {
+	var search func(groups []Group, parents []*Group) []*Group
+	search = func(groups []Group, parents []*Group) []*Group {
+		for i := range groups {
+			path := append(slices.Clone(parents), &groups[i])
+			if groups[i].HasWorkflow(workflow) {
+				return path
+			}
+			if found := search(groups[i].Groups, path); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return search(c.Groups, nil)
+}
+
+// FindGroupByID returns the first group with id, searching depth first, or
+// nil if there is none
+func (c *Config) FindGroupByID(id string) *Group {
+	var search func(groups []Group) *Group
+	search = func(groups []Group) *Group {
+		for i := range groups {
+			if groups[i].ID == id {
+				return &groups[i]
+			}
+			if found := search(groups[i].Groups); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return search(c.Groups)
+}
+
 type PinnedWorkflow struct {
 	WorkflowName string
 	GroupPath    []string
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -80,7 +84,7 @@ func TestMerge(t *testing.T) {
 				"key2": "val2", // should add
 			},
 		},
-		Groups: []Group{{ID: "override", Name: "Override"}}, // Should replace
+		Groups: []Group{{ID: "override", Name: "Override"}}, // Should be appended
 	}
 
 	baseConfig.Merge(overrideConfig)
@@ -97,8 +101,8 @@ func TestMerge(t *testing.T) {
 		t.Errorf("Expected refresh interval 30, got %d", baseConfig.Preferences.RefreshInterval)
 	}
 
-	if len(baseConfig.Groups) != 1 || baseConfig.Groups[0].ID != "override" {
-		t.Error("Groups should be replaced by override config")
+	if len(baseConfig.Groups) != 2 || baseConfig.Groups[0].ID != "base" || baseConfig.Groups[1].ID != "override" {
+		t.Error("Groups with new IDs should be appended to base groups")
 	}
 
 	if val, ok := baseConfig.Preferences.CustomSettings["key1"]; !ok || val != "val1" {
@@ -216,6 +220,128 @@ func TestValidate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Classic layout",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{Layout: LayoutClassic},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Unknown layout",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{Layout: "fancy"},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Negative table page size",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{TablePageSize: -1},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Time format preset",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{TimeFormat: TimeFormatRelative},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Time format layout",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{TimeFormat: "02/01 15:04"},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Time format without layout elements",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{TimeFormat: "yyyy-mm-dd"},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Open behavior",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{OpenBehavior: OpenLogs},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Unknown open behavior",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{OpenBehavior: "terminal"},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Sort mode",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{SortMode: SortFrequency},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Unknown sort mode",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{SortMode: "newest"},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Default workflow in its group",
+			config: &Config{
+				Repository: "owner/repo",
+				Groups: []Group{{
+					ID: "deploy", Name: "Deploy", DefaultWorkflow: "deploy.yml",
+					WorkflowDefs: []Workflow{{File: "deploy.yml"}},
+				}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Default workflow only in a subgroup",
+			config: &Config{
+				Repository: "owner/repo",
+				Groups: []Group{{
+					ID: "ci", Name: "CI", DefaultWorkflow: "nightly.yml",
+					Workflows: []string{"build.yml"},
+					Groups:    []Group{{ID: "nightly", Name: "Nightly", Workflows: []string{"nightly.yml"}}},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Negative idle timeout",
+			config: &Config{
+				Repository:  "owner/repo",
+				Preferences: &Preferences{IdleTimeout: -5},
+				Groups:      []Group{{ID: "test", Name: "Test Group"}},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -230,3 +356,799 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesBranch(t *testing.T) {
+	patterns := []string{"main", "release/*"}
+
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{branch: "main", want: true},
+		{branch: "release/1.0", want: true},
+		{branch: "release/1.0/hotfix", want: false},
+		{branch: "feature/login", want: false},
+		{branch: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			if got := MatchesBranch(tt.branch, patterns); got != tt.want {
+				t.Errorf("MatchesBranch(%q) = %v, want %v", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeTracksSources(t *testing.T) {
+	team := &Config{
+		Repository:  "team/repo",
+		Preferences: &Preferences{RefreshInterval: 30, Theme: "light"},
+		Groups:      []Group{{ID: "ci", Name: "CI"}},
+		configPath:  "team.yaml",
+	}
+	user := &Config{
+		Preferences: &Preferences{RefreshInterval: 10},
+		configPath:  "user.yaml",
+	}
+
+	merged := &Config{}
+	merged.Merge(team)
+	merged.Merge(user)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "repository", want: "team.yaml"},
+		{key: "preferences.refreshInterval", want: "user.yaml"},
+		{key: "preferences.theme", want: "team.yaml"},
+		{key: "groups.ci", want: "team.yaml"},
+	}
+
+	for _, tt := range tests {
+		got, ok := merged.SourceOf(tt.key)
+		if !ok {
+			t.Errorf("expected source for %s", tt.key)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("SourceOf(%s) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+
+	if _, ok := merged.SourceOf("preferences.keybindings"); ok {
+		t.Error("unset key should have no source")
+	}
+}
+
+func TestDefinedKeys(t *testing.T) {
+	cfg := &Config{
+		Repository: "owner/repo",
+		Preferences: &Preferences{
+			Theme:          "dark",
+			CustomSettings: map[string]string{"editor": "vim"},
+		},
+	}
+
+	keys := cfg.DefinedKeys()
+	expected := []string{"preferences.customSettings.editor", "preferences.theme", "repository"}
+	if len(keys) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("key %d: expected %s, got %s", i, key, keys[i])
+		}
+	}
+}
+
+func TestMergeGroupsByID(t *testing.T) {
+	base := &Config{
+		Groups: []Group{
+			{
+				ID:              "ci",
+				Name:            "CI",
+				Workflows:       []string{"test.yml", "build.yml"},
+				WorkflowDefs:    []Workflow{{File: "lint.yml", Name: "Lint"}},
+				PinnedWorkflows: []string{"test.yml"},
+				Groups:          []Group{{ID: "nightly", Name: "Nightly", Workflows: []string{"nightly.yml"}}},
+			},
+			{ID: "deploy", Name: "Deploy", Workflows: []string{"deploy.yml"}},
+		},
+	}
+
+	override := &Config{
+		Groups: []Group{
+			{
+				ID:              "ci",
+				Description:     "Continuous integration",
+				Workflows:       []string{"build.yml", "e2e.yml"},
+				WorkflowDefs:    []Workflow{{File: "lint.yml", Name: "Lint (strict)"}},
+				PinnedWorkflows: []string{"e2e.yml"},
+				Groups:          []Group{{ID: "nightly", Workflows: []string{"fuzz.yml"}}},
+			},
+			{ID: "docs", Name: "Docs", Workflows: []string{"docs.yml"}},
+		},
+	}
+
+	base.Merge(override)
+
+	if len(base.Groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(base.Groups))
+	}
+
+	ci := base.Groups[0]
+	if ci.Name != "CI" {
+		t.Errorf("expected name to be kept when override is empty, got %q", ci.Name)
+	}
+	if ci.Description != "Continuous integration" {
+		t.Errorf("expected description from override, got %q", ci.Description)
+	}
+
+	expectedWorkflows := []string{"test.yml", "build.yml", "e2e.yml"}
+	if len(ci.Workflows) != len(expectedWorkflows) {
+		t.Fatalf("expected workflows %v, got %v", expectedWorkflows, ci.Workflows)
+	}
+	for i, wf := range expectedWorkflows {
+		if ci.Workflows[i] != wf {
+			t.Errorf("workflow %d: expected %s, got %s", i, wf, ci.Workflows[i])
+		}
+	}
+
+	if len(ci.WorkflowDefs) != 1 || ci.WorkflowDefs[0].Name != "Lint (strict)" {
+		t.Errorf("expected workflow def name from override, got %v", ci.WorkflowDefs)
+	}
+
+	if len(ci.PinnedWorkflows) != 2 || ci.PinnedWorkflows[0] != "e2e.yml" || ci.PinnedWorkflows[1] != "test.yml" {
+		t.Errorf("expected override pins followed by base pins, got %v", ci.PinnedWorkflows)
+	}
+
+	if len(ci.Groups) != 1 || len(ci.Groups[0].Workflows) != 2 {
+		t.Errorf("expected nested group to be merged, got %v", ci.Groups)
+	}
+
+	if base.Groups[1].ID != "deploy" || base.Groups[2].ID != "docs" {
+		t.Errorf("expected untouched and new groups to be kept in order, got %s, %s", base.Groups[1].ID, base.Groups[2].ID)
+	}
+}
+
+func TestMergeReplaceGroups(t *testing.T) {
+	base := &Config{
+		Groups: []Group{{ID: "ci", Name: "CI"}, {ID: "deploy", Name: "Deploy"}},
+	}
+	override := &Config{
+		ReplaceGroups: true,
+		Groups:        []Group{{ID: "mine", Name: "Mine"}},
+	}
+
+	base.Merge(override)
+
+	if len(base.Groups) != 1 || base.Groups[0].ID != "mine" {
+		t.Errorf("expected groups to be replaced, got %v", base.Groups)
+	}
+	if _, ok := base.SourceOf("groups.ci"); ok {
+		t.Error("replaced groups should not keep their sources")
+	}
+}
+
+func TestMergePinsAcrossTiers(t *testing.T) {
+	team := &Config{
+		Groups: []Group{
+			{
+				ID:              "ci",
+				Name:            "CI",
+				Workflows:       []string{"test.yml", "build.yml"},
+				PinnedWorkflows: []string{"test.yml"},
+				Groups: []Group{
+					{ID: "nightly", Name: "Nightly", Workflows: []string{"nightly.yml"}},
+				},
+			},
+		},
+	}
+	user := &Config{
+		Groups: []Group{
+			{
+				ID:              "ci",
+				PinnedWorkflows: []string{"build.yml", "test.yml"},
+				Groups: []Group{
+					{ID: "nightly", PinnedWorkflows: []string{"nightly.yml"}},
+				},
+			},
+		},
+	}
+
+	merged := &Config{}
+	merged.Merge(team)
+	merged.Merge(user)
+
+	ci := merged.Groups[0]
+	if len(ci.PinnedWorkflows) != 2 || ci.PinnedWorkflows[0] != "build.yml" || ci.PinnedWorkflows[1] != "test.yml" {
+		t.Errorf("expected unioned pins without duplicates, got %v", ci.PinnedWorkflows)
+	}
+
+	if len(team.Groups[0].PinnedWorkflows) != 1 {
+		t.Errorf("merging should not modify the team config, got %v", team.Groups[0].PinnedWorkflows)
+	}
+
+	pinned := merged.GetAllPinnedWorkflows()
+	if len(pinned) != 3 {
+		t.Fatalf("expected 3 pinned workflows, got %d", len(pinned))
+	}
+	if pinned[2].WorkflowName != "nightly.yml" || pinned[2].Group.ID != "nightly" {
+		t.Errorf("expected nested pin from user tier, got %s in %s", pinned[2].WorkflowName, pinned[2].Group.ID)
+	}
+}
+
+func TestTogglePersonalPinLeavesTeamConfigUnchanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, ".github", ".rivet.yaml")
+	userPath := filepath.Join(tmpDir, ".git", "rivet", "config.yaml")
+
+	teamContent := `repository: owner/repo
+groups:
+  - id: ci
+    name: CI
+    workflows:
+      - test.yml
+      - build.yml
+    groups:
+      - id: nightly
+        name: Nightly
+        workflows:
+          - nightly.yml
+`
+	if err := os.MkdirAll(filepath.Dir(teamPath), 0755); err != nil {
+		t.Fatalf("failed to create team dir: %v", err)
+	}
+	if err := os.WriteFile(teamPath, []byte(teamContent), 0644); err != nil {
+		t.Fatalf("failed to write team config: %v", err)
+	}
+
+	cfg, err := LoadMerged([]string{teamPath})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	nightly := &cfg.Groups[0].Groups[0]
+	pinned, err := cfg.TogglePersonalPin([]string{userPath}, cfg.FindGroupPath(nightly), "nightly.yml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !pinned || !nightly.IsPinned("nightly.yml") {
+		t.Fatal("expected workflow to be pinned in memory")
+	}
+
+	data, err := os.ReadFile(teamPath)
+	if err != nil {
+		t.Fatalf("failed to read team config: %v", err)
+	}
+	if string(data) != teamContent {
+		t.Errorf("team config was modified:\n%s", data)
+	}
+
+	reloaded, err := LoadMerged([]string{teamPath, userPath})
+	if err != nil {
+		t.Fatalf("failed to reload config: %v", err)
+	}
+	if err := reloaded.Validate(); err != nil {
+		t.Fatalf("merged config is invalid: %v", err)
+	}
+	if !reloaded.Groups[0].Groups[0].IsPinned("nightly.yml") {
+		t.Error("expected pin to be persisted to the user tier")
+	}
+
+	pinned, err = reloaded.TogglePersonalPin([]string{userPath}, reloaded.FindGroupPath(&reloaded.Groups[0].Groups[0]), "nightly.yml")
+	if err != nil || pinned {
+		t.Fatalf("expected unpin to succeed, got pinned=%v err=%v", pinned, err)
+	}
+}
+
+func TestTogglePersonalPinTeamPin(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &Config{
+		Groups: []Group{{ID: "ci", Name: "CI", PinnedWorkflows: []string{"test.yml"}}},
+	}
+
+	_, err := cfg.TogglePersonalPin([]string{filepath.Join(tmpDir, "user.yaml")}, cfg.FindGroupPath(&cfg.Groups[0]), "test.yml")
+	if !errors.Is(err, ErrPinnedByTeam) {
+		t.Fatalf("expected ErrPinnedByTeam, got %v", err)
+	}
+	if !cfg.Groups[0].IsPinned("test.yml") {
+		t.Error("team pin should remain")
+	}
+}
+
+func TestTogglePersonalPinGlobalTier(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, "team.yaml")
+	globalPath := filepath.Join(tmpDir, "global.yaml")
+	projectPath := filepath.Join(tmpDir, "project.yaml")
+
+	if err := os.WriteFile(teamPath, []byte("repository: owner/repo\ngroups:\n  - id: ci\n    name: CI\n    workflows: [test.yml, build.yml]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(globalPath, []byte("groups:\n  - id: ci\n    name: CI\n    pinnedWorkflows: [build.yml]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadMerged([]string{teamPath, globalPath})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	tiers := []string{globalPath, projectPath}
+
+	pinned, err := cfg.TogglePersonalPin(tiers, cfg.FindGroupPath(&cfg.Groups[0]), "build.yml")
+	if err != nil || pinned {
+		t.Fatalf("expected the global pin to be removed, got pinned=%v err=%v", pinned, err)
+	}
+	globalCfg, err := LoadFromPath(globalPath)
+	if err != nil {
+		t.Fatalf("failed to reload global config: %v", err)
+	}
+	if globalCfg.Groups[0].IsPinned("build.yml") {
+		t.Error("expected the pin to be removed from the global config")
+	}
+	if _, err := os.Stat(projectPath); !os.IsNotExist(err) {
+		t.Errorf("expected the project config to be left alone, got %v", err)
+	}
+
+	pinned, err = cfg.TogglePersonalPin(tiers, cfg.FindGroupPath(&cfg.Groups[0]), "test.yml")
+	if err != nil || !pinned {
+		t.Fatalf("expected the pin to be added, got pinned=%v err=%v", pinned, err)
+	}
+	projectCfg, err := LoadFromPath(projectPath)
+	if err != nil {
+		t.Fatalf("failed to load project config: %v", err)
+	}
+	if !projectCfg.Groups[0].IsPinned("test.yml") {
+		t.Error("expected a new pin to be written to the highest tier")
+	}
+}
+
+func TestTogglePersonalPins(t *testing.T) {
+	tmpDir := t.TempDir()
+	userPath := filepath.Join(tmpDir, "user.yaml")
+	cfg := &Config{
+		Groups: []Group{{ID: "ci", Name: "CI", PinnedWorkflows: []string{"test.yml"}}},
+	}
+	groupPath := cfg.FindGroupPath(&cfg.Groups[0])
+
+	pinned, unpinned, err := cfg.TogglePersonalPins([]string{userPath}, groupPath, []string{"test.yml", "build.yml", "lint.yml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pinned != 2 || unpinned != 0 {
+		t.Errorf("expected 2 pinned and the team pin skipped, got %d pinned, %d unpinned", pinned, unpinned)
+	}
+
+	userCfg, err := LoadFromPath(userPath)
+	if err != nil {
+		t.Fatalf("failed to load user config: %v", err)
+	}
+	if got := userCfg.Groups[0].PinnedWorkflows; !slices.Equal(got, []string{"build.yml", "lint.yml"}) {
+		t.Errorf("expected only the personal pins saved, got %v", got)
+	}
+
+	pinned, unpinned, err = cfg.TogglePersonalPins([]string{userPath}, groupPath, []string{"build.yml", "deploy.yml"})
+	if err != nil || pinned != 1 || unpinned != 1 {
+		t.Fatalf("expected 1 pinned and 1 unpinned, got %d, %d, %v", pinned, unpinned, err)
+	}
+	if cfg.Groups[0].IsPinned("build.yml") || !cfg.Groups[0].IsPinned("deploy.yml") {
+		t.Errorf("expected the pins to be toggled in memory, got %v", cfg.Groups[0].PinnedWorkflows)
+	}
+}
+
+func TestClearPersonalPins(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, "team.yaml")
+	userPath := filepath.Join(tmpDir, "user.yaml")
+
+	teamContent := `repository: owner/repo
+groups:
+  - id: ci
+    name: CI
+    workflows: [test.yml, build.yml]
+    pinnedWorkflows: [test.yml]
+    groups:
+      - id: nightly
+        name: Nightly
+        workflows: [nightly.yml]
+`
+	userContent := `repository: ""
+groups:
+  - id: ci
+    name: CI
+    pinnedWorkflows: [build.yml]
+    groups:
+      - id: nightly
+        name: Nightly
+        pinnedWorkflows: [nightly.yml]
+`
+	if err := os.WriteFile(teamPath, []byte(teamContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(userPath, []byte(userContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadMerged([]string{teamPath, userPath})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := len(cfg.GetAllPinnedWorkflows()); got != 3 {
+		t.Fatalf("expected 3 merged pins, got %d", got)
+	}
+
+	cleared, err := cfg.ClearPersonalPins([]string{userPath})
+	if err != nil {
+		t.Fatalf("ClearPersonalPins() error = %v", err)
+	}
+	if cleared != 2 {
+		t.Errorf("ClearPersonalPins() = %d, want 2", cleared)
+	}
+
+	pinned := cfg.GetAllPinnedWorkflows()
+	if len(pinned) != 1 || pinned[0].WorkflowName != "test.yml" {
+		t.Errorf("expected only the team pin to remain, got %+v", pinned)
+	}
+
+	userCfg, err := LoadFromPath(userPath)
+	if err != nil {
+		t.Fatalf("failed to reload user config: %v", err)
+	}
+	if got := len(userCfg.GetAllPinnedWorkflows()); got != 0 {
+		t.Errorf("expected no pins saved in the user config, got %d", got)
+	}
+
+	if cleared, err := cfg.ClearPersonalPins([]string{filepath.Join(tmpDir, "missing.yaml")}); err != nil || cleared != 0 {
+		t.Errorf("ClearPersonalPins() without a user config = %d, %v; want 0, nil", cleared, err)
+	}
+}
+
+func TestClearPersonalPinsEveryTier(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, "team.yaml")
+	globalPath := filepath.Join(tmpDir, "global.yaml")
+	projectPath := filepath.Join(tmpDir, "project.yaml")
+
+	files := map[string]string{
+		teamPath:    "repository: owner/repo\ngroups:\n  - id: ci\n    name: CI\n    workflows: [test.yml, build.yml, lint.yml]\n    pinnedWorkflows: [test.yml]\n",
+		globalPath:  "groups:\n  - id: ci\n    name: CI\n    pinnedWorkflows: [build.yml, lint.yml]\n",
+		projectPath: "groups:\n  - id: ci\n    name: CI\n    pinnedWorkflows: [lint.yml]\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := LoadMerged([]string{teamPath, globalPath, projectPath})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	cleared, err := cfg.ClearPersonalPins([]string{globalPath, projectPath})
+	if err != nil {
+		t.Fatalf("ClearPersonalPins() error = %v", err)
+	}
+	if cleared != 2 {
+		t.Errorf("ClearPersonalPins() = %d, want 2", cleared)
+	}
+	if pinned := cfg.GetAllPinnedWorkflows(); len(pinned) != 1 || pinned[0].WorkflowName != "test.yml" {
+		t.Errorf("expected only the team pin to remain, got %+v", pinned)
+	}
+
+	for _, path := range []string{globalPath, projectPath} {
+		userCfg, err := LoadFromPath(path)
+		if err != nil {
+			t.Fatalf("failed to reload %s: %v", path, err)
+		}
+		if got := len(userCfg.GetAllPinnedWorkflows()); got != 0 {
+			t.Errorf("expected no pins saved in %s, got %d", path, got)
+		}
+	}
+}
+
+func TestShareable(t *testing.T) {
+	cfg := &Config{
+		Repository:  "owner/repo",
+		Preferences: &Preferences{RefreshInterval: 10, Theme: "dark"},
+		Groups: []Group{
+			{
+				ID:              "ci",
+				Name:            "CI",
+				Workflows:       []string{"test.yml"},
+				PinnedWorkflows: []string{"test.yml"},
+				Groups: []Group{
+					{ID: "nightly", Name: "Nightly", PinnedWorkflows: []string{"nightly.yml"}},
+				},
+			},
+		},
+	}
+
+	shared := cfg.Shareable()
+
+	if shared.Repository != "owner/repo" {
+		t.Errorf("expected repository to be kept, got %q", shared.Repository)
+	}
+	if shared.Preferences != nil {
+		t.Error("expected preferences to be stripped")
+	}
+	if len(shared.Groups) != 1 || len(shared.Groups[0].Workflows) != 1 {
+		t.Fatalf("expected groups and workflows to be kept, got %v", shared.Groups)
+	}
+	if len(shared.GetAllPinnedWorkflows()) != 0 {
+		t.Error("expected pins to be stripped")
+	}
+	if len(cfg.GetAllPinnedWorkflows()) != 2 {
+		t.Error("original config should keep its pins")
+	}
+}
+
+func TestSetWorkflowNames(t *testing.T) {
+	cfg := &Config{
+		Groups: []Group{
+			{
+				ID:           "ci",
+				Workflows:    []string{"test.yml", "lint.yml"},
+				WorkflowDefs: []Workflow{{File: "build.yml"}, {File: "release.yml", Name: "Ship it"}},
+				Groups: []Group{
+					{ID: "nightly", Workflows: []string{"nightly.yml"}},
+				},
+			},
+		},
+	}
+
+	filled := cfg.SetWorkflowNames(map[string]string{
+		"test.yml":    "Tests",
+		"lint.yml":    "lint.yml",
+		"build.yml":   "Build",
+		"release.yml": "Release",
+		"nightly.yml": "Nightly",
+	})
+	if filled != 3 {
+		t.Errorf("SetWorkflowNames() = %d, want 3", filled)
+	}
+
+	_, names := cfg.WorkflowFiles()
+	want := map[string]string{
+		"test.yml":    "Tests",
+		"lint.yml":    "lint.yml",
+		"build.yml":   "Build",
+		"release.yml": "Ship it",
+		"nightly.yml": "Nightly",
+	}
+	for file, name := range want {
+		if names[file] != name {
+			t.Errorf("name of %s = %q, want %q", file, names[file], name)
+		}
+	}
+
+	if filled := cfg.SetWorkflowNames(map[string]string{"test.yml": "Other"}); filled != 0 {
+		t.Errorf("second SetWorkflowNames() = %d, want 0 as names are kept", filled)
+	}
+}
+
+func TestAddWorkflowNames(t *testing.T) {
+	team := &Config{
+		Repository: "owner/repo",
+		Groups: []Group{
+			{
+				ID:           "ci",
+				Name:         "CI",
+				Workflows:    []string{"test.yml"},
+				WorkflowDefs: []Workflow{{File: "release.yml", Name: "Ship it"}},
+				Groups: []Group{
+					{ID: "nightly", Name: "Nightly", Workflows: []string{"nightly.yml"}},
+				},
+			},
+		},
+	}
+	user := &Config{Groups: []Group{{ID: "ci", WorkflowDefs: []Workflow{{File: "test.yml"}}}}}
+	merged := &Config{}
+	merged.Merge(team)
+	merged.Merge(user)
+
+	filled := user.AddWorkflowNames(merged, map[string]string{
+		"test.yml":    "Tests",
+		"release.yml": "Release",
+		"nightly.yml": "Nightly",
+	})
+	if filled != 2 {
+		t.Errorf("AddWorkflowNames() = %d, want 2", filled)
+	}
+
+	if len(team.Groups[0].WorkflowDefs) != 1 || len(team.Groups[0].Groups[0].WorkflowDefs) != 0 {
+		t.Errorf("expected the merged tiers to be left alone, got %+v", team.Groups)
+	}
+
+	merged = &Config{}
+	merged.Merge(team)
+	merged.Merge(user)
+	_, names := merged.WorkflowFiles()
+	want := map[string]string{
+		"test.yml":    "Tests",
+		"release.yml": "Ship it",
+		"nightly.yml": "Nightly",
+	}
+	for file, name := range want {
+		if names[file] != name {
+			t.Errorf("name of %s = %q, want %q", file, names[file], name)
+		}
+	}
+}
+
+func TestMovePinsToUser(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, ".rivet.yaml")
+	userPath := filepath.Join(tmpDir, "user", "config.yaml")
+
+	teamContent := `repository: owner/repo
+groups:
+  - id: ci
+    name: CI
+    workflows: [test.yml, build.yml]
+    pinnedWorkflows: [test.yml]
+    groups:
+      - id: nightly
+        name: Nightly
+        workflows: [nightly.yml]
+        pinnedWorkflows: [nightly.yml]
+`
+	if err := os.WriteFile(teamPath, []byte(teamContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := MovePinsToUser(teamPath, userPath)
+	if err != nil {
+		t.Fatalf("MovePinsToUser() error = %v", err)
+	}
+	if len(moved) != 2 {
+		t.Fatalf("expected 2 pins moved, got %+v", moved)
+	}
+
+	teamCfg, err := LoadFromPath(teamPath)
+	if err != nil {
+		t.Fatalf("failed to reload team config: %v", err)
+	}
+	if got := len(teamCfg.GetAllPinnedWorkflows()); got != 0 {
+		t.Errorf("expected the team config to have no pins, got %d", got)
+	}
+	if len(teamCfg.Groups[0].Workflows) != 2 {
+		t.Errorf("expected the team config to keep its workflows, got %v", teamCfg.Groups[0].Workflows)
+	}
+
+	merged, err := LoadMerged([]string{teamPath, userPath})
+	if err != nil {
+		t.Fatalf("failed to load merged config: %v", err)
+	}
+	var names []string
+	for _, pin := range merged.GetAllPinnedWorkflows() {
+		names = append(names, strings.Join(pin.GroupPath, "/")+"/"+pin.WorkflowName)
+	}
+	if fmt.Sprint(names) != "[CI/test.yml CI/Nightly/nightly.yml]" {
+		t.Errorf("expected the pins in the merged config, got %v", names)
+	}
+
+	if moved, err := MovePinsToUser(teamPath, userPath); err != nil || len(moved) != 0 {
+		t.Errorf("second MovePinsToUser() = %v, %v; want nothing to move", moved, err)
+	}
+}
+
+func TestToggleFavoriteGroup(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "rivet", "config.yaml")
+	cfg := &Config{
+		Repository: "owner/repo",
+		Groups: []Group{
+			{ID: "ci", Name: "CI", Groups: []Group{{ID: "nightly", Name: "Nightly"}}},
+			{ID: "deploy", Name: "Deploy"},
+		},
+	}
+
+	if group := cfg.FindGroupByID("nightly"); group != &cfg.Groups[0].Groups[0] {
+		t.Fatalf("FindGroupByID(nightly) = %v, want the nested group", group)
+	}
+	if cfg.FindGroupByID("missing") != nil {
+		t.Fatal("expected no group for an unknown ID")
+	}
+
+	for _, id := range []string{"nightly", "deploy"} {
+		favorite, err := cfg.ToggleFavoriteGroup(userPath, id)
+		if err != nil || !favorite {
+			t.Fatalf("ToggleFavoriteGroup(%s) = %v, %v; want true", id, favorite, err)
+		}
+	}
+	favorite, err := cfg.ToggleFavoriteGroup(userPath, "nightly")
+	if err != nil || favorite {
+		t.Fatalf("expected the second toggle to unstar, got %v, %v", favorite, err)
+	}
+	if !slices.Equal(cfg.GetFavoriteGroups(), []string{"deploy"}) {
+		t.Errorf("favorites in memory = %v, want [deploy]", cfg.GetFavoriteGroups())
+	}
+
+	saved, err := LoadFromPath(userPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(saved.GetFavoriteGroups(), []string{"deploy"}) || len(saved.Groups) != 0 {
+		t.Errorf("expected only the favorites saved to the user config, got %+v", saved)
+	}
+}
+
+func TestToggleHiddenWorkflow(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := &Config{
+		Preferences: &Preferences{FavoriteGroups: []string{"ci"}},
+		Groups:      []Group{{ID: "ci", Name: "CI", Workflows: []string{"build.yml", "bot.yml"}}},
+	}
+
+	hidden, err := cfg.ToggleHiddenWorkflow(userPath, "bot.yml")
+	if err != nil || !hidden {
+		t.Fatalf("ToggleHiddenWorkflow(bot.yml) = %v, %v; want true", hidden, err)
+	}
+	if !cfg.IsHiddenWorkflow("bot.yml") || cfg.IsHiddenWorkflow("build.yml") {
+		t.Errorf("hidden workflows = %v, want [bot.yml]", cfg.GetHiddenWorkflows())
+	}
+
+	saved, err := LoadFromPath(userPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(saved.GetHiddenWorkflows(), []string{"bot.yml"}) || len(saved.Groups) != 0 || len(saved.GetFavoriteGroups()) != 0 {
+		t.Errorf("expected only the hidden workflows saved to the user config, got %+v", saved.Preferences)
+	}
+
+	hidden, err = cfg.ToggleHiddenWorkflow(userPath, "bot.yml")
+	if err != nil || hidden {
+		t.Fatalf("expected the second toggle to show the workflow, got %v, %v", hidden, err)
+	}
+	if len(cfg.GetHiddenWorkflows()) != 0 {
+		t.Errorf("expected no hidden workflows, got %v", cfg.GetHiddenWorkflows())
+	}
+
+	// The emptied list is saved, so it keeps clearing the lists of lower tiers
+	data, err := os.ReadFile(userPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "hiddenWorkflows: []") {
+		t.Errorf("expected the empty list saved to the user config, got:\n%s", data)
+	}
+}
+
+func TestMergeExplicitEmptyList(t *testing.T) {
+	tmpDir := t.TempDir()
+	teamPath := filepath.Join(tmpDir, "team.yaml")
+	userPath := filepath.Join(tmpDir, "user.yaml")
+
+	teamContent := `repository: owner/repo
+preferences:
+  favoriteGroups: [ci]
+  hiddenWorkflows: [bot.yml]
+`
+	userContent := `preferences:
+  hiddenWorkflows: []
+`
+	if err := os.WriteFile(teamPath, []byte(teamContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(userPath, []byte(userContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadMerged([]string{teamPath, userPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hidden := cfg.GetHiddenWorkflows(); len(hidden) != 0 {
+		t.Errorf("hidden workflows = %v, want the explicit empty list to override", hidden)
+	}
+	if source, _ := cfg.SourceOf("preferences.hiddenWorkflows"); source != userPath {
+		t.Errorf("hiddenWorkflows source = %q, want %q", source, userPath)
+	}
+	// An unset list leaves the lower tier's in effect
+	if favorites := cfg.GetFavoriteGroups(); !slices.Equal(favorites, []string{"ci"}) {
+		t.Errorf("favorite groups = %v, want [ci]", favorites)
+	}
+}
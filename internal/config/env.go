@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix starts the environment variables that override preferences. The
+// rest of the name is the preference's key in upper snake case, such as
+// RIVET_PREFERENCES_REFRESH_INTERVAL for refreshInterval.
+const EnvPrefix = "RIVET_PREFERENCES_"
+
+// PreferenceEnvVar returns the environment variable that overrides the
+// preference with the given YAML key
+func PreferenceEnvVar(key string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	for i, r := range key {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(rune(key[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// ApplyEnv overrides preferences with the environment variables lookup
+// finds, taking precedence over every config file. Numbers and booleans are
+// parsed with strconv, lists are comma-separated, and customSettings takes
+// comma-separated key=value pairs. An empty variable clears the preference.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	prefs := c.Preferences
+	if prefs == nil {
+		prefs = &Preferences{}
+	}
+	set := false
+
+	v := reflect.ValueOf(prefs).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		key, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		name := PreferenceEnvVar(key)
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := setFromEnv(v.Field(i), strings.TrimSpace(raw)); err != nil {
+			return fmt.Errorf("invalid $%s: %w", name, err)
+		}
+		c.setSource("preferences."+key, "$"+name)
+		set = true
+	}
+
+	if set {
+		c.Preferences = prefs
+	}
+	return nil
+}
+
+// setFromEnv parses raw into field according to its type
+func setFromEnv(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int:
+		n := 0
+		if raw != "" {
+			var err error
+			if n, err = strconv.Atoi(raw); err != nil {
+				return fmt.Errorf("expected a whole number, got %q", raw)
+			}
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b := false
+		if raw != "" {
+			var err error
+			if b, err = strconv.ParseBool(raw); err != nil {
+				return fmt.Errorf("expected true or false, got %q", raw)
+			}
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		settings := make(map[string]string)
+		for _, pair := range strings.Split(raw, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("expected key=value pairs, got %q", pair)
+			}
+			settings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		field.Set(reflect.ValueOf(settings))
+	default:
+		return fmt.Errorf("unsupported preference type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreferenceEnvVar(t *testing.T) {
+	for key, want := range map[string]string{
+		"theme":           "RIVET_PREFERENCES_THEME",
+		"refreshInterval": "RIVET_PREFERENCES_REFRESH_INTERVAL",
+		"ghPath":          "RIVET_PREFERENCES_GH_PATH",
+		"utcTimes":        "RIVET_PREFERENCES_UTC_TIMES",
+	} {
+		if got := PreferenceEnvVar(key); got != want {
+			t.Errorf("PreferenceEnvVar(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestApplyEnvOverridesEveryPreference(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `repository: owner/repo
+preferences:
+  refreshInterval: 30
+  theme: dark
+  hiddenWorkflows: [old.yml]
+groups:
+  - id: ci
+    name: CI
+    workflows: [build.yml]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		value string
+		want  any
+	}{
+		"refreshInterval":  {"60", 60},
+		"theme":            {"light", "light"},
+		"keybindings":      {"emacs", "emacs"},
+		"branchHighlights": {"main, release/*", []string{"main", "release/*"}},
+		"host":             {"ghe.example.com", "ghe.example.com"},
+		"layout":           {"classic", "classic"},
+		"tablePageSize":    {"15", 15},
+		"autoOpenMatch":    {"true", true},
+		"groupPinned":      {"1", true},
+		"rememberFilters":  {"true", true},
+		"hideDescriptions": {"true", true},
+		"idleTimeout":      {"10", 10},
+		"timeFormat":       {"relative", "relative"},
+		"utcTimes":         {"true", true},
+		"openBehavior":     {"logs", "logs"},
+		"sortMode":         {"alpha", "alpha"},
+		"ghPath":           {"/opt/gh", "/opt/gh"},
+		"favoriteGroups":   {"ci,deploy", StringList{"ci", "deploy"}},
+		"hiddenWorkflows":  {"", StringList(nil)},
+		"customSettings":   {"a=1, b=2", map[string]string{"a": "1", "b": "2"}},
+	}
+
+	fields := reflect.TypeOf(Preferences{})
+	for i := 0; i < fields.NumField(); i++ {
+		key, _, _ := strings.Cut(fields.Field(i).Tag.Get("yaml"), ",")
+		if _, ok := tests[key]; !ok {
+			t.Errorf("no override test for preference %s", key)
+		}
+	}
+
+	for key, tt := range tests {
+		t.Setenv(PreferenceEnvVar(key), tt.value)
+	}
+	cfg, err := LoadMerged([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefs := reflect.ValueOf(cfg.Preferences).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		key, _, _ := strings.Cut(fields.Field(i).Tag.Get("yaml"), ",")
+		tt, ok := tests[key]
+		if !ok {
+			continue
+		}
+		if got := prefs.Field(i).Interface(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %#v, want %#v", key, got, tt.want)
+		}
+		if source, _ := cfg.SourceOf("preferences." + key); source != "$"+PreferenceEnvVar(key) {
+			t.Errorf("%s source = %q, want the environment variable", key, source)
+		}
+	}
+}
+
+func TestApplyEnvRejectsInvalidValues(t *testing.T) {
+	for _, tt := range []struct{ key, value string }{
+		{"refreshInterval", "soon"},
+		{"autoOpenMatch", "maybe"},
+		{"customSettings", "novalue"},
+	} {
+		env := map[string]string{PreferenceEnvVar(tt.key): tt.value}
+		cfg := &Config{}
+		err := cfg.ApplyEnv(func(name string) (string, bool) {
+			value, ok := env[name]
+			return value, ok
+		})
+		if err == nil || !strings.Contains(err.Error(), PreferenceEnvVar(tt.key)) {
+			t.Errorf("%s=%q: expected an error naming the variable, got %v", tt.key, tt.value, err)
+		}
+	}
+}
+
+func TestApplyEnvWithoutVariables(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.ApplyEnv(func(string) (string, bool) { return "", false }); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Preferences != nil {
+		t.Errorf("expected no preferences, got %+v", cfg.Preferences)
+	}
+}
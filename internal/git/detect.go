@@ -35,22 +35,97 @@ func GetGitRepositoryRoot() (string, error) {
 	return "", fmt.Errorf("not in a git repository")
 }
 
+// DefaultRemote is the remote used for repository detection unless another
+// one is selected with SetRemote
+const DefaultRemote = "origin"
+
+// selectedRemote is the remote DetectRepository and DetectRemote read from
+var selectedRemote = DefaultRemote
+
+// SetRemote selects the git remote used for repository detection, such as
+// "upstream" for forks. An empty name restores the default (origin).
+func SetRemote(name string) {
+	if name == "" {
+		name = DefaultRemote
+	}
+	selectedRemote = name
+}
+
+// Remote is a git remote that points at a GitHub repository
+type Remote struct {
+	Name       string // Remote name, e.g. "origin"
+	Host       string // GitHub host, e.g. "github.com"
+	Repository string // Repository in owner/repo format
+}
+
+// FullName returns the repository in owner/repo format, prefixed with the
+// host for GitHub Enterprise Server remotes
+func (r Remote) FullName() string {
+	if r.Host != DefaultHost {
+		return r.Host + "/" + r.Repository
+	}
+	return r.Repository
+}
+
 // DetectRepository attempts to detect the GitHub repository from .git/config
-// Returns the repository in owner/repo format, or an error if detection fails
+// Returns the repository in owner/repo format, or host/owner/repo for GitHub
+// Enterprise Server remotes, or an error if detection fails
 func DetectRepository() (string, error) {
+	host, repo, err := DetectRemote()
+	if err != nil {
+		return "", err
+	}
+	return Remote{Host: host, Repository: repo}.FullName(), nil
+}
+
+// DetectRemote detects the host and repository of the selected remote
+// (origin by default). The host is "github.com" for GitHub and the server
+// hostname for GitHub Enterprise Server remotes.
+func DetectRemote() (host, repo string, err error) {
 	// Try to find .git/config in current directory or parent directories
 	gitConfigPath, err := findGitConfig()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	// Read and parse the config file
-	repo, err := parseGitConfig(gitConfigPath)
+	url, err := parseRemoteURL(gitConfigPath, selectedRemote)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	return repo, nil
+	host, repo = extractHostAndRepoFromURL(url)
+	if repo == "" {
+		return "", "", fmt.Errorf("failed to extract owner/repo from URL: %s", url)
+	}
+
+	return host, repo, nil
+}
+
+// ListRemotes returns the remotes in .git/config that point at a GitHub
+// repository, in the order they are defined. Remotes on other hosts, such as
+// GitLab, are left out; see IsGitHubHost for enterpriseHosts.
+func ListRemotes(enterpriseHosts ...string) ([]Remote, error) {
+	gitConfigPath, err := findGitConfig()
+	if err != nil {
+		return nil, err
+	}
+	return parseGitHubRemotes(gitConfigPath, enterpriseHosts)
+}
+
+// IsGitHubHost reports whether host serves GitHub: github.com, a GHE.com
+// subdomain, or one of enterpriseHosts, the GitHub Enterprise Server hosts
+// in use
+func IsGitHubHost(host string, enterpriseHosts ...string) bool {
+	host = strings.ToLower(host)
+	if host == DefaultHost || strings.HasSuffix(host, ".ghe.com") {
+		return true
+	}
+	for _, h := range enterpriseHosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
 }
 
 // findGitConfig locates the .git/config file by searching upward from current directory
@@ -82,51 +157,91 @@ func findGitConfig() (string, error) {
 
 // parseGitConfig reads the git config file and extracts the GitHub repository
 func parseGitConfig(configPath string) (string, error) {
-	content, err := os.ReadFile(configPath)
+	url, err := parseRemoteURL(configPath, DefaultRemote)
 	if err != nil {
-		return "", fmt.Errorf("failed to read git config: %w", err)
+		return "", err
 	}
 
-	// Look for origin remote URL
-	lines := strings.Split(string(content), "\n")
-	var inOriginSection bool
-	var url string
+	// Parse various GitHub URL formats
+	repo := extractRepoFromURL(url)
+	if repo == "" {
+		return "", fmt.Errorf("failed to extract owner/repo from URL: %s", url)
+	}
 
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
+	return repo, nil
+}
 
-		// Check for [remote "origin"] section
-		if strings.HasPrefix(trimmed, "[remote") && strings.Contains(trimmed, "origin") {
-			inOriginSection = true
+// parseGitHubRemotes reads the git config file and returns every remote whose
+// URL is a GitHub repository
+func parseGitHubRemotes(configPath string, enterpriseHosts []string) ([]Remote, error) {
+	names, urls, err := parseRemoteURLs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var remotes []Remote
+	for _, name := range names {
+		host, repo := extractHostAndRepoFromURL(urls[name])
+		if repo == "" || !IsGitHubHost(host, enterpriseHosts...) {
 			continue
 		}
+		remotes = append(remotes, Remote{Name: name, Host: host, Repository: repo})
+	}
+	return remotes, nil
+}
+
+// parseRemoteURL reads the git config file and returns the URL of the named remote
+func parseRemoteURL(configPath, name string) (string, error) {
+	_, urls, err := parseRemoteURLs(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	url := urls[name]
+	if url == "" {
+		return "", fmt.Errorf("no %s remote found in git config", name)
+	}
+
+	return url, nil
+}
+
+// parseRemoteURLs reads the git config file and returns the remote names in
+// definition order along with the first URL of each
+func parseRemoteURLs(configPath string) ([]string, map[string]string, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	var names []string
+	urls := make(map[string]string)
+	var current string
+
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
 
-		// Check for other sections (end of origin section)
-		if inOriginSection && strings.HasPrefix(trimmed, "[") && !strings.Contains(trimmed, "origin") {
-			inOriginSection = false
+		// Section headers end the previous remote; [remote "name"] starts a new one
+		if strings.HasPrefix(trimmed, "[") {
+			current = ""
+			if rest, ok := strings.CutPrefix(trimmed, "[remote "); ok {
+				current = strings.Trim(strings.TrimSuffix(rest, "]"), `"`)
+			}
+			continue
 		}
 
-		// Extract URL from origin section
-		if inOriginSection && strings.HasPrefix(trimmed, "url") {
+		// Extract URL from the current remote section
+		if current != "" && strings.HasPrefix(trimmed, "url") {
 			parts := strings.SplitN(trimmed, "=", 2)
 			if len(parts) == 2 {
-				url = strings.TrimSpace(parts[1])
-				break
+				if _, seen := urls[current]; !seen {
+					names = append(names, current)
+					urls[current] = strings.TrimSpace(parts[1])
+				}
 			}
 		}
 	}
 
-	if url == "" {
-		return "", fmt.Errorf("no origin remote found in git config")
-	}
-
-	// Parse various GitHub URL formats
-	repo := extractRepoFromURL(url)
-	if repo == "" {
-		return "", fmt.Errorf("failed to extract owner/repo from URL: %s", url)
-	}
-
-	return repo, nil
+	return names, urls, nil
 }
 
 // extractRepoFromURL converts various GitHub URL formats to owner/repo format
@@ -135,33 +250,56 @@ func parseGitConfig(configPath string) (string, error) {
 //   - https://github.com/owner/repo.git
 //   - git@github.com:owner/repo
 //   - git@github.com:owner/repo.git
+//   - the same forms on a GitHub Enterprise Server host
 func extractRepoFromURL(url string) string {
-	// Handle HTTPS URLs
-	if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") {
-		// Remove protocol and domain: https://github.com/owner/repo.git -> owner/repo.git
-		if idx := strings.Index(url, "github.com/"); idx != -1 {
-			repo := url[idx+len("github.com/"):]
-			// Remove .git suffix if present
-			repo = strings.TrimSuffix(repo, ".git")
-			// Remove trailing slash
-			repo = strings.TrimSuffix(repo, "/")
-			if isValidRepoFormat(repo) {
-				return repo
-			}
+	_, repo := extractHostAndRepoFromURL(url)
+	return repo
+}
+
+// ParseRepositoryURL splits a repository URL such as
+// https://github.com/owner/repo.git or git@github.com:owner/repo into its
+// host and owner/repo. Both are empty when url is not a repository URL.
+func ParseRepositoryURL(url string) (host, repo string) {
+	return extractHostAndRepoFromURL(url)
+}
+
+// extractHostAndRepoFromURL splits a remote URL into its host and owner/repo.
+// Handles HTTPS/HTTP, ssh:// and scp-like (git@host:owner/repo) URLs.
+// Returns empty strings if the URL is not a recognizable repository URL.
+func extractHostAndRepoFromURL(url string) (string, string) {
+	var host, path string
+
+	switch {
+	case strings.HasPrefix(url, "https://"), strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "ssh://"):
+		// https://github.com/owner/repo.git -> github.com, owner/repo.git
+		rest := url[strings.Index(url, "://")+3:]
+		var ok bool
+		host, path, ok = strings.Cut(rest, "/")
+		if !ok {
+			return "", ""
 		}
+		// Drop credentials (git@host) and ports (host:22)
+		if idx := strings.LastIndex(host, "@"); idx != -1 {
+			host = host[idx+1:]
+		}
+		host, _, _ = strings.Cut(host, ":")
+	case strings.Contains(url, "@") && strings.Contains(url, ":"):
+		// git@github.com:owner/repo.git -> github.com, owner/repo.git
+		userHost, rest, _ := strings.Cut(url, ":")
+		host = userHost[strings.LastIndex(userHost, "@")+1:]
+		path = rest
+	default:
+		return "", ""
 	}
 
-	// Handle SSH URLs: git@github.com:owner/repo.git -> owner/repo
-	if after, ok := strings.CutPrefix(url, "git@github.com:"); ok {
-		repo := after
-		// Remove .git suffix if present
-		repo = strings.TrimSuffix(repo, ".git")
-		if isValidRepoFormat(repo) {
-			return repo
-		}
+	// Remove .git suffix and trailing slash if present
+	repo := strings.TrimSuffix(path, "/")
+	repo = strings.TrimSuffix(repo, ".git")
+	if host == "" || !isValidRepoFormat(repo) {
+		return "", ""
 	}
 
-	return ""
+	return host, repo
 }
 
 // isValidRepoFormat checks if string is in owner/repo format
@@ -75,6 +75,61 @@ func TestExtractRepoFromURL(t *testing.T) {
 	}
 }
 
+func TestExtractHostAndRepoFromURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantHost string
+		wantRepo string
+	}{
+		{
+			name:     "github.com HTTPS",
+			url:      "https://github.com/octocat/Hello-World.git",
+			wantHost: "github.com",
+			wantRepo: "octocat/Hello-World",
+		},
+		{
+			name:     "Enterprise HTTPS",
+			url:      "https://ghe.example.com/octocat/Hello-World.git",
+			wantHost: "ghe.example.com",
+			wantRepo: "octocat/Hello-World",
+		},
+		{
+			name:     "Enterprise scp-like SSH",
+			url:      "git@ghe.example.com:octocat/Hello-World.git",
+			wantHost: "ghe.example.com",
+			wantRepo: "octocat/Hello-World",
+		},
+		{
+			name:     "Enterprise ssh:// with port",
+			url:      "ssh://git@ghe.example.com:2222/octocat/Hello-World.git",
+			wantHost: "ghe.example.com",
+			wantRepo: "octocat/Hello-World",
+		},
+		{
+			name:     "HTTPS with credentials",
+			url:      "https://token@ghe.example.com/octocat/Hello-World",
+			wantHost: "ghe.example.com",
+			wantRepo: "octocat/Hello-World",
+		},
+		{
+			name:     "Local path",
+			url:      "/srv/git/repo.git",
+			wantHost: "",
+			wantRepo: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, repo := extractHostAndRepoFromURL(tt.url)
+			if host != tt.wantHost || repo != tt.wantRepo {
+				t.Errorf("extractHostAndRepoFromURL(%q) = (%q, %q), want (%q, %q)", tt.url, host, repo, tt.wantHost, tt.wantRepo)
+			}
+		})
+	}
+}
+
 func TestIsValidRepoFormat(t *testing.T) {
 	tests := []struct {
 		name string
@@ -254,3 +309,123 @@ func TestFindGitConfig(t *testing.T) {
 		t.Errorf("findGitConfig() = %q, want %q", foundAbs, expectedAbs)
 	}
 }
+
+const multiRemoteConfig = `[core]
+	repositoryformatversion = 0
+[remote "origin"]
+	url = git@github.com:me/Hello-World.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[branch "main"]
+	remote = origin
+[remote "upstream"]
+	url = https://github.com/octocat/Hello-World.git
+[remote "backup"]
+	url = /srv/git/Hello-World.git
+[remote "enterprise"]
+	url = git@ghe.example.com:octocat/Hello-World.git
+[remote "gitlab"]
+	url = git@gitlab.com:octocat/Hello-World.git`
+
+func TestParseGitHubRemotes(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(configPath, []byte(multiRemoteConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	remotes, err := parseGitHubRemotes(configPath, []string{"GHE.example.com"})
+	if err != nil {
+		t.Fatalf("parseGitHubRemotes() error = %v", err)
+	}
+
+	want := []Remote{
+		{Name: "origin", Host: "github.com", Repository: "me/Hello-World"},
+		{Name: "upstream", Host: "github.com", Repository: "octocat/Hello-World"},
+		{Name: "enterprise", Host: "ghe.example.com", Repository: "octocat/Hello-World"},
+	}
+	if len(remotes) != len(want) {
+		t.Fatalf("parseGitHubRemotes() = %+v, want %+v", remotes, want)
+	}
+	for i := range want {
+		if remotes[i] != want[i] {
+			t.Errorf("remote %d = %+v, want %+v", i, remotes[i], want[i])
+		}
+	}
+
+	if got := remotes[2].FullName(); got != "ghe.example.com/octocat/Hello-World" {
+		t.Errorf("FullName() = %q, want host-qualified repository", got)
+	}
+
+	// Without the enterprise host, only the github.com remotes are GitHub's
+	remotes, err = parseGitHubRemotes(configPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remotes) != 2 || remotes[1].Name != "upstream" {
+		t.Errorf("parseGitHubRemotes() without enterprise hosts = %+v, want origin and upstream", remotes)
+	}
+}
+
+func TestIsGitHubHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"github.com", true},
+		{"GitHub.com", true},
+		{"octocorp.ghe.com", true},
+		{"ghe.example.com", true},
+		{"gitlab.com", false},
+		{"bitbucket.org", false},
+	}
+	for _, tt := range tests {
+		if got := IsGitHubHost(tt.host, "ghe.example.com"); got != tt.want {
+			t.Errorf("IsGitHubHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestDetectRepositoryWithRemote(t *testing.T) {
+	tmpdir := t.TempDir()
+	gitDir := filepath.Join(tmpdir, ".git")
+	if err := os.MkdirAll(gitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "config"), []byte(multiRemoteConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldCwd)
+	defer SetRemote("")
+
+	if err := os.Chdir(tmpdir); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		remote  string
+		want    string
+		wantErr bool
+	}{
+		{remote: "", want: "me/Hello-World"},
+		{remote: "upstream", want: "octocat/Hello-World"},
+		{remote: "enterprise", want: "ghe.example.com/octocat/Hello-World"},
+		{remote: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			SetRemote(tt.remote)
+			got, err := DetectRepository()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectRepository() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
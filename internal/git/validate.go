@@ -3,15 +3,35 @@ package git
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
+// DefaultHost is the hostname of github.com
+const DefaultHost = "github.com"
+
 // repository format should be owner/repo
 var RepositoryFormatRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+$`)
 
+// hostRegex matches a GitHub Enterprise Server hostname such as ghe.example.com
+var hostRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+(:[0-9]+)?$`)
+
+// ValidateRepositoryFormat checks that repo is in owner/repo or
+// host/owner/repo format, as accepted by gh's --repo flag
 func ValidateRepositoryFormat(repo string) error {
-	if !RepositoryFormatRegex.MatchString(repo) {
-		return fmt.Errorf("invalid repository format: %q - expected format: owner/repo", repo)
+	if _, ownerRepo := SplitRepository(repo); !RepositoryFormatRegex.MatchString(ownerRepo) {
+		return fmt.Errorf("invalid repository format: %q - expected format: owner/repo or host/owner/repo", repo)
 	}
 
 	return nil
 }
+
+// SplitRepository splits an optional host prefix from a repository string.
+// "ghe.example.com/owner/repo" returns ("ghe.example.com", "owner/repo");
+// "owner/repo" returns ("", "owner/repo").
+func SplitRepository(repo string) (host, ownerRepo string) {
+	parts := strings.Split(repo, "/")
+	if len(parts) == 3 && hostRegex.MatchString(parts[0]) {
+		return parts[0], parts[1] + "/" + parts[2]
+	}
+	return "", repo
+}
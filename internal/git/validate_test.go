@@ -70,6 +70,16 @@ func TestValidateRepositoryFormat(t *testing.T) {
 			repo:    "",
 			wantErr: true,
 		},
+		{
+			name:    "Valid format - enterprise host",
+			repo:    "ghe.example.com/owner/repo",
+			wantErr: false,
+		},
+		{
+			name:    "Invalid format - enterprise host with extra path",
+			repo:    "ghe.example.com/owner/repo/name",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -81,3 +91,25 @@ func TestValidateRepositoryFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitRepository(t *testing.T) {
+	tests := []struct {
+		repo     string
+		wantHost string
+		wantRepo string
+	}{
+		{repo: "owner/repo", wantHost: "", wantRepo: "owner/repo"},
+		{repo: "ghe.example.com/owner/repo", wantHost: "ghe.example.com", wantRepo: "owner/repo"},
+		{repo: "ghe.example.com:8443/owner/repo", wantHost: "ghe.example.com:8443", wantRepo: "owner/repo"},
+		{repo: "owner/repo/name", wantHost: "", wantRepo: "owner/repo/name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			host, repo := SplitRepository(tt.repo)
+			if host != tt.wantHost || repo != tt.wantRepo {
+				t.Errorf("SplitRepository(%q) = (%q, %q), want (%q, %q)", tt.repo, host, repo, tt.wantHost, tt.wantRepo)
+			}
+		})
+	}
+}
@@ -1,75 +1,302 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/Cloudsky01/gh-rivet/internal/git"
 	"github.com/Cloudsky01/gh-rivet/pkg/models"
 )
 
 const DefaultTimeout = 30 * time.Second
 
+// ErrNoWorkflowDispatch is returned when a workflow cannot be triggered manually
+var ErrNoWorkflowDispatch = errors.New("workflow does not have a workflow_dispatch trigger")
+
+// ErrNoRuns is returned when there is no run to return
+var ErrNoRuns = errors.New("no workflow runs found")
+
+// CommandFunc builds the process for a gh invocation. It has the signature
+// of exec.CommandContext, which is the default.
+type CommandFunc func(ctx context.Context, name string, args ...string) *exec.Cmd
+
+// ghPath is the gh executable clients run, set with SetGHPath
+var ghPath = "gh"
+
+// SetGHPath sets the gh executable that clients created afterwards run: a
+// name looked up on PATH, or the path of a binary or wrapper script. An empty
+// path restores the default, gh from PATH.
+func SetGHPath(path string) {
+	if path == "" {
+		path = "gh"
+	}
+	ghPath = path
+}
+
+// GHPath returns the gh executable set with SetGHPath
+func GHPath() string {
+	return ghPath
+}
+
+// ValidateGHPath returns an error when path cannot be run: a name that is not
+// on PATH, or a file that is missing or not executable
+func ValidateGHPath(path string) error {
+	_, err := exec.LookPath(path)
+	return err
+}
+
 type Client struct {
-	repo    string
-	timeout time.Duration
+	repo       string
+	host       string
+	ghPath     string
+	timeout    time.Duration
+	newCommand CommandFunc
 }
 
 func NewClient(repo string) *Client {
 	return NewClientWithTimeout(repo, DefaultTimeout)
 }
 
+// NormalizeRepo cleans up a repository given by the user: surrounding
+// whitespace is trimmed, repository URLs and a trailing .git are accepted,
+// and the host is lowercased, or dropped when it is github.com. The result is
+// in owner/repo or host/owner/repo format.
+func NormalizeRepo(s string) (string, error) {
+	repo := strings.TrimSpace(s)
+	host, ownerRepo := git.ParseRepositoryURL(repo)
+	if ownerRepo == "" {
+		repo = strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+		host, ownerRepo = git.SplitRepository(repo)
+	}
+
+	if !git.RepositoryFormatRegex.MatchString(ownerRepo) {
+		return "", fmt.Errorf("invalid repository format: %q - expected format: owner/repo or host/owner/repo", s)
+	}
+	host = strings.ToLower(host)
+	if host == "" || host == git.DefaultHost {
+		return ownerRepo, nil
+	}
+	return host + "/" + ownerRepo, nil
+}
+
+// AliasCommand returns a `gh alias set` line that defines name as a shortcut
+// launching rivet on repo, straight into workflow's runs when it is set. An
+// empty name is derived from the repository and workflow.
+func AliasCommand(name, repo, workflow string) string {
+	expansion := "rivet --repo " + repo
+	if workflow != "" {
+		expansion += " --workflow " + workflow
+	}
+	if name == "" {
+		name = aliasName(repo, workflow)
+	}
+	return "gh alias set " + shellQuote(name) + " " + shellQuote(expansion)
+}
+
+// aliasName names an alias after the repository and the workflow file
+// without its extension, such as rivet-cli-build
+func aliasName(repo, workflow string) string {
+	name := "rivet-" + path.Base(repo)
+	if workflow != "" {
+		name += "-" + strings.TrimSuffix(path.Base(workflow), path.Ext(workflow))
+	}
+	return name
+}
+
+// shellQuote quotes s as a single POSIX shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 func NewClientWithTimeout(repo string, timeout time.Duration) *Client {
 	if timeout <= 0 {
 		timeout = DefaultTimeout
 	}
 	return &Client{
-		repo:    repo,
-		timeout: timeout,
+		repo:       repo,
+		ghPath:     ghPath,
+		timeout:    timeout,
+		newCommand: exec.CommandContext,
 	}
 }
 
-func (c *Client) GetLatestRun() (*models.GHRun, error) {
+// SetCommandFunc replaces how gh processes are created, so tests can run a
+// stub instead of the real CLI
+func (c *Client) SetCommandFunc(fn CommandFunc) {
+	c.newCommand = fn
+}
+
+// SetHost sets the GitHub hostname gh commands are sent to, for example a
+// GitHub Enterprise Server host. An empty host uses gh's default.
+func (c *Client) SetHost(host string) {
+	c.host = host
+}
+
+// Host returns the hostname set with SetHost
+func (c *Client) Host() string {
+	return c.host
+}
+
+// command builds a gh command that targets the client's host via GH_HOST
+func (c *Client) command(ctx context.Context, host string, args ...string) *exec.Cmd {
+	cmd := c.newCommand(ctx, c.ghPath, args...)
+	if host == "" {
+		host = c.host
+	}
+	slog.Debug("running gh", "args", args, "host", host)
+	if host != "" {
+		cmd.Env = append(cmd.Environ(), "GH_HOST="+host)
+	}
+	return cmd
+}
+
+// GetLatestRepoRun returns the repository's newest run of any workflow, or
+// ErrNoRuns when there is none
+func (c *Client) GetLatestRepoRun() (*models.GHRun, error) {
 	runs, err := c.GetRecentRuns(1)
 	if err != nil {
 		return nil, err
 	}
 	if len(runs) == 0 {
-		return nil, fmt.Errorf("no workflow runs found")
+		return nil, ErrNoRuns
 	}
 	return &runs[0], nil
 }
 
+// GetLatestRun returns the newest run of one workflow file, or ErrNoRuns
+// when it has never run
+func (c *Client) GetLatestRun(workflowName string) (*models.GHRun, error) {
+	runs, err := c.GetWorkflowRuns(workflowName, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, ErrNoRuns
+	}
+	return &runs[0], nil
+}
+
+// DefaultRunLimit is how many runs ListRuns returns when no limit is given
+const DefaultRunLimit = 20
+
+// runFields are the run fields every listing fetches
+var runFields = []string{"databaseId", "displayTitle", "workflowName", "status", "conclusion", "createdAt", "headBranch", "attempt"}
+
+// RunOrder is the timestamp runs are sorted by, newest first
+type RunOrder int
+
+const (
+	OrderByCreated RunOrder = iota
+	OrderByUpdated
+)
+
+// RunListOptions selects the runs ListRuns returns and their order
+type RunListOptions struct {
+	// Workflow limits the runs to one workflow file; empty lists every run
+	Workflow string
+	// Limit is the most runs to return, DefaultRunLimit when zero
+	Limit int
+	// Fields are JSON fields to fetch on top of the defaults, such as "event"
+	Fields  []string
+	OrderBy RunOrder
+}
+
+// args returns the gh run list arguments for the options
+func (o RunListOptions) args() []string {
+	limit := o.Limit
+	if limit <= 0 {
+		limit = DefaultRunLimit
+	}
+
+	fields := slices.Clone(runFields)
+	extra := o.Fields
+	if o.OrderBy == OrderByUpdated {
+		extra = append(slices.Clip(extra), "updatedAt")
+	}
+	for _, field := range extra {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	args := []string{"run", "list", "--limit", strconv.Itoa(limit), "--json", strings.Join(fields, ",")}
+	if o.Workflow != "" {
+		args = append(args, "--workflow", o.Workflow)
+	}
+	return args
+}
+
+// sortRuns orders runs newest first by the chosen timestamp, breaking ties
+// by ID
+func sortRuns(runs []models.GHRun, order RunOrder) {
+	at := func(run *models.GHRun) time.Time {
+		if order == OrderByUpdated {
+			return run.UpdatedAt
+		}
+		return run.CreatedAt
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		if at(&runs[i]).Equal(at(&runs[j])) {
+			return runs[i].DatabaseID > runs[j].DatabaseID
+		}
+		return at(&runs[i]).After(at(&runs[j]))
+	})
+}
+
 func (c *Client) GetRecentRuns(limit int) ([]models.GHRun, error) {
-	return c.GetWorkflowRuns("", limit)
+	return c.ListRuns(RunListOptions{Limit: limit})
 }
 
 func (c *Client) GetWorkflowRuns(workflowName string, limit int) ([]models.GHRun, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel()
+	return c.ListRuns(RunListOptions{Workflow: workflowName, Limit: limit})
+}
 
-	args := []string{"run", "list", "--limit", fmt.Sprintf("%d", limit), "--json", "databaseId,displayTitle,workflowName,status,conclusion,createdAt,headBranch"}
+// GetWorkflowRunsContext is GetWorkflowRuns, aborted when ctx is cancelled
+func (c *Client) GetWorkflowRunsContext(ctx context.Context, workflowName string, limit int) ([]models.GHRun, error) {
+	return c.ListRunsContext(ctx, RunListOptions{Workflow: workflowName, Limit: limit})
+}
 
-	if workflowName != "" {
-		args = append(args, "--workflow", workflowName)
-	}
+// ListRuns lists the repository's runs as selected by opts
+func (c *Client) ListRuns(opts RunListOptions) ([]models.GHRun, error) {
+	return c.ListRunsContext(context.Background(), opts)
+}
 
+// ListRunsContext is ListRuns, aborted when ctx is cancelled. The error of
+// an aborted call wraps context.Canceled.
+func (c *Client) ListRunsContext(ctx context.Context, opts RunListOptions) ([]models.GHRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	args := opts.args()
 	if c.repo != "" {
 		args = append(args, "--repo", c.repo)
 	}
 
-	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd := c.command(ctx, "", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("gh run list timed out after %v", c.timeout)
 		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, fmt.Errorf("gh run list cancelled: %w", ctx.Err())
+		}
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
 			return nil, fmt.Errorf("gh run list failed: %s", string(exitErr.Stderr))
@@ -81,13 +308,10 @@ func (c *Client) GetWorkflowRuns(workflowName string, limit int) ([]models.GHRun
 	if err := json.Unmarshal(output, &runs); err != nil {
 		return nil, fmt.Errorf("failed to parse workflow runs: %w", err)
 	}
-
-	sort.Slice(runs, func(i, j int) bool {
-		if runs[i].CreatedAt.Equal(runs[j].CreatedAt) {
-			return runs[i].DatabaseID > runs[j].DatabaseID
-		}
-		return runs[i].CreatedAt.After(runs[j].CreatedAt)
-	})
+	for i := range runs {
+		runs[i].Normalize()
+	}
+	sortRuns(runs, opts.OrderBy)
 
 	return runs, nil
 }
@@ -96,13 +320,13 @@ func (c *Client) GetRunByID(runID int) (*models.GHRun, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
-	args := []string{"run", "view", fmt.Sprintf("%d", runID), "--json", "databaseId,displayTitle,workflowName,status,conclusion,createdAt,headBranch"}
+	args := []string{"run", "view", fmt.Sprintf("%d", runID), "--json", strings.Join(runFields, ",")}
 
 	if c.repo != "" {
 		args = append(args, "--repo", c.repo)
 	}
 
-	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd := c.command(ctx, "", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
@@ -119,6 +343,7 @@ func (c *Client) GetRunByID(runID int) (*models.GHRun, error) {
 	if err := json.Unmarshal(output, &run); err != nil {
 		return nil, fmt.Errorf("failed to parse workflow run: %w", err)
 	}
+	run.Normalize()
 
 	return &run, nil
 }
@@ -133,7 +358,7 @@ func (c *Client) GetRunJobs(runID int) ([]models.GHJob, error) {
 		args = append(args, "--repo", c.repo)
 	}
 
-	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd := c.command(ctx, "", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
@@ -150,10 +375,121 @@ func (c *Client) GetRunJobs(runID int) ([]models.GHJob, error) {
 	if err := json.Unmarshal(output, &detail); err != nil {
 		return nil, fmt.Errorf("failed to parse job details: %w", err)
 	}
+	for i := range detail.Jobs {
+		detail.Jobs[i].Normalize()
+	}
 
 	return detail.Jobs, nil
 }
 
+// GetRunAnnotations fetches the check annotations of every job in a run,
+// which takes one checks API call per job
+func (c *Client) GetRunAnnotations(runID int) ([]models.GHAnnotation, error) {
+	jobs, err := c.GetRunJobs(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []models.GHAnnotation
+	for _, job := range jobs {
+		jobAnnotations, err := c.getJobAnnotations(job.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range jobAnnotations {
+			jobAnnotations[i].JobName = job.Name
+		}
+		annotations = append(annotations, jobAnnotations...)
+	}
+	return annotations, nil
+}
+
+// getJobAnnotations fetches the annotations of one job. A job's ID is also
+// the ID of its check run.
+func (c *Client) getJobAnnotations(jobID int) ([]models.GHAnnotation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	host, repo := c.apiRepo()
+	args := []string{"api", "--paginate", fmt.Sprintf("repos/%s/check-runs/%d/annotations", repo, jobID), "--jq", ".[]"}
+
+	cmd := c.command(ctx, host, args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("gh api timed out after %v", c.timeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to fetch annotations: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh api failed: %w", err)
+	}
+
+	return parseAnnotations(output)
+}
+
+// parseAnnotations decodes the one JSON object per line that --jq '.[]'
+// prints across pages
+func parseAnnotations(output []byte) ([]models.GHAnnotation, error) {
+	var annotations []models.GHAnnotation
+	dec := json.NewDecoder(bytes.NewReader(output))
+	for dec.More() {
+		var annotation models.GHAnnotation
+		if err := dec.Decode(&annotation); err != nil {
+			return nil, fmt.Errorf("failed to parse annotations: %w", err)
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations, nil
+}
+
+// WriteRunLog streams a run's log to w. A non-zero jobID limits the log to
+// that job and failedOnly limits it to failed steps. Logs can be large, so
+// only ctx bounds the call, not the client timeout.
+func (c *Client) WriteRunLog(ctx context.Context, runID, jobID int, failedOnly bool, w io.Writer) error {
+	args := []string{"run", "view", fmt.Sprintf("%d", runID)}
+	if failedOnly {
+		args = append(args, "--log-failed")
+	} else {
+		args = append(args, "--log")
+	}
+	if jobID > 0 {
+		args = append(args, "--job", fmt.Sprintf("%d", jobID))
+	}
+
+	if c.repo != "" {
+		args = append(args, "--repo", c.repo)
+	}
+
+	var stderr bytes.Buffer
+	cmd := c.command(ctx, "", args...)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("gh run view failed: %s", msg)
+		}
+		return fmt.Errorf("gh run view failed: %w", err)
+	}
+	return nil
+}
+
+// GetRunLog returns the full log of a run
+func (c *Client) GetRunLog(runID int) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	if err := c.WriteRunLog(ctx, runID, 0, false, &buf); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("gh run view timed out after %v", c.timeout)
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (c *Client) GetJobsFromRuns(runs []models.GHRun) ([]models.GHJob, error) {
 	var allJobs []models.GHJob
 
@@ -176,57 +512,266 @@ func (c *Client) GetJobsFromRuns(runs []models.GHRun) ([]models.GHJob, error) {
 	return allJobs, nil
 }
 
+// OpenWorkflowInBrowser launches the browser on the workflow's page. It
+// blocks until gh returns, so the TUI calls it from a tea.Cmd.
 func (c *Client) OpenWorkflowInBrowser(workflowName string) error {
+	return c.openInBrowser("workflow", "view", workflowName)
+}
+
+// OpenRunInBrowser launches the browser on the run's page. It blocks until
+// gh returns, so the TUI calls it from a tea.Cmd.
+func (c *Client) OpenRunInBrowser(runID int) error {
+	return c.openInBrowser("run", "view", fmt.Sprintf("%d", runID))
+}
+
+// OpenJobInBrowser launches the browser on a job's page within a run, where
+// its steps and log are shown. Without a job ID the run page is opened.
+func (c *Client) OpenJobInBrowser(runID, jobID int) error {
+	if jobID <= 0 {
+		return c.OpenRunInBrowser(runID)
+	}
+	return c.openInBrowser("run", "view", fmt.Sprintf("%d", runID), "--job", fmt.Sprintf("%d", jobID))
+}
+
+// OpenActionsInBrowser launches the browser on the repository's Actions
+// tab, which lists the runs of every workflow. GitHub has no page for a
+// chosen set of workflows, so this is as close as a group gets. It needs gh
+// 2.62 or later for gh browse --actions.
+func (c *Client) OpenActionsInBrowser() error {
+	return c.browse("Actions", "browse", "--actions")
+}
+
+func (c *Client) openInBrowser(args ...string) error {
+	return c.browse(args[0], append(args, "-w")...)
+}
+
+// browse runs a gh command that opens what in the browser, on the client's
+// repository
+func (c *Client) browse(what string, args ...string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
-	args := []string{"workflow", "view", workflowName, "-w"}
-
 	if c.repo != "" {
 		args = append(args, "--repo", c.repo)
 	}
 
-	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd := c.command(ctx, "", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("gh workflow view timed out after %v", c.timeout)
+			return fmt.Errorf("gh %s %s timed out after %v", args[0], args[1], c.timeout)
 		}
-		return fmt.Errorf("failed to open workflow in browser: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("failed to open %s in browser: %w\nOutput: %s", what, err, string(output))
 	}
 	return nil
 }
 
-func (c *Client) OpenRunInBrowser(runID int) error {
+// GetWorkflowInputs fetches a workflow's definition and returns its
+// workflow_dispatch inputs in the order they are declared. It returns
+// ErrNoWorkflowDispatch if the workflow cannot be dispatched.
+func (c *Client) GetWorkflowInputs(workflowName string) ([]models.WorkflowInput, error) {
+	output, err := c.workflowYAML(workflowName)
+	if err != nil {
+		return nil, err
+	}
+	return parseWorkflowInputs(output)
+}
+
+// GetWorkflowInfo returns a workflow's path and the events that trigger it,
+// read from the workflow file on the default branch
+func (c *Client) GetWorkflowInfo(file string) (*models.WorkflowInfo, error) {
+	output, err := c.workflowYAML(file)
+	if err != nil {
+		return nil, err
+	}
+	info, err := parseWorkflowInfo(output)
+	if err != nil {
+		return nil, err
+	}
+	info.File = file
+	info.Path = file
+	if !strings.Contains(file, "/") {
+		info.Path = ".github/workflows/" + file
+	}
+	return info, nil
+}
+
+// workflowYAML returns the contents of a workflow file
+// GetWorkflowSource returns the contents of a workflow file on the default
+// branch
+func (c *Client) GetWorkflowSource(file string) (string, error) {
+	output, err := c.workflowYAML(file)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+func (c *Client) workflowYAML(workflowName string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
-	args := []string{"run", "view", fmt.Sprintf("%d", runID), "-w"}
+	args := []string{"workflow", "view", workflowName, "--yaml"}
 
 	if c.repo != "" {
 		args = append(args, "--repo", c.repo)
 	}
 
-	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd := c.command(ctx, "", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("gh workflow view timed out after %v", c.timeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("gh workflow view failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh workflow view failed: %w", err)
+	}
+	return output, nil
+}
+
+// DispatchWorkflow triggers a workflow_dispatch run of a workflow with the
+// given inputs on ref, or on the repository's default branch when ref is ""
+func (c *Client) DispatchWorkflow(workflowName, ref string, inputs map[string]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	args := []string{"workflow", "run", workflowName}
+	if ref != "" {
+		args = append(args, "--ref", ref)
+	}
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "-f", name+"="+inputs[name])
+	}
+
+	if c.repo != "" {
+		args = append(args, "--repo", c.repo)
+	}
+
+	cmd := c.command(ctx, "", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("gh workflow run timed out after %v", c.timeout)
+		}
+		return fmt.Errorf("gh workflow run failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RerunFailedJobs re-runs the failed jobs of a finished run. GitHub runs
+// them as a new attempt of the same run.
+func (c *Client) RerunFailedJobs(runID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	args := []string{"run", "rerun", fmt.Sprintf("%d", runID), "--failed"}
+	if c.repo != "" {
+		args = append(args, "--repo", c.repo)
+	}
+
+	cmd := c.command(ctx, "", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("gh run view timed out after %v", c.timeout)
+			return fmt.Errorf("gh run rerun timed out after %v", c.timeout)
 		}
-		return fmt.Errorf("failed to open run in browser: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("gh run rerun failed: %s", strings.TrimSpace(string(output)))
 	}
 	return nil
 }
 
+// apiRepo returns the host and owner/repo path of the client's repository
+// for gh api. Without a repository, gh fills in {owner}/{repo} from the
+// current directory.
+func (c *Client) apiRepo() (string, string) {
+	host, repo := git.SplitRepository(c.repo)
+	if repo == "" {
+		repo = "{owner}/{repo}"
+	}
+	return host, repo
+}
+
+// api runs gh api against the client's repository. path is relative to
+// repos/OWNER/REPO.
+func (c *Client) api(path string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	host, repo := c.apiRepo()
+	args = append([]string{"api", "repos/" + repo + path}, args...)
+
+	cmd := c.command(ctx, host, args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("gh api timed out after %v", c.timeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("gh api failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh api failed: %w", err)
+	}
+	return output, nil
+}
+
+// GetDefaultBranch returns the repository's default branch
+func (c *Client) GetDefaultBranch() (string, error) {
+	output, err := c.api("", "--jq", ".default_branch")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ListBranches returns the names of all of the repository's branches,
+// following pagination
+func (c *Client) ListBranches() ([]string, error) {
+	output, err := c.api("/branches?per_page=100", "--paginate", "--jq", ".[].name")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// RefExists reports whether ref names a branch, tag, or commit of the
+// repository
+func (c *Client) RefExists(ref string) (bool, error) {
+	// Branch names may contain slashes, which the endpoint accepts as is
+	segments := strings.Split(ref, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	_, err := c.api("/commits/"+strings.Join(segments, "/"), "--jq", ".sha")
+	if err != nil {
+		// Unknown refs are a 404; malformed ones such as a bad SHA a 422
+		if msg := err.Error(); strings.Contains(msg, "HTTP 404") || strings.Contains(msg, "HTTP 422") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // RepositoryExists checks if a repository exists on GitHub
 func (c *Client) RepositoryExists(ctx context.Context, repo string) (bool, error) {
 	cmdCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	// Use gh api to check if repository exists
+	host, repo := git.SplitRepository(repo)
 	args := []string{"api", fmt.Sprintf("repos/%s", repo)}
 
-	cmd := exec.CommandContext(cmdCtx, "gh", args...)
+	cmd := c.command(cmdCtx, host, args...)
 	output, err := cmd.Output()
 
 	if err != nil {
@@ -259,8 +804,9 @@ func (c *Client) GetWorkflows(ctx context.Context, repo string) ([]string, error
 	cmdCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
+	host, repo := git.SplitRepository(repo)
 	args := []string{"api", "--paginate", fmt.Sprintf("repos/%s/actions/workflows", repo), "--jq", ".workflows[].path"}
-	cmd := exec.CommandContext(cmdCtx, "gh", args...)
+	cmd := c.command(cmdCtx, host, args...)
 	output, err := cmd.Output()
 
 	if err != nil {
@@ -278,6 +824,33 @@ func (c *Client) GetWorkflows(ctx context.Context, repo string) ([]string, error
 	return parseWorkflowPaths(string(output)), nil
 }
 
+// GetWorkflowNames fetches the display name of each workflow in a repository,
+// keyed by file. GitHub names workflows without a name: field after their
+// path, and those are left out.
+func (c *Client) GetWorkflowNames(ctx context.Context, repo string) (map[string]string, error) {
+	cmdCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	host, repo := git.SplitRepository(repo)
+	args := []string{"api", "--paginate", fmt.Sprintf("repos/%s/actions/workflows", repo), "--jq", ".workflows[] | [.path, .name] | @tsv"}
+	cmd := c.command(cmdCtx, host, args...)
+	output, err := cmd.Output()
+
+	if err != nil {
+		if cmdCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("gh api timed out after %v", c.timeout)
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to fetch workflow names: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh api failed: %w", err)
+	}
+
+	return parseWorkflowNames(string(output)), nil
+}
+
 func parseWorkflowPaths(output string) []string {
 	var workflows []string
 	const prefix = ".github/workflows/"
@@ -295,3 +868,113 @@ func parseWorkflowPaths(output string) []string {
 	sort.Strings(workflows)
 	return workflows
 }
+
+// parseWorkflowNames parses tab-separated path and name lines
+func parseWorkflowNames(output string) map[string]string {
+	names := make(map[string]string)
+	const prefix = ".github/workflows/"
+
+	for _, line := range strings.Split(output, "\n") {
+		path, name, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		name = strings.TrimSpace(name)
+		if !ok || !strings.HasPrefix(path, prefix) || name == "" || name == path {
+			continue
+		}
+		names[path[len(prefix):]] = name
+	}
+
+	return names
+}
+
+// parseWorkflowInputs extracts the workflow_dispatch inputs from a workflow file
+func parseWorkflowInputs(data []byte) ([]models.WorkflowInput, error) {
+	var workflow struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(data, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	dispatch, ok := findTrigger(&workflow.On, "workflow_dispatch")
+	if !ok {
+		return nil, ErrNoWorkflowDispatch
+	}
+
+	inputs, _ := findTrigger(dispatch, "inputs")
+	if inputs == nil || inputs.Kind != yaml.MappingNode {
+		return []models.WorkflowInput{}, nil
+	}
+
+	result := make([]models.WorkflowInput, 0, len(inputs.Content)/2)
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		var input models.WorkflowInput
+		if err := inputs.Content[i+1].Decode(&input); err != nil {
+			return nil, fmt.Errorf("failed to parse input %q: %w", inputs.Content[i].Value, err)
+		}
+		input.Name = inputs.Content[i].Value
+		if input.Type == "" {
+			input.Type = "string"
+		}
+		result = append(result, input)
+	}
+	return result, nil
+}
+
+// parseWorkflowInfo reads a workflow's name and trigger events
+func parseWorkflowInfo(data []byte) (*models.WorkflowInfo, error) {
+	var workflow struct {
+		Name string    `yaml:"name"`
+		On   yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(data, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	return &models.WorkflowInfo{Name: workflow.Name, Triggers: triggerNames(&workflow.On)}, nil
+}
+
+// triggerNames lists the events of an "on:" style node in file order, in
+// any of the forms findTrigger accepts
+func triggerNames(node *yaml.Node) []string {
+	var names []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value != "" {
+			names = append(names, node.Value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			names = append(names, item.Value)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			names = append(names, node.Content[i].Value)
+		}
+	}
+	return names
+}
+
+// findTrigger looks up key in an "on:" style node, which may be a single
+// event name, a list of event names, or a mapping of event configurations.
+// It returns the key's value node (nil for the scalar and list forms).
+func findTrigger(node *yaml.Node, key string) (*yaml.Node, bool) {
+	if node == nil {
+		return nil, false
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return nil, node.Value == key
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Value == key {
+				return nil, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], true
+			}
+		}
+	}
+	return nil, false
+}
@@ -1,9 +1,35 @@
 package github
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"testing"
 )
 
+func TestCommandRunsGH(t *testing.T) {
+	// A stand-in gh on PATH that lists one run titled after GH_HOST
+	dir := t.TempDir()
+	script := "#!/bin/sh\nprintf '[{\"databaseId\": 1, \"displayTitle\": \"%s\"}]' \"$GH_HOST\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "gh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	client := NewClient("owner/repo")
+	client.SetHost("ghe.example.com")
+	runs, err := client.GetWorkflowRuns("build.yml", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].DisplayTitle != "ghe.example.com" {
+		t.Errorf("expected the run listed by gh on the client host, got %+v", runs)
+	}
+}
+
 func TestParseWorkflowPaths(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -62,3 +88,418 @@ func TestParseWorkflowPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWorkflowNames(t *testing.T) {
+	output := ".github/workflows/build.yml\tBuild and test\n" +
+		".github/workflows/lint.yml\t.github/workflows/lint.yml\n" +
+		"dynamic/github-code-scanning/codeql\tCodeQL\n" +
+		"\n"
+
+	names := parseWorkflowNames(output)
+	if len(names) != 1 || names["build.yml"] != "Build and test" {
+		t.Errorf("parseWorkflowNames() = %v, want only build.yml named", names)
+	}
+}
+
+func TestParseAnnotations(t *testing.T) {
+	output := `{"path": "main.go", "start_line": 12, "annotation_level": "failure", "title": "vet", "message": "undefined: foo"}
+{"path": ".github", "start_line": 1, "annotation_level": "notice", "message": "Node 16 is deprecated"}
+`
+	annotations, err := parseAnnotations([]byte(output))
+	if err != nil {
+		t.Fatalf("parseAnnotations() error: %v", err)
+	}
+	if len(annotations) != 2 {
+		t.Fatalf("expected 2 annotations, got %d", len(annotations))
+	}
+	if a := annotations[0]; a.Path != "main.go" || a.StartLine != 12 || a.Level != "failure" || a.Title != "vet" {
+		t.Errorf("unexpected first annotation: %+v", a)
+	}
+
+	if annotations, err := parseAnnotations(nil); err != nil || len(annotations) != 0 {
+		t.Errorf("parseAnnotations(empty) = %v, %v; want none", annotations, err)
+	}
+	if _, err := parseAnnotations([]byte("{not json")); err == nil {
+		t.Error("expected an error for malformed output")
+	}
+}
+
+func TestParseWorkflowInputs(t *testing.T) {
+	workflow := `name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        required: true
+        type: choice
+        options: [staging, production]
+      dry_run:
+        type: boolean
+        default: false
+      version:
+        description: Version to deploy
+jobs: {}
+`
+
+	inputs, err := parseWorkflowInputs([]byte(workflow))
+	if err != nil {
+		t.Fatalf("parseWorkflowInputs() error = %v", err)
+	}
+
+	if len(inputs) != 3 {
+		t.Fatalf("expected 3 inputs, got %d", len(inputs))
+	}
+
+	if inputs[0].Name != "environment" || !inputs[0].Required || inputs[0].Type != "choice" || len(inputs[0].Options) != 2 {
+		t.Errorf("unexpected first input: %+v", inputs[0])
+	}
+	if inputs[1].Name != "dry_run" || inputs[1].Type != "boolean" || inputs[1].Default != "false" {
+		t.Errorf("unexpected second input: %+v", inputs[1])
+	}
+	if inputs[2].Name != "version" || inputs[2].Type != "string" {
+		t.Errorf("expected untyped input to default to string, got %+v", inputs[2])
+	}
+}
+
+func TestParseWorkflowInputsTriggerForms(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow string
+		wantErr  error
+	}{
+		{name: "scalar trigger", workflow: "on: workflow_dispatch\n"},
+		{name: "list trigger", workflow: "on: [push, workflow_dispatch]\n"},
+		{name: "mapping without inputs", workflow: "on:\n  workflow_dispatch:\n"},
+		{name: "no dispatch", workflow: "on: [push, pull_request]\n", wantErr: ErrNoWorkflowDispatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, err := parseWorkflowInputs([]byte(tt.workflow))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseWorkflowInputs() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(inputs) != 0 {
+				t.Errorf("expected no inputs, got %+v", inputs)
+			}
+		})
+	}
+}
+
+func TestParseWorkflowInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow string
+		want     []string
+	}{
+		{name: "scalar", workflow: "name: CI\non: push\n", want: []string{"push"}},
+		{name: "list", workflow: "name: CI\non: [push, pull_request]\n", want: []string{"push", "pull_request"}},
+		{name: "mapping", workflow: "name: CI\non:\n  pull_request:\n    branches: [main]\n  workflow_dispatch:\n", want: []string{"pull_request", "workflow_dispatch"}},
+		{name: "no triggers", workflow: "name: CI\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := parseWorkflowInfo([]byte(tt.workflow))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Name != "CI" || !slices.Equal(info.Triggers, tt.want) {
+				t.Errorf("parseWorkflowInfo() = %+v, want triggers %v", info, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandHost(t *testing.T) {
+	client := NewClient("owner/repo")
+
+	cmd := client.command(context.Background(), "", "run", "list")
+	if cmd.Env != nil {
+		t.Errorf("expected inherited environment without a host, got %v", cmd.Env)
+	}
+	if got := cmd.Args; len(got) != 3 || got[1] != "run" || got[2] != "list" {
+		t.Errorf("unexpected args %v", got)
+	}
+
+	client.SetHost("ghe.example.com")
+	cmd = client.command(context.Background(), "", "run", "list")
+	if !slices.Contains(cmd.Env, "GH_HOST=ghe.example.com") {
+		t.Error("expected GH_HOST from the client host")
+	}
+
+	cmd = client.command(context.Background(), "other.example.com", "run", "list")
+	if !slices.Contains(cmd.Env, "GH_HOST=other.example.com") {
+		t.Error("expected explicit host to override the client host")
+	}
+}
+
+func TestNormalizeRepo(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "owner/repo", want: "owner/repo"},
+		{input: "  owner/repo\n", want: "owner/repo"},
+		{input: "owner/repo.git", want: "owner/repo"},
+		{input: "owner/repo/", want: "owner/repo"},
+		{input: "github.com/owner/repo", want: "owner/repo"},
+		{input: "GHE.Example.com/Owner/Repo", want: "ghe.example.com/Owner/Repo"},
+		{input: "https://github.com/owner/repo", want: "owner/repo"},
+		{input: "https://github.com/owner/repo.git", want: "owner/repo"},
+		{input: "git@github.com:owner/repo.git", want: "owner/repo"},
+		{input: " https://ghe.example.com/owner/repo ", want: "ghe.example.com/owner/repo"},
+		{input: "", wantErr: true},
+		{input: "owner", wantErr: true},
+		{input: "owner/repo/extra", wantErr: true},
+		{input: "https://github.com/owner", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeRepo(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeRepo(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeRepo(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestAliasCommand(t *testing.T) {
+	tests := []struct {
+		name, repo, workflow string
+		want                 string
+	}{
+		{repo: "owner/cli", want: `gh alias set 'rivet-cli' 'rivet --repo owner/cli'`},
+		{repo: "owner/cli", workflow: "build.yml", want: `gh alias set 'rivet-cli-build' 'rivet --repo owner/cli --workflow build.yml'`},
+		{name: "ci", repo: "ghe.example.com/owner/cli", want: `gh alias set 'ci' 'rivet --repo ghe.example.com/owner/cli'`},
+		{name: "it's", repo: "owner/cli", want: `gh alias set 'it'\''s' 'rivet --repo owner/cli'`},
+	}
+
+	for _, tt := range tests {
+		if got := AliasCommand(tt.name, tt.repo, tt.workflow); got != tt.want {
+			t.Errorf("AliasCommand(%q, %q, %q) = %s, want %s", tt.name, tt.repo, tt.workflow, got, tt.want)
+		}
+	}
+}
+
+func TestSetGHPath(t *testing.T) {
+	t.Cleanup(func() { SetGHPath("") })
+
+	dir := t.TempDir()
+	wrapper := filepath.Join(dir, "gh-wrapper")
+	if err := os.WriteFile(wrapper, []byte("#!/bin/sh\nexec gh \"$@\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	notExecutable := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(notExecutable, []byte("gh"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateGHPath(wrapper); err != nil {
+		t.Errorf("expected wrapper to be valid: %v", err)
+	}
+	if err := ValidateGHPath(notExecutable); err == nil {
+		t.Error("expected an error for a file that is not executable")
+	}
+	if err := ValidateGHPath(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	SetGHPath(wrapper)
+	cmd := NewClient("owner/repo").command(context.Background(), "", "run", "list")
+	if cmd.Path != wrapper {
+		t.Errorf("expected command to run %s, got %s", wrapper, cmd.Path)
+	}
+
+	SetGHPath("")
+	if GHPath() != "gh" {
+		t.Errorf("expected empty path to restore gh, got %q", GHPath())
+	}
+}
+
+func TestOpenJobInBrowser(t *testing.T) {
+	var gotArgs []string
+	client := NewClient("owner/repo")
+	client.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.CommandContext(ctx, "true")
+	})
+
+	if err := client.OpenJobInBrowser(12, 34); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"run", "view", "12", "--job", "34", "-w", "--repo", "owner/repo"}
+	if !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+
+	if err := client.OpenJobInBrowser(12, 0); err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(gotArgs, "--job") {
+		t.Errorf("expected the run page without a job, got %v", gotArgs)
+	}
+}
+
+func TestOpenActionsInBrowser(t *testing.T) {
+	var gotArgs []string
+	client := NewClient("owner/repo")
+	client.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.CommandContext(ctx, "true")
+	})
+
+	if err := client.OpenActionsInBrowser(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"browse", "--actions", "--repo", "owner/repo"}
+	if !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestListRunsOptions(t *testing.T) {
+	runs := `[
+		{"databaseId": 1, "createdAt": "2024-01-01T10:00:00Z", "updatedAt": "2024-01-01T12:00:00Z", "event": "push"},
+		{"databaseId": 2, "createdAt": "2024-01-01T11:00:00Z", "updatedAt": "2024-01-01T11:30:00Z", "event": "schedule"}
+	]`
+
+	var gotArgs []string
+	client := NewClient("owner/repo")
+	client.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.CommandContext(ctx, "echo", runs)
+	})
+
+	tests := []struct {
+		name       string
+		opts       RunListOptions
+		wantArgs   []string
+		wantFields string
+		wantIDs    []int
+	}{
+		{
+			name:       "defaults",
+			opts:       RunListOptions{},
+			wantArgs:   []string{"--limit", "20"},
+			wantFields: "databaseId,displayTitle,workflowName,status,conclusion,createdAt,headBranch,attempt",
+			wantIDs:    []int{2, 1},
+		},
+		{
+			name:       "workflow and extra fields",
+			opts:       RunListOptions{Workflow: "ci.yml", Limit: 5, Fields: []string{"event", "createdAt"}},
+			wantArgs:   []string{"--limit", "5", "--workflow", "ci.yml"},
+			wantFields: "databaseId,displayTitle,workflowName,status,conclusion,createdAt,headBranch,attempt,event",
+			wantIDs:    []int{2, 1},
+		},
+		{
+			name:       "by updated",
+			opts:       RunListOptions{OrderBy: OrderByUpdated},
+			wantArgs:   []string{"--limit", "20"},
+			wantFields: "databaseId,displayTitle,workflowName,status,conclusion,createdAt,headBranch,attempt,updatedAt",
+			wantIDs:    []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ListRuns(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < len(tt.wantArgs); i += 2 {
+				flag := slices.Index(gotArgs, tt.wantArgs[i])
+				if flag < 0 || flag+1 >= len(gotArgs) || gotArgs[flag+1] != tt.wantArgs[i+1] {
+					t.Errorf("expected %s %s in %v", tt.wantArgs[i], tt.wantArgs[i+1], gotArgs)
+				}
+			}
+			if json := slices.Index(gotArgs, "--json"); json < 0 || gotArgs[json+1] != tt.wantFields {
+				t.Errorf("expected fields %s in %v", tt.wantFields, gotArgs)
+			}
+			if tt.opts.Workflow == "" && slices.Contains(gotArgs, "--workflow") {
+				t.Errorf("unexpected --workflow in %v", gotArgs)
+			}
+
+			var ids []int
+			for _, run := range got {
+				ids = append(ids, run.DatabaseID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("run order = %v, want %v", ids, tt.wantIDs)
+			}
+			if got[0].Event == "" {
+				t.Error("expected the event to be decoded")
+			}
+		})
+	}
+}
+
+func TestGetLatestRun(t *testing.T) {
+	output := `[{"databaseId": 7, "status": "completed", "conclusion": "success"}]`
+	var gotArgs []string
+	client := NewClient("owner/repo")
+	client.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.CommandContext(ctx, "echo", output)
+	})
+
+	run, err := client.GetLatestRun("ci.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.DatabaseID != 7 {
+		t.Errorf("expected run 7, got %d", run.DatabaseID)
+	}
+	if !slices.Contains(gotArgs, "ci.yml") || !slices.Contains(gotArgs, "1") {
+		t.Errorf("expected one run of ci.yml requested, got %v", gotArgs)
+	}
+
+	output = `[]`
+	if _, err := client.GetLatestRun("ci.yml"); !errors.Is(err, ErrNoRuns) {
+		t.Errorf("expected ErrNoRuns, got %v", err)
+	}
+}
+
+func TestGetLatestRepoRun(t *testing.T) {
+	var gotArgs []string
+	client := NewClient("owner/repo")
+	client.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.CommandContext(ctx, "echo", `[{"databaseId": 9, "workflowName": "Deploy"}]`)
+	})
+
+	run, err := client.GetLatestRepoRun()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.DatabaseID != 9 {
+		t.Errorf("expected run 9, got %d", run.DatabaseID)
+	}
+	if slices.Contains(gotArgs, "--workflow") {
+		t.Errorf("expected runs of every workflow, got %v", gotArgs)
+	}
+}
+
+func TestRerunFailedJobs(t *testing.T) {
+	var gotArgs []string
+	client := NewClient("owner/repo")
+	client.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.CommandContext(ctx, "true")
+	})
+
+	if err := client.RerunFailedJobs(42); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"run", "rerun", "42", "--failed", "--repo", "owner/repo"}
+	if !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
@@ -0,0 +1,185 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+// Strategy decides which tier a legacy config ends up in
+type Strategy int
+
+const (
+	// MigrateToUser moves the legacy config into the user tier: the project
+	// user config for a config inside a repository, the user global config
+	// otherwise
+	MigrateToUser Strategy = iota
+	// KeepAsTeam keeps the legacy config as the repository default shared with
+	// the team. Its pins are personal, so they move to the project user config.
+	KeepAsTeam
+)
+
+// Plan lists the file changes a migration makes, so they can be shown
+// before anything is written
+type Plan struct {
+	Strategy Strategy
+	Source   string
+	Created  []string
+	Modified []string
+	Deleted  []string
+	// MovedPins are the pins moved out of the team config into the project
+	// user config
+	MovedPins []config.PinnedWorkflow
+	// Tiers are the config files in effect afterwards, lowest precedence first
+	Tiers []string
+
+	moveTo    string // where Source moves, or "" when it stays in place
+	teamPath  string // the team config pins are moved out of
+	userPath  string // the user config pins are moved into
+	backupDir string // where changed files are backed up first
+}
+
+// PlanMigration works out how the legacy config at legacyPath moves into the
+// tier strategy asks for, without writing anything. A migration never
+// overwrites an existing config; one whose target exists has to be merged by
+// hand.
+func PlanMigration(legacyPath string, p *paths.Paths, strategy Strategy) (*Plan, error) {
+	legacy, err := config.LoadFromPath(legacyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Strategy: strategy, Source: legacyPath, backupDir: p.BackupDir()}
+	switch strategy {
+	case MigrateToUser:
+		err = plan.toUser(p)
+	case KeepAsTeam:
+		err = plan.asTeam(legacy, p)
+	default:
+		err = fmt.Errorf("unknown migration strategy %d", strategy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan.Tiers = plan.tiersAfter(p)
+	return plan, nil
+}
+
+// Migrate plans the migration of the legacy config at legacyPath and applies
+// it
+func Migrate(legacyPath string, p *paths.Paths, strategy Strategy) (*Plan, error) {
+	plan, err := PlanMigration(legacyPath, p, strategy)
+	if err != nil {
+		return nil, err
+	}
+	return plan, plan.Apply()
+}
+
+// Apply makes the file changes of the plan, backing up every file it changes
+// or moves first. Pins are saved to the user config before they are removed
+// from the team config, so a failure never loses them.
+func (plan *Plan) Apply() error {
+	for _, path := range slices.Concat(plan.Modified, plan.Deleted) {
+		if err := config.BackupFile(plan.backupDir, path); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if plan.moveTo != "" {
+		if err := moveFile(plan.Source, plan.moveTo); err != nil {
+			return err
+		}
+	}
+	if len(plan.MovedPins) > 0 {
+		if _, err := config.MovePinsToUser(plan.teamPath, plan.userPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (plan *Plan) toUser(p *paths.Paths) error {
+	target := p.UserConfigFile()
+	if inProject(plan.Source, p) {
+		target = p.ProjectUserConfigPath
+	}
+	if fileExists(target) {
+		return fmt.Errorf("%s already exists; merge %s into it by hand", target, plan.Source)
+	}
+
+	plan.moveTo = target
+	plan.Created = append(plan.Created, target)
+	plan.Deleted = append(plan.Deleted, plan.Source)
+	return nil
+}
+
+func (plan *Plan) asTeam(legacy *config.Config, p *paths.Paths) error {
+	if !inProject(plan.Source, p) {
+		return fmt.Errorf("%s is not inside a repository, so it cannot be kept as a team default", plan.Source)
+	}
+
+	teamPath := p.RepoDefaultConfigPath
+	if plan.Source != teamPath {
+		if fileExists(teamPath) {
+			return fmt.Errorf("%s already exists; merge %s into it by hand", teamPath, plan.Source)
+		}
+		plan.moveTo = teamPath
+		plan.Created = append(plan.Created, teamPath)
+		plan.Deleted = append(plan.Deleted, plan.Source)
+	}
+
+	plan.MovedPins = legacy.GetAllPinnedWorkflows()
+	if len(plan.MovedPins) == 0 {
+		return nil
+	}
+	plan.teamPath, plan.userPath = teamPath, p.ProjectUserConfigPath
+	if fileExists(plan.userPath) {
+		plan.Modified = append(plan.Modified, plan.userPath)
+	} else {
+		plan.Created = append(plan.Created, plan.userPath)
+	}
+	if plan.Source == teamPath {
+		plan.Modified = append(plan.Modified, teamPath)
+	}
+	return nil
+}
+
+// tiersAfter returns the config tiers that exist once the plan is applied,
+// lowest precedence first
+func (plan *Plan) tiersAfter(p *paths.Paths) []string {
+	var tiers []string
+	for _, path := range []string{p.RepoDefaultConfigPath, p.UserConfigFile(), p.ProjectUserConfigPath} {
+		if path == "" || slices.Contains(plan.Deleted, path) {
+			continue
+		}
+		if fileExists(path) || slices.Contains(plan.Created, path) {
+			tiers = append(tiers, path)
+		}
+	}
+	return tiers
+}
+
+// inProject reports whether path lies inside p's repository
+func inProject(path string, p *paths.Paths) bool {
+	return p.ProjectRoot != "" && strings.HasPrefix(path, p.ProjectRoot+string(filepath.Separator))
+}
+
+// moveFile moves src to dst, creating dst's directory
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,198 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+const legacyContent = `repository: owner/repo
+groups:
+  - id: ci
+    name: CI
+    workflows: [test.yml, build.yml]
+    pinnedWorkflows: [test.yml]
+`
+
+// newProject returns paths for a repository in a temp dir with a legacy
+// .rivet.yaml at its root
+func newProject(t *testing.T) (*paths.Paths, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "repo")
+	p := &paths.Paths{
+		UserConfigDir:         filepath.Join(tmpDir, "config"),
+		UserCacheDir:          filepath.Join(tmpDir, "cache"),
+		ProjectRoot:           root,
+		RepoDefaultConfigPath: filepath.Join(root, ".github", paths.LegacyConfigFileName),
+		ProjectUserConfigPath: filepath.Join(root, ".git", "rivet", paths.ConfigFileName),
+	}
+	legacyPath := filepath.Join(root, paths.LegacyConfigFileName)
+	if err := os.MkdirAll(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacyPath, []byte(legacyContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p, legacyPath
+}
+
+func TestMigrateToUser(t *testing.T) {
+	p, legacyPath := newProject(t)
+
+	result, err := Migrate(legacyPath, p, MigrateToUser)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0] != p.ProjectUserConfigPath {
+		t.Errorf("expected the project user config created, got %v", result.Created)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != legacyPath {
+		t.Errorf("expected the legacy config deleted, got %v", result.Deleted)
+	}
+	if len(result.MovedPins) != 0 {
+		t.Errorf("expected pins to stay with the config, got %+v", result.MovedPins)
+	}
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Errorf("expected %s removed, got %v", legacyPath, err)
+	}
+	if backups, _ := config.ListBackups(p.BackupDir()); len(backups) != 1 || backups[0].Source != legacyPath {
+		t.Errorf("expected the legacy config backed up, got %+v", backups)
+	}
+
+	cfg, err := config.LoadFromPath(p.ProjectUserConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Repository != "owner/repo" || !cfg.Groups[0].IsPinned("test.yml") {
+		t.Errorf("expected the legacy config with its pins, got %+v", cfg)
+	}
+}
+
+func TestMigrateToUserRefusesExistingTarget(t *testing.T) {
+	p, legacyPath := newProject(t)
+	if err := os.MkdirAll(filepath.Dir(p.ProjectUserConfigPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p.ProjectUserConfigPath, []byte("repository: owner/other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Migrate(legacyPath, p, MigrateToUser); err == nil {
+		t.Fatal("expected an error when the target exists")
+	}
+	if _, err := os.Stat(legacyPath); err != nil {
+		t.Errorf("expected the legacy config kept, got %v", err)
+	}
+}
+
+func TestKeepAsTeam(t *testing.T) {
+	p, legacyPath := newProject(t)
+
+	result, err := Migrate(legacyPath, p, KeepAsTeam)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(result.MovedPins) != 1 || result.MovedPins[0].WorkflowName != "test.yml" {
+		t.Errorf("expected test.yml reported as moved, got %+v", result.MovedPins)
+	}
+	if len(result.Created) != 2 || result.Created[0] != p.RepoDefaultConfigPath || result.Created[1] != p.ProjectUserConfigPath {
+		t.Errorf("expected the team and project user configs created, got %v", result.Created)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != legacyPath {
+		t.Errorf("expected the legacy config deleted, got %v", result.Deleted)
+	}
+
+	team, err := config.LoadFromPath(p.RepoDefaultConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(team.GetAllPinnedWorkflows()) != 0 || len(team.Groups[0].Workflows) != 2 {
+		t.Errorf("expected the team config without pins, got %+v", team.Groups)
+	}
+
+	merged, err := config.LoadMerged([]string{p.RepoDefaultConfigPath, p.ProjectUserConfigPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinned := merged.GetAllPinnedWorkflows(); len(pinned) != 1 || pinned[0].WorkflowName != "test.yml" {
+		t.Errorf("expected test.yml still pinned for the user, got %+v", pinned)
+	}
+}
+
+func TestKeepAsTeamInPlace(t *testing.T) {
+	p, legacyPath := newProject(t)
+	if err := os.MkdirAll(filepath.Dir(p.RepoDefaultConfigPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(legacyPath, p.RepoDefaultConfigPath); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Migrate(p.RepoDefaultConfigPath, p, KeepAsTeam)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(result.Deleted) != 0 {
+		t.Errorf("expected nothing deleted, got %v", result.Deleted)
+	}
+	if len(result.Modified) != 1 || result.Modified[0] != p.RepoDefaultConfigPath {
+		t.Errorf("expected the team config modified, got %v", result.Modified)
+	}
+	if len(result.MovedPins) != 1 {
+		t.Errorf("expected one pin moved, got %+v", result.MovedPins)
+	}
+}
+
+func TestKeepAsTeamOutsideRepository(t *testing.T) {
+	tmpDir := t.TempDir()
+	legacyPath := filepath.Join(tmpDir, paths.LegacyConfigFileName)
+	if err := os.WriteFile(legacyPath, []byte(legacyContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p := &paths.Paths{UserConfigDir: filepath.Join(tmpDir, "config"), UserCacheDir: filepath.Join(tmpDir, "cache")}
+
+	if _, err := Migrate(legacyPath, p, KeepAsTeam); err == nil {
+		t.Fatal("expected an error outside a repository")
+	}
+
+	result, err := Migrate(legacyPath, p, MigrateToUser)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0] != p.UserConfigFile() {
+		t.Errorf("expected the user global config created, got %v", result.Created)
+	}
+}
+
+func TestPlanMigrationWritesNothing(t *testing.T) {
+	p, legacyPath := newProject(t)
+
+	plan, err := PlanMigration(legacyPath, p, KeepAsTeam)
+	if err != nil {
+		t.Fatalf("PlanMigration() error = %v", err)
+	}
+	for _, path := range plan.Created {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s not created by planning, got %v", path, err)
+		}
+	}
+	if _, err := os.Stat(legacyPath); err != nil {
+		t.Errorf("expected the legacy config untouched, got %v", err)
+	}
+	if len(plan.Tiers) != 2 || plan.Tiers[0] != p.RepoDefaultConfigPath || plan.Tiers[1] != p.ProjectUserConfigPath {
+		t.Errorf("expected the team and project user tiers afterwards, got %v", plan.Tiers)
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	for _, path := range plan.Tiers {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s after applying the plan, got %v", path, err)
+		}
+	}
+}
@@ -17,6 +17,15 @@ const (
 	// StateFileName is the name of the state file
 	StateFileName = "state.yaml"
 
+	// GlobalStateFileName is the name of the state file shared by all repositories
+	GlobalStateFileName = "global.yaml"
+
+	// TagsFileName ends the name of each repository's run tags file
+	TagsFileName = "tags.yaml"
+
+	// DebugLogFileName is the name of the log written with --debug
+	DebugLogFileName = "debug.log"
+
 	// LegacyConfigFileName is the old config file name
 	LegacyConfigFileName = ".rivet.yaml"
 
@@ -77,12 +86,31 @@ type Paths struct {
 	usingFallbacks map[string]bool
 }
 
-// New creates a new Paths instance with XDG-compliant directories
+// baseDir replaces the XDG base directories when set with SetBaseDir
+var baseDir string
+
+// SetBaseDir moves the user directories under dir: the config directory is
+// dir itself, with state and cache in dir/state and dir/cache. It takes
+// precedence over the XDG environment variables for every later New call.
+// An empty dir restores the XDG directories.
+func SetBaseDir(dir string) {
+	baseDir = dir
+}
+
+// New creates a new Paths instance with XDG-compliant directories, or the
+// directories under the base directory set with SetBaseDir
 func New() (*Paths, error) {
 	p := &Paths{
 		usingFallbacks: make(map[string]bool),
 	}
 
+	if baseDir != "" {
+		p.UserConfigDir = baseDir
+		p.UserStateDir = filepath.Join(baseDir, "state")
+		p.UserCacheDir = filepath.Join(baseDir, "cache")
+		return p, nil
+	}
+
 	// Get user config directory (XDG_CONFIG_HOME or ~/.config on Unix, %AppData% on Windows)
 	configDir, err := os.UserConfigDir()
 	if err != nil {
@@ -140,6 +168,60 @@ func (p *Paths) UserStateFile(repoOwner, repoName string) string {
 	return filepath.Join(p.UserStateDir, filename)
 }
 
+// RepositoryStateFiles returns the per-repository state files in
+// UserStateDir, keyed by the owner/repo each belongs to
+func (p *Paths) RepositoryStateFiles() (map[string]string, error) {
+	entries, err := os.ReadDir(p.UserStateDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		// Owners cannot contain underscores, so the first one ends the owner
+		base, ok := strings.CutSuffix(entry.Name(), "."+StateFileName)
+		owner, repo, found := strings.Cut(base, "_")
+		if !ok || !found || owner == "" || repo == "" {
+			continue
+		}
+		files[owner+"/"+repo] = filepath.Join(p.UserStateDir, entry.Name())
+	}
+	return files, nil
+}
+
+// UserTagsFile returns the path of the file holding the run tags of a
+// repository, in owner/repo or host/owner/repo form
+func (p *Paths) UserTagsFile(repository string) string {
+	return filepath.Join(p.UserStateDir, sanitizeForFilename(repository)+"."+TagsFileName)
+}
+
+// GlobalStateFile returns the path to the state file shared by all repositories
+func (p *Paths) GlobalStateFile() string {
+	return filepath.Join(p.UserStateDir, GlobalStateFileName)
+}
+
+// BackupDir returns where config files are backed up before a destructive
+// write, such as a reset or import
+func (p *Paths) BackupDir() string {
+	return filepath.Join(p.UserCacheDir, "backups")
+}
+
+// RunLogDir returns where run logs saved from the TUI are written
+func (p *Paths) RunLogDir() string {
+	return filepath.Join(p.UserCacheDir, "logs")
+}
+
+// DebugLogFile returns the path of the log written with --debug
+func (p *Paths) DebugLogFile() string {
+	return filepath.Join(p.UserStateDir, DebugLogFileName)
+}
+
 // dirSpec defines a directory with its criticality and purpose
 type dirSpec struct {
 	path     *string // pointer to the path field in Paths struct
@@ -40,6 +40,29 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithBaseDir(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(base, "xdg-config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(base, "xdg-state"))
+
+	SetBaseDir(base)
+	t.Cleanup(func() { SetBaseDir("") })
+
+	p, err := NewWithProject(filepath.Join(base, "project"))
+	if err != nil {
+		t.Fatalf("NewWithProject() failed: %v", err)
+	}
+	if p.UserConfigFile() != filepath.Join(base, ConfigFileName) {
+		t.Errorf("UserConfigFile() = %s, want it directly in the base directory", p.UserConfigFile())
+	}
+	if p.UserStateDir != filepath.Join(base, "state") || p.UserCacheDir != filepath.Join(base, "cache") {
+		t.Errorf("expected state and cache under the base directory, got %s and %s", p.UserStateDir, p.UserCacheDir)
+	}
+	if p.ProjectUserConfigPath != filepath.Join(base, "project", ".git", AppName, ConfigFileName) {
+		t.Errorf("project paths should not move, got %s", p.ProjectUserConfigPath)
+	}
+}
+
 func TestNewWithProject(t *testing.T) {
 	projectRoot := "/path/to/project"
 	p, err := NewWithProject(projectRoot)
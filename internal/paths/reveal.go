@@ -0,0 +1,49 @@
+package paths
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ErrNoFileManager is returned by Reveal when there is no file manager to
+// open, such as over SSH or in a container
+var ErrNoFileManager = errors.New("no file manager available")
+
+// Reveal opens dir in the system file manager: open on macOS, explorer on
+// Windows, and xdg-open elsewhere when a display is available
+func Reveal(dir string) error {
+	opener, err := fileManager(runtime.GOOS, os.Getenv, exec.LookPath)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(opener, dir)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// explorer exits non-zero even on success, so the result is not awaited
+	return cmd.Process.Release()
+}
+
+// fileManager picks the command that opens a directory on goos
+func fileManager(goos string, getenv func(string) string, lookPath func(string) (string, error)) (string, error) {
+	var opener string
+	switch goos {
+	case "darwin":
+		opener = "open"
+	case "windows":
+		opener = "explorer"
+	default:
+		if getenv("DISPLAY") == "" && getenv("WAYLAND_DISPLAY") == "" {
+			return "", ErrNoFileManager
+		}
+		opener = "xdg-open"
+	}
+
+	if _, err := lookPath(opener); err != nil {
+		return "", ErrNoFileManager
+	}
+	return opener, nil
+}
@@ -0,0 +1,44 @@
+package paths
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFileManager(t *testing.T) {
+	found := func(name string) (string, error) { return "/usr/bin/" + name, nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	tests := []struct {
+		name     string
+		goos     string
+		env      map[string]string
+		lookPath func(string) (string, error)
+		want     string
+	}{
+		{name: "macOS", goos: "darwin", lookPath: found, want: "open"},
+		{name: "Windows", goos: "windows", lookPath: found, want: "explorer"},
+		{name: "Linux desktop", goos: "linux", env: map[string]string{"DISPLAY": ":0"}, lookPath: found, want: "xdg-open"},
+		{name: "Wayland", goos: "linux", env: map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, lookPath: found, want: "xdg-open"},
+		{name: "headless", goos: "linux", lookPath: found},
+		{name: "no xdg-open", goos: "linux", env: map[string]string{"DISPLAY": ":0"}, lookPath: missing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fileManager(tt.goos, env(tt.env), tt.lookPath)
+			if tt.want == "" {
+				if !errors.Is(err, ErrNoFileManager) {
+					t.Errorf("fileManager() = %q, %v; want ErrNoFileManager", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("fileManager() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+// GlobalState represents state shared across repositories
+type GlobalState struct {
+	// Repositories to view instead of the configured one (set by `rivet
+	// switch`), keyed by project root ("" outside a repository) so a switch
+	// in one project leaves the others alone
+	ActiveRepositories map[string]string `yaml:"activeRepositories,omitempty"`
+
+	// Repositories opened in each project, keyed by project root ("" outside
+	// a repository), so prune-state can check every config that may name them
+	Projects map[string][]string `yaml:"projects,omitempty"`
+}
+
+// LoadGlobal reads the global state, returning an empty state if none exists
+func LoadGlobal(p *paths.Paths) (*GlobalState, error) {
+	data, err := os.ReadFile(p.GlobalStateFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &GlobalState{}, nil
+		}
+		return nil, err
+	}
+
+	var gs GlobalState
+	if err := yaml.Unmarshal(data, &gs); err != nil {
+		// If the state file is corrupted, start from an empty state
+		return &GlobalState{}, nil
+	}
+
+	return &gs, nil
+}
+
+// SaveGlobal writes the global state to the user state directory
+func SaveGlobal(p *paths.Paths, gs *GlobalState) error {
+	if err := p.EnsureDirs(); err != nil {
+		return fmt.Errorf("failed to ensure state directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(gs)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.GlobalStateFile(), data, 0644)
+}
+
+// ActiveRepository returns the repository switched to in the project at
+// root, or "" when the project views its configured repository
+func (gs *GlobalState) ActiveRepository(root string) string {
+	return gs.ActiveRepositories[root]
+}
+
+// SetActiveRepository records repo as the active repository of the project
+// at root so its next launch defaults to it. An empty repo clears the
+// override.
+func SetActiveRepository(p *paths.Paths, root, repo string) error {
+	gs, err := LoadGlobal(p)
+	if err != nil {
+		return fmt.Errorf("failed to load global state: %w", err)
+	}
+
+	if repo == "" {
+		delete(gs.ActiveRepositories, root)
+	} else {
+		if gs.ActiveRepositories == nil {
+			gs.ActiveRepositories = make(map[string]string)
+		}
+		gs.ActiveRepositories[root] = repo
+	}
+	if err := SaveGlobal(p, gs); err != nil {
+		return fmt.Errorf("failed to save global state: %w", err)
+	}
+	return nil
+}
+
+// RecordProject records that repo was opened in the project at root, an
+// empty root meaning outside any repository. Nothing is written when it is
+// already recorded.
+func RecordProject(p *paths.Paths, root, repo string) error {
+	gs, err := LoadGlobal(p)
+	if err != nil {
+		return fmt.Errorf("failed to load global state: %w", err)
+	}
+	if slices.Contains(gs.Projects[root], repo) {
+		return nil
+	}
+
+	if gs.Projects == nil {
+		gs.Projects = make(map[string][]string)
+	}
+	gs.Projects[root] = append(gs.Projects[root], repo)
+	if err := SaveGlobal(p, gs); err != nil {
+		return fmt.Errorf("failed to save global state: %w", err)
+	}
+	return nil
+}
@@ -35,9 +35,31 @@ type NavigationState struct {
 	// Was workflow accessed via pinned view? (determines back navigation)
 	FromPinnedView bool `yaml:"fromPinnedView,omitempty"`
 
+	// For a workflow opened from the pinned view or search: the group IDs of
+	// the group it was opened in, as the same file can sit in several groups
+	SelectedGroupPath []string `yaml:"selectedGroupPath,omitempty"`
+
 	// List selection indices for better UX
 	ListIndex       int `yaml:"listIndex,omitempty"`
 	PinnedListIndex int `yaml:"pinnedListIndex,omitempty"`
+
+	// Last inputs used to dispatch each workflow, keyed by workflow file
+	DispatchInputs map[string]map[string]string `yaml:"dispatchInputs,omitempty"`
+
+	// Last ref each workflow was dispatched on, keyed by workflow file
+	DispatchRefs map[string]string `yaml:"dispatchRefs,omitempty"`
+
+	// How many times each workflow's runs were opened, keyed by workflow
+	// file, for listing the most used first
+	OpenCounts map[string]int `yaml:"openCounts,omitempty"`
+
+	// List workflows by filename instead of display name
+	ShowFilenames bool `yaml:"showFilenames,omitempty"`
+
+	// Last filter of each group list, keyed by group ID ("" for the root),
+	// and the repository they were typed in
+	Filters           map[string]string `yaml:"filters,omitempty"`
+	FiltersRepository string            `yaml:"filtersRepository,omitempty"`
 }
 
 // DefaultStatePath returns the default state file path relative to config (legacy)
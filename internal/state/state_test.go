@@ -3,9 +3,12 @@ package state
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
 )
 
 func TestLoadNonExistent(t *testing.T) {
@@ -34,6 +37,9 @@ func TestSaveAndLoad(t *testing.T) {
 		FromPinnedView:   true,
 		ListIndex:        5,
 		PinnedListIndex:  2,
+		DispatchInputs: map[string]map[string]string{
+			"deploy.yml": {"environment": "staging"},
+		},
 	}
 
 	// Save
@@ -77,6 +83,10 @@ func TestSaveAndLoad(t *testing.T) {
 	if loaded.PinnedListIndex != original.PinnedListIndex {
 		t.Errorf("PinnedListIndex: got %d, want %d", loaded.PinnedListIndex, original.PinnedListIndex)
 	}
+
+	if got := loaded.DispatchInputs["deploy.yml"]["environment"]; got != "staging" {
+		t.Errorf("DispatchInputs: got %q, want %q", got, "staging")
+	}
 }
 
 func TestClear(t *testing.T) {
@@ -248,3 +258,131 @@ func TestLoadCorruptedFile(t *testing.T) {
 		t.Errorf("Expected default ViewState, got %s", state.ViewState)
 	}
 }
+
+func TestSaveAndLoadGlobal(t *testing.T) {
+	p := &paths.Paths{
+		UserConfigDir: filepath.Join(t.TempDir(), "config"),
+		UserStateDir:  filepath.Join(t.TempDir(), "state"),
+		UserCacheDir:  filepath.Join(t.TempDir(), "cache"),
+	}
+
+	gs, err := LoadGlobal(p)
+	if err != nil {
+		t.Fatalf("LoadGlobal should not fail without a state file: %v", err)
+	}
+	if active := gs.ActiveRepository("/src/app"); active != "" {
+		t.Errorf("expected no active repository, got %q", active)
+	}
+
+	gs.ActiveRepositories = map[string]string{"/src/app": "octocat/Hello-World"}
+	if err := SaveGlobal(p, gs); err != nil {
+		t.Fatalf("SaveGlobal failed: %v", err)
+	}
+
+	loaded, err := LoadGlobal(p)
+	if err != nil {
+		t.Fatalf("LoadGlobal failed: %v", err)
+	}
+	if active := loaded.ActiveRepository("/src/app"); active != "octocat/Hello-World" {
+		t.Errorf("ActiveRepository: got %q, want %q", active, "octocat/Hello-World")
+	}
+}
+
+func TestSetActiveRepository(t *testing.T) {
+	p := &paths.Paths{
+		UserConfigDir: filepath.Join(t.TempDir(), "config"),
+		UserStateDir:  filepath.Join(t.TempDir(), "state"),
+		UserCacheDir:  filepath.Join(t.TempDir(), "cache"),
+	}
+
+	if err := SetActiveRepository(p, "/src/app", "me/fork"); err != nil {
+		t.Fatalf("SetActiveRepository failed: %v", err)
+	}
+	if err := SetActiveRepository(p, "/src/other", "me/other-fork"); err != nil {
+		t.Fatalf("SetActiveRepository failed: %v", err)
+	}
+
+	data, err := os.ReadFile(p.GlobalStateFile())
+	if err != nil {
+		t.Fatalf("global state file was not written: %v", err)
+	}
+	if !strings.Contains(string(data), "/src/app: me/fork") {
+		t.Errorf("global state file = %q, want /src/app: me/fork", string(data))
+	}
+
+	if err := SetActiveRepository(p, "/src/app", ""); err != nil {
+		t.Fatalf("SetActiveRepository failed: %v", err)
+	}
+	loaded, err := LoadGlobal(p)
+	if err != nil {
+		t.Fatalf("LoadGlobal failed: %v", err)
+	}
+	if active := loaded.ActiveRepository("/src/app"); active != "" {
+		t.Errorf("expected cleared active repository, got %q", active)
+	}
+	// Other projects keep their switch
+	if active := loaded.ActiveRepository("/src/other"); active != "me/other-fork" {
+		t.Errorf("active repository of another project = %q, want me/other-fork", active)
+	}
+}
+
+func TestRecordProject(t *testing.T) {
+	p := &paths.Paths{
+		UserConfigDir: filepath.Join(t.TempDir(), "config"),
+		UserStateDir:  filepath.Join(t.TempDir(), "state"),
+		UserCacheDir:  filepath.Join(t.TempDir(), "cache"),
+	}
+
+	for _, repo := range []string{"team/app", "me/fork", "team/app"} {
+		if err := RecordProject(p, "/src/app", repo); err != nil {
+			t.Fatalf("RecordProject failed: %v", err)
+		}
+	}
+	if err := RecordProject(p, "", "me/scratch"); err != nil {
+		t.Fatalf("RecordProject failed: %v", err)
+	}
+
+	loaded, err := LoadGlobal(p)
+	if err != nil {
+		t.Fatalf("LoadGlobal failed: %v", err)
+	}
+	if got := loaded.Projects["/src/app"]; !slices.Equal(got, []string{"team/app", "me/fork"}) {
+		t.Errorf("expected each repository recorded once, got %v", got)
+	}
+	if got := loaded.Projects[""]; !slices.Equal(got, []string{"me/scratch"}) {
+		t.Errorf("expected the repository opened outside a project, got %v", got)
+	}
+}
+
+func TestRunTags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "owner_repo.tags.yaml")
+
+	tags, err := LoadTags(path)
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("LoadTags() of a missing file = %v, %v; want no tags", tags, err)
+	}
+
+	if !tags.Toggle(42, "investigating") || !tags.Toggle(42, "flaky") || !tags.Toggle(7, "flaky") {
+		t.Fatal("expected Toggle to add new tags")
+	}
+	if got := tags.All(); !slices.Equal(got, []string{"flaky", "investigating"}) {
+		t.Errorf("All() = %v, want [flaky investigating]", got)
+	}
+	if tags.Toggle(7, "flaky") {
+		t.Error("expected Toggle to remove a tag the run has")
+	}
+	if _, ok := tags[7]; ok {
+		t.Error("expected a run without tags to be dropped")
+	}
+
+	if err := tags.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadTags(path)
+	if err != nil {
+		t.Fatalf("LoadTags() error = %v", err)
+	}
+	if !slices.Equal(loaded[42], []string{"investigating", "flaky"}) || len(loaded) != 1 {
+		t.Errorf("loaded tags = %v, want run 42's tags", loaded)
+	}
+}
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RunTags are the labels, such as "known-good" or "investigating", the
+// user gave runs of one repository, keyed by run ID
+type RunTags map[int][]string
+
+// LoadTags reads run tags from path, returning no tags if the file does not
+// exist
+func LoadTags(path string) (RunTags, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return RunTags{}, nil
+		}
+		return nil, err
+	}
+
+	tags := RunTags{}
+	if err := yaml.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("invalid tags file %s: %w", path, err)
+	}
+	return tags, nil
+}
+
+// Save writes the tags to path, creating its directory if needed
+func (t RunTags) Save(path string) error {
+	data, err := yaml.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Toggle adds tag to the run, or removes it if the run has it already, and
+// reports whether the run has it now
+func (t RunTags) Toggle(runID int, tag string) bool {
+	tags := t[runID]
+	if i := slices.Index(tags, tag); i >= 0 {
+		tags = slices.Delete(tags, i, i+1)
+		if len(tags) == 0 {
+			delete(t, runID)
+		} else {
+			t[runID] = tags
+		}
+		return false
+	}
+	t[runID] = append(tags, tag)
+	return true
+}
+
+// All returns every tag in use, sorted
+func (t RunTags) All() []string {
+	var all []string
+	for _, tags := range t {
+		for _, tag := range tags {
+			if !slices.Contains(all, tag) {
+				all = append(all, tag)
+			}
+		}
+	}
+	slices.Sort(all)
+	return all
+}
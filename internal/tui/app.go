@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,12 +18,14 @@ import (
 )
 
 type workflowRunsMsg struct {
-	runs []models.GHRun
-	err  error
+	workflow string // The workflow the runs were fetched for
+	runs     []models.GHRun
+	err      error
 }
 
 type refreshTickMsg struct {
 	timestamp time.Time
+	gen       int
 }
 
 type ViewMode int
@@ -37,23 +43,35 @@ const (
 )
 
 type App struct {
-	config     *config.Config
-	configPath string
-	statePath  string
-	gh         *github.Client
+	config        *config.Config
+	configPath    string
+	configPaths   []string
+	pinConfigPath string
+	pinTiers      []string // Personal tiers pins are toggled in, ending with pinConfigPath
+	statePath     string
+	gh            *github.Client
 
 	theme *theme.Theme
 
-	sidebar     components.Sidebar
-	navList     components.List
-	runsTable   *components.RunsTable
-	search      components.Search
-	cmdPalette  components.CmdPalette
-	helpOverlay components.HelpOverlay
-	toaster     components.Toaster
-	spinner     components.Spinner
-	statusBar   components.StatusBar
-	helpBar     components.HelpBar
+	sidebar      components.Sidebar
+	navList      components.List
+	runsTable    *components.RunsTable
+	search       components.Search
+	groupJump    components.Search
+	cmdPalette   components.CmdPalette
+	helpOverlay  components.HelpOverlay
+	dispatchForm components.DispatchForm
+	confirm      components.Confirm
+	annotations  components.Annotations
+	sourceView   components.SourceView
+	runView      components.RunView
+	onConfirm    func() (tea.Model, tea.Cmd)
+	branchPicker components.BranchPicker
+	onBranch     func(ref string) (tea.Model, tea.Cmd)
+	toaster      components.Toaster
+	spinner      components.Spinner
+	statusBar    components.StatusBar
+	helpBar      components.HelpBar
 
 	groupPath        []*config.Group
 	selectedWorkflow string
@@ -68,10 +86,103 @@ type App struct {
 	workflowRuns []models.GHRun
 	loading      bool
 	err          error
+	// Cancels the runs fetch in flight, so leaving a workflow aborts its
+	// gh call. See fetchWorkflowRuns.
+	cancelFetch context.CancelFunc
+
+	// Health view buckets workflows by the status of their latest run
+	healthView      bool
+	autoOpenMatch   bool
+	openBehavior    string // What w and enter do on a run, a config.Open* action
+	healthGroups    []config.Group
+	configGroupPath []*config.Group
+	latestRuns      map[string]*models.GHRun
+	healthErrors    map[string]error // why a workflow's latest run could not be fetched
+
+	// Labels the user gave runs of this repository, and the file in the
+	// state directory they are saved to
+	runTags  state.RunTags
+	tagsPath string
+
+	// List hidden workflows anyway, marked as hidden
+	showHidden bool
+	// The current directory's repository, and the one the user chose to
+	// view instead on exit. See SwitchRepository.
+	localRepository  string
+	switchRepository string
+	// List workflows by filename instead of display name
+	showFilenames bool
+	// Last filter of each group list, restored when rememberFilters is set.
+	// See rememberFilter.
+	filters         map[string]string
+	rememberFilters bool
+
+	// Built nav list items by the group they list, so navigating back and
+	// forth does not rebuild them. See invalidateNavItems.
+	navItems map[navItemsKey][]components.ListItem
+	// The nav list's current items, to tell when they are replaced by
+	// another group's
+	navListKey navItemsKey
+	// Groups flattened for search, built on first use
+	searchIndex *components.SearchIndex
+
+	// Only workflows that ran within this window get a badge at startup
+	since time.Duration
+
+	// The classic layout previews the highlighted workflow's runs in a
+	// details panel next to the group list
+	classicLayout   bool
+	details         components.Details
+	detailsWorkflow string
+	detailsRuns     map[string][]models.GHRun
+
+	// Check annotations of the runs the user opened them for, by run ID
+	runAnnotations map[int][]models.GHAnnotation
+	// Workflow files' YAML, by workflow file, fetched when first viewed
+	workflowSources map[string]string
+
+	// Path and triggers of the workflows whose runs were opened, by file
+	workflowInfo map[string]*models.WorkflowInfo
+
+	session    *Session
+	recordPath string
+
+	// Last inputs and ref each workflow was dispatched with, keyed by
+	// workflow file
+	dispatchInputs map[string]map[string]string
+	dispatchRefs   map[string]string
+	// How many times each workflow's runs were opened, keyed by workflow
+	// file, and the order of the lists
+	openCounts map[string]int
+	order      navOrder
+	// The repository's branches, default branch first, fetched once per
+	// session
+	branches []string
 
 	refreshInterval    int
-	refreshTicker      *time.Ticker
+	refreshTicker      ticker
+	refreshStop        chan struct{}
+	refreshGen         int
+	refreshArmed       bool
+	shutDown           bool
+	clock              clock
 	autoRefreshEnabled bool
+
+	// Quit after this long without key input, 0 meaning never
+	idleTimeout     time.Duration
+	lastInteraction time.Time
+
+	// The re-run being followed until it finishes, checked every rerunPoll
+	followed  followedRun
+	rerunPoll time.Duration
+
+	// The run just started by a dispatch or re-run that ctrl+o opens, found
+	// by checking every newRunPoll for up to newRunWait after a dispatch
+	offer      offeredRun
+	newRunPoll time.Duration
+	newRunWait time.Duration
+	// A run to highlight once the runs being fetched arrive
+	pendingRunID int
 }
 
 type AppOptions struct {
@@ -79,6 +190,38 @@ type AppOptions struct {
 	StatePath       string
 	NoRestoreState  bool
 	RefreshInterval int
+	// ConfigPaths are the config files cfg was merged from, lowest
+	// precedence first, reread when the config is reloaded. Defaults to the
+	// config path.
+	ConfigPaths []string
+	// PinConfigPath is the user-tier config that pin changes are written to.
+	// Defaults to the config path.
+	PinConfigPath string
+	// PersonalConfigPaths are the personal config tiers, lowest precedence
+	// first, that an unpin removes the pin from. The pin config path is
+	// always the last of them.
+	PersonalConfigPaths []string
+	// RecordPath, when set, saves the session's key presses and resizes
+	// there on exit so it can be replayed
+	RecordPath string
+	// Layout is config.LayoutModern (default) or config.LayoutClassic
+	Layout string
+	// Since, when positive, decorates at startup only the workflows that ran
+	// within this window, found with a single recent-runs query
+	Since time.Duration
+	// OpenPinned, when positive, opens the runs of the pinned workflow at
+	// this 1-based index of GetAllPinnedWorkflows at startup
+	OpenPinned int
+	// OpenWorkflow, when set, opens the runs of this workflow file at
+	// startup
+	OpenWorkflow string
+	// NoAutoOpen keeps the group list at startup when the config has a
+	// single workflow, whose runs are opened otherwise
+	NoAutoOpen bool
+	// LocalRepository is the repository of the current directory's git
+	// remote. When it differs from the one viewed, the status bar warns
+	// about it and ctrl+l offers to view it instead.
+	LocalRepository string
 }
 
 // MenuOptions is deprecated, use AppOptions instead
@@ -97,9 +240,25 @@ func NewApp(cfg *config.Config, configPath string, gh *github.Client, opts AppOp
 		statePath = state.DefaultStatePath(configPath)
 	}
 
+	pinConfigPath := opts.PinConfigPath
+	if pinConfigPath == "" {
+		pinConfigPath = configPath
+	}
+	configPaths := opts.ConfigPaths
+	if len(configPaths) == 0 {
+		configPaths = []string{configPath}
+	}
+	pinTiers := slices.DeleteFunc(slices.Clone(opts.PersonalConfigPaths), func(path string) bool {
+		return path == pinConfigPath
+	})
+	pinTiers = append(pinTiers, pinConfigPath)
+
 	app := &App{
 		config:             cfg,
 		configPath:         configPath,
+		configPaths:        configPaths,
+		pinConfigPath:      pinConfigPath,
+		pinTiers:           pinTiers,
 		statePath:          statePath,
 		gh:                 gh,
 		theme:              t,
@@ -107,23 +266,68 @@ func NewApp(cfg *config.Config, configPath string, gh *github.Client, opts AppOp
 		navList:            components.NewList(t, "📁 Groups"),
 		runsTable:          components.NewRunsTablePtr(t),
 		search:             components.NewSearch(t),
+		groupJump:          components.NewSearch(t),
 		cmdPalette:         components.NewCmdPalette(t),
 		helpOverlay:        components.NewHelpOverlay(t),
+		dispatchForm:       components.NewDispatchForm(t),
+		confirm:            components.NewConfirm(t),
+		annotations:        components.NewAnnotations(t),
+		sourceView:         components.NewSourceView(t),
+		runView:            components.NewRunView(t),
+		branchPicker:       components.NewBranchPicker(t),
 		toaster:            components.NewToaster(t),
 		spinner:            components.NewSpinner(t),
 		statusBar:          components.NewStatusBar(t),
 		helpBar:            components.NewHelpBar(t),
 		groupPath:          []*config.Group{},
+		latestRuns:         make(map[string]*models.GHRun),
+		healthErrors:       make(map[string]error),
+		navItems:           make(map[navItemsKey][]components.ListItem),
+		dispatchInputs:     loadDispatchInputs(statePath),
+		dispatchRefs:       loadDispatchRefs(statePath),
+		openCounts:         loadOpenCounts(statePath),
+		showFilenames:      loadShowFilenames(statePath),
+		filters:            loadFilters(statePath, cfg.Repository),
 		viewMode:           ViewGroups,
 		focusArea:          FocusMain,
 		showSidebar:        true,
 		refreshInterval:    opts.RefreshInterval,
+		clock:              realClock{},
+		rerunPoll:          rerunPollInterval,
+		newRunPoll:         newRunPollInterval,
+		newRunWait:         newRunWait,
 		autoRefreshEnabled: opts.RefreshInterval > 0,
+		since:              opts.Since,
+		classicLayout:      opts.Layout == config.LayoutClassic,
+		details:            components.NewDetails(t),
+		detailsRuns:        make(map[string][]models.GHRun),
+		runAnnotations:     make(map[int][]models.GHAnnotation),
+		workflowSources:    make(map[string]string),
+		workflowInfo:       make(map[string]*models.WorkflowInfo),
+		recordPath:         opts.RecordPath,
+		localRepository:    opts.LocalRepository,
+		lastInteraction:    time.Now(),
+	}
+
+	if opts.RecordPath != "" {
+		app.session = NewSession()
 	}
 
 	app.search.SetSearchFunc(func(query string) []components.SearchResult {
 		return app.performGlobalSearch(query)
 	})
+	app.groupJump.SetSearchFunc(func(query string) []components.SearchResult {
+		return app.groupSearchIndex().SearchPaths(query)
+	})
+	app.groupJump.SetLabels("Jump to Group", "Type a group name or path...", "Start typing to find a group by name or path")
+
+	app.runTags, app.tagsPath = loadRunTags(cfg.Repository)
+	app.runsTable.SetRunTags(app.runTags)
+
+	app.navList.SetFilterPredicates(app.healthFilterPredicates())
+	app.navList.SetAltTitles(app.showFilenames)
+	app.sidebar.SetAltTitles(app.showFilenames)
+	app.applyPreferences()
 
 	app.setupCommands()
 	app.refreshNavList()
@@ -138,16 +342,68 @@ func NewApp(cfg *config.Config, configPath string, gh *github.Client, opts AppOp
 		app.focusArea = FocusSidebar
 	}
 
+	if pins := cfg.GetAllPinnedWorkflows(); opts.OpenPinned > 0 && opts.OpenPinned <= len(pins) {
+		// The runs are fetched by Init
+		pin := pins[opts.OpenPinned-1]
+		app.selectWorkflow(pin.WorkflowName, pin.Group)
+	}
+	if opts.OpenWorkflow != "" {
+		app.selectWorkflow(opts.OpenWorkflow, nil)
+	}
+	if !opts.NoAutoOpen && app.selectedWorkflow == "" && app.focusArea != FocusSidebar {
+		// A single workflow leaves nothing to navigate to
+		if files, _ := cfg.WorkflowFiles(); len(files) == 1 {
+			app.enterGroupPath(cfg.FindWorkflowGroupPath(files[0]))
+			app.selectWorkflow(files[0], nil)
+		}
+	}
+
 	app.updateFocus()
 
 	return app
 }
 
 func (a *App) Init() tea.Cmd {
-	return nil
+	cmds := []tea.Cmd{a.syncDetails(), a.idleTickCmd()}
+	if a.selectedWorkflow != "" {
+		cmds = append(cmds, a.showWorkflowInfo(a.selectedWorkflow))
+		if a.loading {
+			cmds = append(cmds, a.spinner.Start("Loading runs..."), a.fetchWorkflowRuns())
+		}
+	}
+	if a.since > 0 {
+		cmds = append(cmds, a.spinner.Start("Checking recent activity..."), a.fetchActiveRunsCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
+// Update logs input and new errors for --debug, then handles msg
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// Typed characters may be form or prompt text, which --debug logs
+		// end up in bug reports, so only named keys are logged
+		if msg.Type != tea.KeyRunes {
+			slog.Debug("key", "key", msg.String())
+		}
+		a.touch()
+	case tea.WindowSizeMsg:
+		slog.Debug("resize", "width", msg.Width, "height", msg.Height)
+	}
+
+	if a.session != nil {
+		a.session.record(msg)
+	}
+
+	prevErr := a.err
+	model, cmd := a.update(msg)
+	if a.err != nil && a.err != prevErr {
+		slog.Error("tui error", "err", a.err)
+	}
+	return model, cmd
+}
+
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -155,9 +411,19 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a.handleResize(msg)
 
 	case tea.KeyMsg:
-		return a.handleKey(msg)
+		model, cmd := a.handleKey(msg)
+		return model, tea.Batch(cmd, a.syncDetails())
 
 	case workflowRunsMsg:
+		// A fetch aborted by leaving its workflow, or by a newer fetch
+		if errors.Is(msg.err, context.Canceled) {
+			return a, nil
+		}
+		// Runs of a workflow that is no longer shown, which arrived after
+		// fast navigation outran cancelling their fetch
+		if a.viewMode != ViewRuns || msg.workflow != a.selectedWorkflow {
+			return a, nil
+		}
 		a.loading = false
 		a.spinner.Stop()
 		if msg.err != nil {
@@ -167,18 +433,82 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			a.workflowRuns = msg.runs
 			a.runsTable.SetRuns(msg.runs, a.selectedWorkflow)
+			if a.pendingRunID != 0 {
+				a.runsTable.SelectRun(a.pendingRunID)
+				a.pendingRunID = 0
+			}
+			a.cacheLatestRun(a.selectedWorkflow, msg.runs)
+			a.detailsRuns[a.selectedWorkflow] = msg.runs
 		}
 		a.runsTable.SetLoading(false)
 		cmds = append(cmds, a.getRefreshTickerCmd())
 		return a, tea.Batch(cmds...)
 
 	case refreshTickMsg:
-		if a.selectedWorkflow != "" && !a.loading {
-			a.loading = true
-			a.runsTable.SetLoading(true)
-			return a, tea.Batch(a.fetchWorkflowRunsCmd, a.getRefreshTickerCmd())
-		}
-		return a, a.getRefreshTickerCmd()
+		return a.handleRefreshTick(msg)
+
+	case idleTickMsg:
+		return a.handleIdleTick(msg)
+
+	case latestRunsMsg:
+		return a.handleLatestRuns(msg)
+
+	case latestRunMsg:
+		return a.handleLatestRun(msg)
+
+	case repoWorkflowsMsg:
+		return a.handleRepoWorkflows(msg)
+
+	case rerunStartedMsg:
+		return a.handleRerunStarted(msg)
+
+	case rerunPollMsg:
+		return a.pollFollowedRun(msg)
+
+	case followedRunMsg:
+		return a.handleFollowedRun(msg)
+
+	case newRunMsg:
+		return a.handleNewRun(msg)
+
+	case offerExpiredMsg:
+		return a.handleOfferExpired(msg)
+
+	case activeRunsMsg:
+		return a.handleActiveRuns(msg)
+
+	case detailsDebounceMsg:
+		return a.handleDetailsDebounce(msg)
+
+	case detailsRunsMsg:
+		return a.handleDetailsRuns(msg)
+
+	case annotationsMsg:
+		return a.handleAnnotations(msg)
+
+	case workflowSourceMsg:
+		return a.handleWorkflowSource(msg)
+
+	case runViewMsg:
+		return a.handleRunView(msg)
+
+	case workflowInfoMsg:
+		return a.handleWorkflowInfo(msg)
+
+	case runLogMsg:
+		return a.handleRunLog(msg)
+
+	case actionResultMsg:
+		return a.handleActionResult(msg)
+
+	case branchesMsg:
+		return a.handleBranches(msg)
+
+	case dispatchInputsMsg:
+		return a.handleDispatchInputs(msg)
+
+	case dispatchResultMsg:
+		return a.handleDispatchResult(msg)
 
 	case components.ToastExpiredMsg:
 		a.toaster.Update(msg)
@@ -201,6 +531,10 @@ func (a *App) View() string {
 		return ""
 	}
 
+	if computeLayout(a.width, a.height, a.showSidebar).tooSmall {
+		return a.renderTooSmall()
+	}
+
 	if a.helpOverlay.IsActive() {
 		return a.helpOverlay.View()
 	}
@@ -209,10 +543,38 @@ func (a *App) View() string {
 		return a.cmdPalette.View()
 	}
 
+	if a.dispatchForm.IsActive() {
+		return a.dispatchForm.View()
+	}
+
+	if a.confirm.IsActive() {
+		return a.confirm.View()
+	}
+
+	if a.annotations.IsActive() {
+		return a.annotations.View()
+	}
+
+	if a.sourceView.IsActive() {
+		return a.sourceView.View()
+	}
+
+	if a.runView.IsActive() {
+		return a.runView.View()
+	}
+
+	if a.branchPicker.IsActive() {
+		return a.branchPicker.View()
+	}
+
 	if a.search.IsActive() {
 		return a.search.View()
 	}
 
+	if a.groupJump.IsActive() {
+		return a.groupJump.View()
+	}
+
 	return a.renderLayout()
 }
 
@@ -220,21 +582,21 @@ func (a *App) handleResize(msg tea.WindowSizeMsg) (*App, tea.Cmd) {
 	a.width = msg.Width
 	a.height = msg.Height
 
-	barHeight := 2
-	panelHeight := a.height - barHeight - 2
+	l := computeLayout(a.width, a.height, a.showSidebar)
 
-	sidebarWidth := 0
-	if a.showSidebar {
-		sidebarWidth = max(25, a.width/5)
-	}
-	mainWidth := a.width - sidebarWidth - 2
-
-	a.sidebar.SetSize(sidebarWidth-2, panelHeight)
-	a.navList.SetSize(mainWidth-2, panelHeight)
-	a.runsTable.SetSize(mainWidth-2, panelHeight)
+	a.sidebar.SetSize(inner(l.sidebarWidth), l.panelHeight)
+	a.navList.SetSize(inner(l.mainWidth), l.panelHeight)
+	a.runsTable.SetSize(inner(l.mainWidth), l.panelHeight)
 	a.search.SetSize(a.width, a.height)
+	a.groupJump.SetSize(a.width, a.height)
 	a.cmdPalette.SetSize(a.width, a.height)
 	a.helpOverlay.SetSize(a.width, a.height)
+	a.dispatchForm.SetSize(a.width, a.height)
+	a.confirm.SetSize(a.width, a.height)
+	a.annotations.SetSize(a.width, a.height)
+	a.sourceView.SetSize(a.width, a.height)
+	a.runView.SetSize(a.width, a.height)
+	a.branchPicker.SetSize(a.width, a.height)
 	a.toaster.SetWidth(a.width)
 	a.statusBar.SetSize(a.width)
 	a.helpBar.SetSize(a.width)
@@ -250,10 +612,17 @@ func (a *App) selectNavItem(item *components.ListItem) (*App, tea.Cmd) {
 
 	if navItem.isGroup {
 		if navItem.group != nil {
-			a.groupPath = append(a.groupPath, navItem.group)
-			a.navList.ClearFilter()
-			a.refreshNavList()
+			// A nested favorite listed at the root opens at its full path
+			path := append(a.groupPath, navItem.group)
+			if fullPath := a.config.FindGroupPath(navItem.group); len(a.groupPath) == 0 && fullPath != nil {
+				path = fullPath
+			}
+			a.enterGroupPath(path)
 			a.saveState()
+			// Going back from the default workflow's runs lists the group
+			if wf := navItem.group.DefaultWorkflow; wf != "" {
+				return a.selectWorkflow(wf, nil)
+			}
 		}
 		return a, nil
 	}
@@ -269,6 +638,7 @@ func (a *App) selectWorkflowFromSidebar(item *components.PinnedItem) (*App, tea.
 func (a *App) selectWorkflow(name string, group *config.Group) (*App, tea.Cmd) {
 	a.selectedWorkflow = name
 	a.selectedGroup = group
+	a.openCounts[name]++
 	a.loading = true
 	a.viewMode = ViewRuns
 	a.runsTable.SetVisible(true)
@@ -278,15 +648,32 @@ func (a *App) selectWorkflow(name string, group *config.Group) (*App, tea.Cmd) {
 	a.startRefreshTicker()
 	a.updateStatusBar()
 	a.saveState()
-	return a, tea.Batch(a.spinner.Start("Loading runs..."), a.fetchWorkflowRunsCmd)
+	return a, tea.Batch(a.spinner.Start("Loading runs..."), a.fetchWorkflowRuns(), a.showWorkflowInfo(name))
+}
+
+// leaveRunsView goes back from a workflow's runs to the groups, abandoning
+// the runs fetch in flight
+func (a *App) leaveRunsView() {
+	a.viewMode = ViewGroups
+	a.selectedWorkflow = ""
+	a.selectedGroup = nil
+	a.stopRefreshTicker()
+	a.cancelRunsFetch()
+	a.updateFocus()
+	a.updateStatusBar()
 }
 
 func RunApp(app *App) error {
 	p := tea.NewProgram(app, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		return err
+	_, runErr := p.Run()
+	// Covers exits that bypass quit, such as SIGTERM
+	app.shutdown()
+	if app.session != nil {
+		if err := app.session.Save(app.recordPath); err != nil {
+			return err
+		}
 	}
-	return nil
+	return runErr
 }
 
 func NewAppFromMenuOptions(cfg *config.Config, configPath string, gh *github.Client, opts MenuOptions) *App {
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+// actionResultMsg reports the outcome of a gh call started with runAction
+type actionResultMsg struct {
+	success string // toast shown on success, if any
+	failure string // toast shown on failure
+	err     error
+}
+
+// runAction runs fn off the event loop while the spinner shows label, then
+// toasts the outcome. gh calls that change something or launch another
+// program go through here so the UI never freezes on a synchronous exec.
+func (a *App) runAction(label, success, failure string, fn func() error) tea.Cmd {
+	return tea.Batch(
+		a.spinner.Start(label),
+		func() tea.Msg {
+			return actionResultMsg{success: success, failure: failure, err: fn()}
+		},
+	)
+}
+
+func (a *App) handleActionResult(msg actionResultMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	if msg.err != nil {
+		a.err = msg.err
+		return a, a.toaster.Error(msg.failure)
+	}
+	if msg.success != "" {
+		return a, a.toaster.Success(msg.success)
+	}
+	return a, nil
+}
+
+func (a *App) openWorkflowInBrowser(workflowName string) tea.Cmd {
+	return a.runAction("Opening "+workflowName+"...", "Opened in browser", "Failed to open browser", func() error {
+		return a.gh.OpenWorkflowInBrowser(workflowName)
+	})
+}
+
+func (a *App) openRunInBrowser(runID int) tea.Cmd {
+	return a.runAction(fmt.Sprintf("Opening run #%d...", runID), "Opened run in browser", "Failed to open browser", func() error {
+		return a.gh.OpenRunInBrowser(runID)
+	})
+}
+
+// openActionsInBrowser opens the repository's Actions tab for group, the
+// nearest page to one listing only its workflows
+func (a *App) openActionsInBrowser(group *config.Group) tea.Cmd {
+	return a.runAction("Opening Actions for "+group.Name+"...", "Opened Actions in browser", "Failed to open browser", func() error {
+		return a.gh.OpenActionsInBrowser()
+	})
+}
+
+// openNavItemInBrowser opens the selected workflow's page, or the Actions
+// tab for a selected group
+func (a *App) openNavItemInBrowser() tea.Cmd {
+	item := a.navList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	navItem, ok := item.Data.(*navItemData)
+	switch {
+	case !ok:
+		return nil
+	case navItem.isGroup:
+		return a.openActionsInBrowser(navItem.group)
+	}
+	return a.openWorkflowInBrowser(navItem.workflowName)
+}
+
+// revealConfigDir opens the user config directory in the file manager, or
+// shows its path where there is none
+func (a *App) revealConfigDir() tea.Cmd {
+	p, err := paths.New()
+	if err != nil {
+		a.err = err
+		return a.toaster.Error("Failed to find the config directory")
+	}
+	dir := p.UserConfigDir
+
+	return func() tea.Msg {
+		err := paths.Reveal(dir)
+		if errors.Is(err, paths.ErrNoFileManager) {
+			return actionResultMsg{success: "Config directory: " + dir}
+		}
+		return actionResultMsg{success: "Opened " + dir, failure: "Failed to open the file manager", err: err}
+	}
+}
+
+// copyToClipboard writes text to the system clipboard and toasts the result.
+// Clipboard tools may shell out, so this runs as a command too.
+func (a *App) copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		return actionResultMsg{
+			success: "Copied " + text,
+			failure: "Failed to copy to clipboard",
+			err:     clipboard.WriteAll(text),
+		}
+	}
+}
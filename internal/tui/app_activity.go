@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// activityRunLimit is how many recent repository runs are scanned to find
+// workflows that were active within the --since window
+const activityRunLimit = 100
+
+type activeRunsMsg struct {
+	runs []models.GHRun
+	err  error
+}
+
+// fetchActiveRunsCmd lists the repository's recent runs in a single call, so
+// only workflows with activity are decorated instead of querying each one
+func (a *App) fetchActiveRunsCmd() tea.Cmd {
+	gh := a.gh
+	return func() tea.Msg {
+		runs, err := gh.GetRecentRuns(activityRunLimit)
+		return activeRunsMsg{runs: runs, err: err}
+	}
+}
+
+// handleActiveRuns records the newest run of each configured workflow that
+// ran within the --since window. Dormant workflows are left without a cached
+// run and therefore render without a status badge.
+func (a *App) handleActiveRuns(msg activeRunsMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	if msg.err != nil {
+		a.err = msg.err
+		return a, a.toaster.Error("Failed to load recent activity")
+	}
+
+	files, names := a.config.WorkflowFiles()
+	for wf, run := range models.LatestRuns(files, names, msg.runs, time.Now().Add(-a.since)) {
+		a.latestRuns[wf] = run
+	}
+
+	a.invalidateNavItems()
+	a.refreshNavList()
+	return a, nil
+}
+
+// workflowBadge returns the status icon of a workflow's cached latest run, or
+// "" when nothing is known about it
+func (a *App) workflowBadge(workflow string) string {
+	run, ok := a.latestRuns[workflow]
+	if !ok || run == nil {
+		return ""
+	}
+	icon, _ := a.theme.StatusIcon(run.Status, run.Conclusion)
+	return icon
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+type annotationsMsg struct {
+	runID       int
+	annotations []models.GHAnnotation
+	err         error
+}
+
+// showAnnotations opens the selected run's annotations, fetching them the
+// first time. Annotations take an API call per job, so they are only fetched
+// for runs the user asks about.
+func (a *App) showAnnotations() (tea.Model, tea.Cmd) {
+	runID := a.runsTable.SelectedRunID()
+	if runID == 0 {
+		return a, nil
+	}
+
+	a.annotations.Open(runID)
+	if annotations, ok := a.runAnnotations[runID]; ok {
+		a.annotations.SetAnnotations(runID, annotations, nil)
+		return a, nil
+	}
+	return a, a.fetchAnnotationsCmd(runID)
+}
+
+func (a *App) fetchAnnotationsCmd(runID int) tea.Cmd {
+	return func() tea.Msg {
+		annotations, err := a.gh.GetRunAnnotations(runID)
+		return annotationsMsg{runID: runID, annotations: annotations, err: err}
+	}
+}
+
+// handleAnnotations caches fetched annotations and shows their counts in the
+// runs table. When they cannot be fetched, for example without checks
+// access, the overlay says so and the table is left as it was.
+func (a *App) handleAnnotations(msg annotationsMsg) (tea.Model, tea.Cmd) {
+	a.annotations.SetAnnotations(msg.runID, msg.annotations, msg.err)
+	if msg.err != nil {
+		a.err = msg.err
+		return a, nil
+	}
+	a.runAnnotations[msg.runID] = msg.annotations
+	a.runsTable.SetAnnotationSummary(msg.runID, models.SummarizeAnnotations(msg.annotations))
+	return a, nil
+}
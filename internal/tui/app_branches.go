@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"log/slog"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type branchesMsg struct {
+	branches []string
+	err      error
+}
+
+// pickBranch opens the branch picker with selected highlighted and runs
+// onBranch with the picked ref. The branches are fetched the first time and
+// then reused for the session.
+func (a *App) pickBranch(title, selected string, onBranch func(ref string) (tea.Model, tea.Cmd)) tea.Cmd {
+	a.branchPicker.Open(title, selected)
+	a.onBranch = onBranch
+	if a.branches != nil {
+		a.branchPicker.SetBranches(a.branches, nil)
+		return nil
+	}
+	return a.fetchBranchesCmd()
+}
+
+// fetchBranchesCmd lists the repository's branches with the default branch
+// first. Without a default branch the branches keep GitHub's order.
+func (a *App) fetchBranchesCmd() tea.Cmd {
+	gh := a.gh
+	return func() tea.Msg {
+		branches, err := gh.ListBranches()
+		if err != nil {
+			return branchesMsg{err: err}
+		}
+		defaultBranch, err := gh.GetDefaultBranch()
+		if err != nil {
+			slog.Debug("default branch unavailable", "err", err)
+			return branchesMsg{branches: branches}
+		}
+		branches = slices.DeleteFunc(branches, func(b string) bool {
+			return b == defaultBranch
+		})
+		return branchesMsg{branches: append([]string{defaultBranch}, branches...)}
+	}
+}
+
+func (a *App) handleBranches(msg branchesMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil {
+		a.branches = msg.branches
+		if a.branches == nil {
+			a.branches = []string{}
+		}
+	}
+	a.branchPicker.SetBranches(msg.branches, msg.err)
+	return a, nil
+}
@@ -4,19 +4,42 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
 	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
 )
 
 func (a *App) setupCommands() {
 	cmds := []components.Command{
 		{Name: "quit", Aliases: []string{"q", "exit"}, Description: "Exit the application"},
-		{Name: "refresh", Aliases: []string{"r"}, Description: "Refresh current view"},
+		{Name: "refresh", Aliases: []string{"r"}, Description: "Refresh current view", Repeatable: true},
 		{Name: "search", Aliases: []string{"s", "find"}, Description: "Open global search"},
+		{Name: "jump-group", Aliases: []string{"jump", "goto"}, Description: "Jump to a group by name or path"},
 		{Name: "help", Aliases: []string{"h", "?"}, Description: "Show help"},
-		{Name: "pin", Aliases: []string{"p"}, Description: "Pin/unpin selected workflow"},
-		{Name: "open", Aliases: []string{"o", "web", "browser"}, Description: "Open in browser"},
-		{Name: "sidebar", Aliases: []string{"1"}, Description: "Toggle sidebar"},
-		{Name: "back", Aliases: []string{"b"}, Description: "Go back"},
+		{Name: "legend", Aliases: []string{"K", "keys"}, Description: "Toggle the key legend", Repeatable: true},
+		{Name: "pin", Aliases: []string{"p"}, Description: "Pin/unpin selected workflow", Repeatable: true},
+		{Name: "favorite", Aliases: []string{"f", "star"}, Description: "Star/unstar selected group", Repeatable: true},
+		{Name: "hide", Aliases: []string{"H", "unhide"}, Description: "Hide/unhide selected workflow", Repeatable: true},
+		{Name: "filenames", Aliases: []string{"t", "names"}, Description: "Toggle workflow names and filenames", Repeatable: true},
+		{Name: "descriptions", Aliases: []string{"D", "desc"}, Description: "Show or hide workflow descriptions", Repeatable: true},
+		{Name: "show-hidden", Aliases: []string{"."}, Description: "Show or hide hidden workflows", Repeatable: true},
+		{Name: "source", Aliases: []string{"V", "yaml"}, Description: "View the selected workflow's YAML"},
+		{Name: "latest", Aliases: []string{"i", "last-run"}, Description: "Show the selected workflow's latest run", Repeatable: true},
+		{Name: "open", Aliases: []string{"o", "web", "browser"}, Description: "Open in browser", Repeatable: true},
+		{Name: "sidebar", Aliases: []string{"1"}, Description: "Toggle sidebar", Repeatable: true},
+		{Name: "back", Aliases: []string{"b"}, Description: "Go back", Repeatable: true},
+		{Name: "health", Aliases: []string{"v", "status"}, Description: "Toggle grouping by workflow health", Repeatable: true},
+		{Name: "dispatch", Aliases: []string{"x", "run", "trigger"}, Description: "Dispatch selected workflow"},
+		{Name: "redispatch", Aliases: []string{"X", "rerun-last"}, Description: "Dispatch selected workflow with its last inputs"},
+		{Name: "run-log", Aliases: []string{"log", "view-log"}, Description: "View the selected run's log"},
+		{Name: "run-jobs", Aliases: []string{"J", "jobs"}, Description: "Show the selected run's jobs"},
+		{Name: "tag", Aliases: []string{"T", "label"}, Description: "Tag or untag the selected run"},
+		{Name: "tag-filter", Aliases: []string{"#", "tagged"}, Description: "Show only runs with a tag, or all runs again"},
+		{Name: "rerun-failed", Aliases: []string{"F", "rerun"}, Description: "Re-run the selected run's failed jobs and follow it"},
+		{Name: "clear-pins", Aliases: []string{"unpin-all"}, Description: "Unpin every workflow you pinned"},
+		{Name: "switch-local", Aliases: []string{"local"}, Description: "View the current directory's repository instead"},
+		{Name: "reload", Aliases: []string{"R", "reload-config"}, Description: "Reload the config files", Repeatable: true},
+		{Name: "reveal-config", Aliases: []string{"config"}, Description: "Open the config directory in the file manager", Repeatable: true},
+		{Name: "copy-alias", Aliases: []string{"alias", "gh-alias"}, Description: "Copy a gh alias that opens rivet on the selected workflow", Repeatable: true},
 	}
 	a.cmdPalette.SetCommands(cmds)
 }
@@ -26,27 +49,58 @@ func (a *App) executeCommand(cmd *components.Command) (tea.Model, tea.Cmd) {
 
 	switch cmd.Name {
 	case "quit":
-		a.stopRefreshTicker()
-		a.saveState()
-		return a, tea.Quit
+		return a.quit()
 
 	case "refresh":
 		if a.selectedWorkflow != "" && !a.loading {
 			a.loading = true
 			a.runsTable.SetLoading(true)
 			cmds = append(cmds, a.spinner.Start("Refreshing..."))
-			cmds = append(cmds, a.fetchWorkflowRunsCmd)
+			cmds = append(cmds, a.fetchWorkflowRuns())
 		}
 
 	case "search":
 		a.search.Open()
 
+	case "jump-group":
+		a.groupJump.Open()
+
 	case "help":
+		a.updateHelpBar()
 		a.helpOverlay.Toggle()
 
+	case "switch-local":
+		return a.confirmSwitchToLocal()
+
+	case "source":
+		return a.showWorkflowSource()
+
+	case "legend":
+		a.updateHelpBar()
+		a.helpBar.ToggleExpanded()
+
 	case "pin":
 		return a.handlePinAction()
 
+	case "favorite":
+		if a.viewMode == ViewGroups && a.focusArea == FocusMain {
+			return a.handleFavoriteInGroups()
+		}
+
+	case "hide":
+		if a.viewMode == ViewGroups && a.focusArea == FocusMain {
+			return a.handleHideInGroups()
+		}
+
+	case "show-hidden":
+		return a.toggleShowHidden()
+
+	case "filenames":
+		return a.toggleFilenames()
+
+	case "descriptions":
+		return a.toggleDescriptions()
+
 	case "open":
 		return a.handleOpenAction()
 
@@ -58,17 +112,62 @@ func (a *App) executeCommand(cmd *components.Command) (tea.Model, tea.Cmd) {
 		a.updateFocus()
 		return a.handleResize(tea.WindowSizeMsg{Width: a.width, Height: a.height})
 
+	case "dispatch":
+		return a.startDispatch(false)
+
+	case "redispatch":
+		return a.startDispatch(true)
+
+	case "clear-pins":
+		return a.confirmClearPins()
+
+	case "reload":
+		return a.reloadConfig()
+
+	case "rerun-failed":
+		if a.viewMode == ViewRuns {
+			return a.confirmRerunAndWatch()
+		}
+		return a, a.toaster.Info("Open a workflow's runs to re-run one")
+
+	case "run-log", "run-jobs":
+		if a.viewMode != ViewRuns {
+			return a, a.toaster.Info("Open a workflow's runs to view one")
+		}
+		if cmd.Name == "run-log" {
+			return a.openRun(config.OpenLogs)
+		}
+		return a.openRun(config.OpenJobs)
+
+	case "tag", "tag-filter":
+		if a.viewMode != ViewRuns {
+			return a, a.toaster.Info("Open a workflow's runs to tag them")
+		}
+		if cmd.Name == "tag" {
+			return a.tagRun()
+		}
+		return a.filterRunsByTag()
+
+	case "latest":
+		return a.peekLatestRun()
+
+	case "reveal-config":
+		return a, a.revealConfigDir()
+
+	case "copy-alias":
+		return a, a.copyToClipboard(github.AliasCommand("", a.config.Repository, a.currentWorkflow()))
+
+	case "health":
+		if a.viewMode == ViewRuns {
+			a.leaveRunsView()
+		}
+		return a.toggleHealthView()
+
 	case "back":
 		if a.viewMode == ViewRuns {
-			a.viewMode = ViewGroups
-			a.selectedWorkflow = ""
-			a.selectedGroup = nil
-			a.stopRefreshTicker()
-			a.updateFocus()
-			a.updateStatusBar()
+			a.leaveRunsView()
 		} else if len(a.groupPath) > 0 {
-			a.groupPath = a.groupPath[:len(a.groupPath)-1]
-			a.refreshNavList()
+			a.enterGroupPath(a.groupPath[:len(a.groupPath)-1])
 			a.saveState()
 		}
 	}
@@ -83,59 +182,26 @@ func (a *App) handlePinAction() (tea.Model, tea.Cmd) {
 	if a.focusArea == FocusSidebar {
 		if item := a.sidebar.SelectedItem(); item != nil {
 			if group, ok := item.Data.(*config.Group); ok {
-				group.TogglePin(item.WorkflowName)
-				if err := a.config.Save(a.configPath); err != nil {
-					return a, a.toaster.Error("Failed to save")
-				}
-				a.refreshPinnedList()
-				a.refreshNavList()
-				a.saveState()
-				return a, a.toaster.Success("Unpinned workflow")
-			}
-		}
-	} else if a.viewMode == ViewGroups && len(a.groupPath) > 0 {
-		if item := a.navList.SelectedItem(); item != nil {
-			if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
-				currentGroup := a.groupPath[len(a.groupPath)-1]
-				wasPinned := currentGroup.IsPinned(navItem.workflowName)
-				currentGroup.TogglePin(navItem.workflowName)
-				if err := a.config.Save(a.configPath); err != nil {
-					return a, a.toaster.Error("Failed to save")
-				}
-				a.refreshNavList()
-				a.refreshPinnedList()
-				a.saveState()
-				if wasPinned {
-					return a, a.toaster.Success("Unpinned workflow")
-				}
-				return a, a.toaster.Success("Pinned workflow")
+				return a.togglePin(a.config.FindGroupPath(group), item.WorkflowName)
 			}
 		}
+	} else if a.viewMode == ViewGroups && !a.healthView {
+		return a.handlePinInGroups()
 	}
 	return a, nil
 }
 
 func (a *App) handleOpenAction() (tea.Model, tea.Cmd) {
-	var err error
 	if a.focusArea == FocusSidebar {
 		if item := a.sidebar.SelectedItem(); item != nil {
-			err = a.gh.OpenWorkflowInBrowser(item.WorkflowName)
+			return a, a.openWorkflowInBrowser(item.WorkflowName)
 		}
 	} else if a.viewMode == ViewGroups {
-		if item := a.navList.SelectedItem(); item != nil {
-			if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
-				err = a.gh.OpenWorkflowInBrowser(navItem.workflowName)
-			}
-		}
+		return a, a.openNavItemInBrowser()
 	} else if a.viewMode == ViewRuns {
-		runID := a.runsTable.SelectedRunID()
-		if runID > 0 {
-			err = a.gh.OpenRunInBrowser(runID)
+		if runID := a.runsTable.SelectedRunID(); runID > 0 {
+			return a, a.openRunInBrowser(runID)
 		}
 	}
-
-	if err != nil {
-		return a, a.toaster.Error("Failed to open browser")
-	}
-	return a, a.toaster.Info("Opening in browser...")
+	return a, nil
 }
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// detailsDebounce delays fetching runs for the details panel so scrolling
+// through the list does not start a gh call per row
+const detailsDebounce = 300 * time.Millisecond
+
+// detailsRunLimit is how many runs the details panel previews
+const detailsRunLimit = 5
+
+// minDetailsWidth is the narrowest details panel worth showing
+const minDetailsWidth = 24
+
+type detailsDebounceMsg struct {
+	workflow string
+}
+
+type detailsRunsMsg struct {
+	workflow string
+	runs     []models.GHRun
+	err      error
+}
+
+// highlightedWorkflow returns the workflow under the cursor in the group list
+func (a *App) highlightedWorkflow() (string, string) {
+	item := a.navList.SelectedItem()
+	if item == nil {
+		return "", ""
+	}
+	navItem, ok := item.Data.(*navItemData)
+	if !ok || navItem.isGroup {
+		return "", ""
+	}
+	return navItem.workflowName, item.Title
+}
+
+// syncDetails points the classic layout's details panel at the highlighted
+// workflow, using cached runs when available and otherwise scheduling a
+// debounced fetch
+func (a *App) syncDetails() tea.Cmd {
+	if !a.classicLayout || a.viewMode != ViewGroups {
+		// Re-sync on return so runs loaded meanwhile are shown
+		a.detailsWorkflow = ""
+		return nil
+	}
+
+	workflow, title := a.highlightedWorkflow()
+	if workflow == a.detailsWorkflow {
+		return nil
+	}
+	a.detailsWorkflow = workflow
+
+	a.details.Clear()
+	if workflow == "" {
+		return nil
+	}
+	a.details.SetWorkflow(title)
+
+	if runs, ok := a.detailsRuns[workflow]; ok {
+		a.details.SetRuns(runs)
+		return nil
+	}
+
+	a.details.SetLoading(true)
+	return tea.Tick(detailsDebounce, func(time.Time) tea.Msg {
+		return detailsDebounceMsg{workflow: workflow}
+	})
+}
+
+func (a *App) handleDetailsDebounce(msg detailsDebounceMsg) (tea.Model, tea.Cmd) {
+	if msg.workflow != a.detailsWorkflow {
+		return a, nil
+	}
+	gh := a.gh
+	return a, func() tea.Msg {
+		runs, err := gh.GetWorkflowRuns(msg.workflow, detailsRunLimit)
+		return detailsRunsMsg{workflow: msg.workflow, runs: runs, err: err}
+	}
+}
+
+func (a *App) handleDetailsRuns(msg detailsRunsMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil {
+		a.detailsRuns[msg.workflow] = msg.runs
+		a.cacheLatestRun(msg.workflow, msg.runs)
+	}
+	if msg.workflow != a.detailsWorkflow {
+		return a, nil
+	}
+
+	a.details.SetLoading(false)
+	if msg.err != nil {
+		a.details.SetError(msg.err)
+	} else {
+		a.details.SetRuns(msg.runs)
+	}
+	return a, nil
+}
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+type dispatchInputsMsg struct {
+	workflow string
+	ref      string
+	inputs   []models.WorkflowInput
+	useLast  bool
+	err      error
+}
+
+type dispatchResultMsg struct {
+	workflow string
+	ref      string
+	inputs   map[string]string
+	// The newest run before the dispatch, -1 when it is unknown
+	afterID int
+	err     error
+}
+
+// currentWorkflow returns the workflow the user is looking at: the one whose
+// runs are shown, or the highlighted workflow in the sidebar or group list
+func (a *App) currentWorkflow() string {
+	if a.focusArea == FocusSidebar {
+		if item := a.sidebar.SelectedItem(); item != nil {
+			return item.WorkflowName
+		}
+		return ""
+	}
+	if a.viewMode == ViewRuns {
+		return a.selectedWorkflow
+	}
+	if item := a.navList.SelectedItem(); item != nil {
+		if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
+			return navItem.workflowName
+		}
+	}
+	return ""
+}
+
+// canDispatch reports whether workflow may be dispatched. Until its trigger
+// info is cached this is unknown, so the dispatch is attempted.
+func (a *App) canDispatch(workflow string) bool {
+	info, ok := a.workflowInfo[workflow]
+	return !ok || info.CanDispatch()
+}
+
+// startDispatch asks for the ref to run the current workflow on, then
+// fetches its inputs and opens the dispatch form. With useLast, the previous
+// ref and inputs are offered for confirmation instead.
+func (a *App) startDispatch(useLast bool) (tea.Model, tea.Cmd) {
+	workflow := a.currentWorkflow()
+	if workflow == "" {
+		return a, a.toaster.Warning("Select a workflow to dispatch")
+	}
+	if !a.canDispatch(workflow) {
+		return a, a.toaster.Warning("Workflow has no workflow_dispatch trigger")
+	}
+
+	// An empty ref runs on the default branch
+	ref := a.dispatchRefs[workflow]
+	if useLast {
+		return a, a.loadDispatchInputs(workflow, ref, true)
+	}
+	return a, a.pickBranch("Dispatch "+workflow+" on", ref, func(ref string) (tea.Model, tea.Cmd) {
+		return a, a.loadDispatchInputs(workflow, ref, false)
+	})
+}
+
+func (a *App) loadDispatchInputs(workflow, ref string, useLast bool) tea.Cmd {
+	gh := a.gh
+	return tea.Batch(
+		a.spinner.Start("Loading workflow inputs..."),
+		func() tea.Msg {
+			inputs, err := gh.GetWorkflowInputs(workflow)
+			return dispatchInputsMsg{workflow: workflow, ref: ref, inputs: inputs, useLast: useLast, err: err}
+		},
+	)
+}
+
+func (a *App) handleDispatchInputs(msg dispatchInputsMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	if errors.Is(msg.err, github.ErrNoWorkflowDispatch) {
+		return a, a.toaster.Warning("Workflow has no workflow_dispatch trigger")
+	}
+	if msg.err != nil {
+		a.err = msg.err
+		return a, a.toaster.Error("Failed to load workflow inputs")
+	}
+
+	last, hasLast := a.dispatchInputs[msg.workflow]
+	if msg.useLast && hasLast && lastInputsMatch(last, msg.inputs) {
+		a.dispatchForm.Open(msg.workflow, msg.ref, a.branches, msg.inputs, last, true)
+		return a, nil
+	}
+
+	a.dispatchForm.Open(msg.workflow, msg.ref, a.branches, msg.inputs, last, false)
+	if msg.useLast && hasLast {
+		return a, a.toaster.Info("Workflow inputs changed since last dispatch")
+	}
+	if msg.useLast {
+		return a, a.toaster.Info("No previous inputs for this workflow")
+	}
+	return a, nil
+}
+
+// lastInputsMatch reports whether previously used inputs still fit the
+// workflow: every saved input must still exist and every required input
+// must have a saved value
+func lastInputsMatch(last map[string]string, inputs []models.WorkflowInput) bool {
+	known := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		known[input.Name] = true
+		if input.Required && last[input.Name] == "" {
+			return false
+		}
+	}
+	for name := range last {
+		if !known[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// submitDispatch checks that the ref exists, since gh would otherwise fail
+// with a less helpful error, and then dispatches the workflow
+func (a *App) submitDispatch(req *components.DispatchRequest) tea.Cmd {
+	gh := a.gh
+	return tea.Batch(
+		a.spinner.Start("Dispatching "+req.Workflow+"..."),
+		func() tea.Msg {
+			msg := dispatchResultMsg{workflow: req.Workflow, ref: req.Ref, inputs: req.Inputs}
+			if req.Ref != "" {
+				exists, err := gh.RefExists(req.Ref)
+				if err == nil && !exists {
+					err = fmt.Errorf("no branch, tag, or commit named %q", req.Ref)
+				}
+				if err != nil {
+					msg.err = err
+					return msg
+				}
+			}
+			msg.afterID = latestRunID(gh, req.Workflow)
+			msg.err = gh.DispatchWorkflow(req.Workflow, req.Ref, req.Inputs)
+			return msg
+		},
+	)
+}
+
+func (a *App) handleDispatchResult(msg dispatchResultMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	if msg.err != nil {
+		a.err = msg.err
+		return a, a.toaster.Error("Dispatch failed")
+	}
+
+	a.dispatchInputs[msg.workflow] = msg.inputs
+	if msg.ref != "" {
+		a.dispatchRefs[msg.workflow] = msg.ref
+	}
+	a.saveState()
+
+	text := fmt.Sprintf("Dispatched %s", msg.workflow)
+	if msg.ref != "" {
+		text += " on " + msg.ref
+	}
+	if msg.afterID < 0 {
+		return a, a.toaster.Success(text)
+	}
+	return a, tea.Batch(a.toaster.Success(text+", waiting for its run..."), a.findNewRunCmd(msg.workflow, msg.afterID))
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+)
+
+// repoWorkflowsMsg reports the workflows found in the repository when the
+// config has none
+type repoWorkflowsMsg struct {
+	files []string
+	err   error
+}
+
+// hasNoWorkflows reports whether the config lists no workflow at all, as
+// rivet init leaves it for a repository that has none yet
+func (a *App) hasNoWorkflows() bool {
+	var walk func(groups []config.Group) bool
+	walk = func(groups []config.Group) bool {
+		for i := range groups {
+			group := &groups[i]
+			if len(group.Workflows) > 0 || len(group.WorkflowDefs) > 0 || !walk(group.Groups) {
+				return false
+			}
+		}
+		return true
+	}
+	return walk(a.config.Groups)
+}
+
+// renderNoWorkflows explains what to do when the config has no workflows,
+// in place of a group list that would only show empty groups
+func (a *App) renderNoWorkflows(width, height int) string {
+	text := []string{
+		a.theme.Title.Render("No workflows yet"),
+		"",
+		a.theme.Text.Render(fmt.Sprintf("%s has no workflows in its config.", a.config.Repository)),
+		"",
+		a.theme.TextMuted.Render("Add a workflow under .github/workflows and push it,"),
+		a.theme.TextMuted.Render("then press ctrl+r to check the repository again."),
+		"",
+		a.theme.TextMuted.Render("Once it has workflows, quit and run `rivet init` to group"),
+		a.theme.TextMuted.Render("them, or add them to your config and run `rivet config enrich`"),
+		a.theme.TextMuted.Render("to fill in their names."),
+	}
+	content := lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(text, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// checkForWorkflows asks GitHub whether the repository has workflows yet
+func (a *App) checkForWorkflows() (tea.Model, tea.Cmd) {
+	gh, repo := a.gh, a.config.Repository
+	return a, tea.Batch(a.spinner.Start("Checking for workflows..."), func() tea.Msg {
+		files, err := gh.GetWorkflows(context.Background(), repo)
+		return repoWorkflowsMsg{files: files, err: err}
+	})
+}
+
+func (a *App) handleRepoWorkflows(msg repoWorkflowsMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	switch {
+	case msg.err != nil:
+		a.err = msg.err
+		return a, a.toaster.Error("Failed to check for workflows")
+	case len(msg.files) == 0:
+		return a, a.toaster.Info("Still no workflows in " + a.config.Repository)
+	case len(msg.files) == 1:
+		return a, a.toaster.Success("Found " + msg.files[0] + ": quit and run rivet init to add it")
+	}
+	return a, a.toaster.Success(fmt.Sprintf("Found %d workflows: quit and run rivet init to group them", len(msg.files)))
+}
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// healthFetchWorkers bounds concurrent gh calls when checking workflow health
+const healthFetchWorkers = 4
+
+type latestRunsMsg struct {
+	runs map[string]*models.GHRun
+	// errs holds the workflows whose latest run could not be fetched
+	errs map[string]error
+}
+
+// healthBucket describes one status bucket in the health view
+type healthBucket struct {
+	id   string
+	name string
+	desc string
+}
+
+var healthBuckets = []healthBucket{
+	{id: "health-failing", name: "Failing", desc: "Latest run did not succeed"},
+	{id: "health-running", name: "In progress", desc: "Latest run is queued or running"},
+	{id: "health-passing", name: "Passing", desc: "Latest run succeeded"},
+	{id: "health-never-run", name: "Never run", desc: "No runs found"},
+	{id: "health-unknown", name: "Unknown", desc: "Latest run could not be fetched"},
+}
+
+// healthBucketID returns the bucket a workflow belongs to based on its latest run
+func healthBucketID(run *models.GHRun) string {
+	switch {
+	case run == nil:
+		return "health-never-run"
+	case !run.IsTerminal():
+		return "health-running"
+	case run.IsSuccess():
+		return "health-passing"
+	default:
+		return "health-failing"
+	}
+}
+
+// healthFilterKeywords are the nav-list filter keywords that show workflows
+// by the health of their cached latest run
+var healthFilterKeywords = []struct {
+	keyword, bucket, desc string
+}{
+	{"failing", "health-failing", "failing workflows"},
+	{"running", "health-running", "running workflows"},
+	{"passing", "health-passing", "passing workflows"},
+}
+
+// healthFilterPredicates returns the nav-list filter predicates for the
+// health keywords. A group matches when any workflow within it does. Only
+// cached runs are considered, so workflows not yet fetched never match.
+func (a *App) healthFilterPredicates() []components.FilterPredicate {
+	predicates := make([]components.FilterPredicate, len(healthFilterKeywords))
+	for i, kw := range healthFilterKeywords {
+		matches := func(workflow string) bool {
+			run, ok := a.latestRuns[workflow]
+			return ok && run != nil && healthBucketID(run) == kw.bucket
+		}
+		predicates[i] = components.FilterPredicate{
+			Keyword:     kw.keyword,
+			Description: kw.desc,
+			Match: func(item components.ListItem) bool {
+				data, ok := item.Data.(*navItemData)
+				if !ok {
+					return false
+				}
+				if !data.isGroup {
+					return matches(data.workflowName)
+				}
+				return slices.ContainsFunc(data.group.GetAllWorkflows(), matches)
+			},
+		}
+	}
+	return predicates
+}
+
+// toggleHealthView switches the nav list between config groups and status buckets
+func (a *App) toggleHealthView() (tea.Model, tea.Cmd) {
+	a.healthView = !a.healthView
+	a.navList.ClearFilter()
+
+	if !a.healthView {
+		a.groupPath = a.configGroupPath
+		a.configGroupPath = nil
+		a.healthGroups = nil
+		a.refreshNavList()
+		a.updateHelpBar()
+		return a, nil
+	}
+
+	a.configGroupPath = a.groupPath
+	a.groupPath = []*config.Group{}
+	a.rebuildHealthGroups()
+	a.refreshNavList()
+	a.updateHelpBar()
+
+	if a.hasCompleteHealthData() {
+		return a, nil
+	}
+	return a, tea.Batch(a.spinner.Start("Checking workflow health..."), a.fetchLatestRunsCmd())
+}
+
+func (a *App) hasCompleteHealthData() bool {
+	files, _ := a.config.WorkflowFiles()
+	for _, wf := range files {
+		if _, ok := a.latestRuns[wf]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuildHealthGroups buckets all workflows into synthetic groups by the
+// status of their cached latest run
+func (a *App) rebuildHealthGroups() {
+	files, names := a.config.WorkflowFiles()
+
+	groups := make([]config.Group, len(healthBuckets))
+	index := make(map[string]int, len(healthBuckets))
+	for i, bucket := range healthBuckets {
+		groups[i] = config.Group{ID: bucket.id, Name: bucket.name, Description: bucket.desc}
+		index[bucket.id] = i
+	}
+
+	for _, wf := range files {
+		bucket := "health-unknown"
+		if run, known := a.latestRuns[wf]; known {
+			bucket = healthBucketID(run)
+		} else if _, failed := a.healthErrors[wf]; !failed {
+			continue
+		}
+		g := &groups[index[bucket]]
+		g.WorkflowDefs = append(g.WorkflowDefs, config.Workflow{File: wf, Name: names[wf]})
+	}
+
+	a.healthGroups = groups
+	a.invalidateNavItems()
+
+	// Keep the current bucket pointer valid after rebuilding
+	if len(a.groupPath) > 0 {
+		a.groupPath = []*config.Group{&a.healthGroups[index[a.groupPath[0].ID]]}
+	}
+}
+
+func (a *App) fetchLatestRunsCmd() tea.Cmd {
+	files, _ := a.config.WorkflowFiles()
+	gh := a.gh
+
+	return func() tea.Msg {
+		results := make(map[string]*models.GHRun, len(files))
+		errs := make(map[string]error)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, healthFetchWorkers)
+
+		for _, wf := range files {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(wf string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				runs, err := gh.GetWorkflowRuns(wf, 1)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs[wf] = err
+				} else if len(runs) > 0 {
+					results[wf] = &runs[0]
+				} else {
+					results[wf] = nil
+				}
+			}(wf)
+		}
+
+		wg.Wait()
+		return latestRunsMsg{runs: results, errs: errs}
+	}
+}
+
+// handleLatestRuns caches the latest runs fetched for the health view. The
+// workflows that failed go to the Unknown bucket, with the first error shown.
+func (a *App) handleLatestRuns(msg latestRunsMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	for wf, run := range msg.runs {
+		a.latestRuns[wf] = run
+		delete(a.healthErrors, wf)
+	}
+	for wf, err := range msg.errs {
+		a.healthErrors[wf] = err
+	}
+	a.invalidateNavItems()
+	if a.healthView {
+		a.rebuildHealthGroups()
+	}
+	a.refreshNavList()
+
+	if len(msg.errs) == 0 {
+		return a, nil
+	}
+	failed := slices.Sorted(maps.Keys(msg.errs))
+	a.err = fmt.Errorf("%s: %w", failed[0], msg.errs[failed[0]])
+	return a, a.toaster.Warning(fmt.Sprintf("Could not check %d workflows: %s", len(failed), a.healthError(failed[0])))
+}
+
+// healthError returns why the latest run of workflow could not be fetched,
+// shortened to its first line, or "" when nothing failed
+func (a *App) healthError(workflow string) string {
+	err, ok := a.healthErrors[workflow]
+	if !ok {
+		return ""
+	}
+	line, _, _ := strings.Cut(err.Error(), "\n")
+	return line
+}
+
+// cacheLatestRun records the newest run of a workflow for the health view
+func (a *App) cacheLatestRun(workflow string, runs []models.GHRun) {
+	if workflow == "" {
+		return
+	}
+	if len(runs) > 0 {
+		run := runs[0]
+		a.latestRuns[workflow] = &run
+	} else {
+		a.latestRuns[workflow] = nil
+	}
+	delete(a.healthErrors, workflow)
+
+	a.invalidateNavItems()
+	if a.healthView {
+		a.rebuildHealthGroups()
+	}
+	a.refreshNavList()
+}
+
+// exitHealthView leaves the health view without restoring the previous group path
+func (a *App) exitHealthView() {
+	a.healthView = false
+	a.healthGroups = nil
+	a.configGroupPath = nil
+}
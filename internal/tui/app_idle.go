@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleCheckInterval is how often the idle timeout is checked. Quitting up to
+// this much later than the timeout is fine for a screen-privacy feature.
+const idleCheckInterval = 30 * time.Second
+
+type idleTickMsg struct {
+	at time.Time
+}
+
+// touch records user input, restarting the idle timeout
+func (a *App) touch() {
+	a.lastInteraction = time.Now()
+}
+
+// idleTickCmd schedules the next idle check, or nothing when the idle
+// timeout is disabled
+func (a *App) idleTickCmd() tea.Cmd {
+	if a.idleTimeout <= 0 {
+		return nil
+	}
+	return tea.Tick(idleCheckInterval, func(t time.Time) tea.Msg {
+		return idleTickMsg{at: t}
+	})
+}
+
+// handleIdleTick quits, saving state like a normal quit, once there has been
+// no key input for the idle timeout
+func (a *App) handleIdleTick(msg idleTickMsg) (tea.Model, tea.Cmd) {
+	if a.idleTimeout <= 0 {
+		return a, nil
+	}
+	if msg.at.Sub(a.lastInteraction) >= a.idleTimeout {
+		return a.quit()
+	}
+	return a, a.idleTickCmd()
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,11 +18,67 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.cmdPalette.IsActive() {
 		cmd, teaCmd := a.cmdPalette.Update(msg)
 		if cmd != nil {
-			return a.executeCommand(cmd)
+			model, teaCmd := a.executeCommand(cmd)
+			// A palette kept open gives way to a confirmation or overlay
+			// the command opened, which would otherwise not get keys
+			if a.cmdPalette.IsActive() && a.overlayActive() {
+				a.cmdPalette.Close()
+			}
+			return model, teaCmd
 		}
 		return a, teaCmd
 	}
 
+	if a.dispatchForm.IsActive() {
+		if req := a.dispatchForm.Update(msg); req != nil {
+			return a, a.submitDispatch(req)
+		}
+		return a, nil
+	}
+
+	if a.confirm.IsActive() {
+		confirmed := a.confirm.Update(msg)
+		onConfirm := a.onConfirm
+		if !a.confirm.IsActive() {
+			a.onConfirm = nil
+		}
+		if confirmed && onConfirm != nil {
+			return onConfirm()
+		}
+		return a, nil
+	}
+
+	if a.annotations.IsActive() {
+		a.annotations.Update(msg)
+		return a, nil
+	}
+
+	if a.sourceView.IsActive() {
+		if a.sourceView.Update(msg) {
+			return a, a.copyWorkflowSource(a.sourceView.Workflow(), a.sourceView.Source())
+		}
+		return a, nil
+	}
+
+	if a.runView.IsActive() {
+		if a.runView.Update(msg) {
+			return a, a.openRunInBrowser(a.runView.RunID())
+		}
+		return a, nil
+	}
+
+	if a.branchPicker.IsActive() {
+		ref := a.branchPicker.Update(msg)
+		onBranch := a.onBranch
+		if !a.branchPicker.IsActive() {
+			a.onBranch = nil
+		}
+		if ref != nil && onBranch != nil {
+			return onBranch(*ref)
+		}
+		return a, nil
+	}
+
 	if a.search.IsActive() {
 		result, cmd := a.search.Update(msg)
 		if result != nil {
@@ -30,20 +87,32 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 
+	if a.groupJump.IsActive() {
+		result, cmd := a.groupJump.Update(msg)
+		if result != nil {
+			return a.navigateToSearchResult(result)
+		}
+		return a, cmd
+	}
+
 	if a.isFiltering() {
 		return a.handleFilterKey(msg)
 	}
 
 	switch msg.String() {
 	case "ctrl+c", "q":
-		a.stopRefreshTicker()
-		a.saveState()
-		return a, tea.Quit
+		return a.quit()
 
 	case "?":
+		a.updateHelpBar()
 		a.helpOverlay.Toggle()
 		return a, nil
 
+	case "K":
+		a.updateHelpBar()
+		a.helpBar.ToggleExpanded()
+		return a, nil
+
 	case ":":
 		a.cmdPalette.Open()
 		return a, nil
@@ -73,11 +142,39 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.search.Open()
 		return a, nil
 
+	case "ctrl+g":
+		a.groupJump.Open()
+		return a, nil
+
 	case "ctrl+r":
 		return a.handleRefreshKey()
 
+	case "ctrl+o":
+		return a.openOfferedRun()
+
 	case "ctrl+t":
 		return a.handleToggleAutoRefresh()
+
+	case "R":
+		return a.reloadConfig()
+
+	case "ctrl+l":
+		return a.confirmSwitchToLocal()
+
+	case "t":
+		return a.toggleFilenames()
+
+	case "D":
+		return a.toggleDescriptions()
+
+	case "x":
+		return a.startDispatch(false)
+
+	case "X":
+		return a.startDispatch(true)
+
+	case "V":
+		return a.showWorkflowSource()
 	}
 
 	if a.focusArea == FocusSidebar {
@@ -118,16 +215,27 @@ func (a *App) handleShiftTabKey() (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// overlayActive reports whether an overlay other than the command palette
+// is open
+func (a *App) overlayActive() bool {
+	return a.helpOverlay.IsActive() || a.dispatchForm.IsActive() || a.confirm.IsActive() ||
+		a.annotations.IsActive() || a.sourceView.IsActive() || a.runView.IsActive() ||
+		a.branchPicker.IsActive() || a.search.IsActive() || a.groupJump.IsActive()
+}
+
 func (a *App) handleRefreshKey() (tea.Model, tea.Cmd) {
 	if a.selectedWorkflow != "" && !a.loading {
 		a.loading = true
 		a.runsTable.SetLoading(true)
-		cmds := []tea.Cmd{a.spinner.Start("Refreshing..."), a.fetchWorkflowRunsCmd}
+		cmds := []tea.Cmd{a.spinner.Start("Refreshing..."), a.fetchWorkflowRuns()}
 		if a.refreshInterval > 0 && a.autoRefreshEnabled {
 			a.startRefreshTicker()
 		}
 		return a, tea.Batch(cmds...)
 	}
+	if a.viewMode == ViewGroups && a.hasNoWorkflows() {
+		return a.checkForWorkflows()
+	}
 	return a, nil
 }
 
@@ -140,6 +248,7 @@ func (a *App) handleToggleAutoRefresh() (tea.Model, tea.Cmd) {
 			a.stopRefreshTicker()
 		}
 		a.updateStatusBar()
+		return a, a.getRefreshTickerCmd()
 	}
 	return a, nil
 }
@@ -147,6 +256,9 @@ func (a *App) handleToggleAutoRefresh() (tea.Model, tea.Cmd) {
 func (a *App) handleSidebarKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
+		if a.sidebar.ToggleGroup() {
+			return a, nil
+		}
 		if item := a.sidebar.SelectedItem(); item != nil {
 			return a.selectWorkflowFromSidebar(item)
 		}
@@ -155,29 +267,26 @@ func (a *App) handleSidebarKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "p":
 		if item := a.sidebar.SelectedItem(); item != nil {
 			if group, ok := item.Data.(*config.Group); ok {
-				group.TogglePin(item.WorkflowName)
-				if err := a.config.Save(a.configPath); err != nil {
-					a.err = fmt.Errorf("failed to save config: %w", err)
-					return a, a.toaster.Error("Failed to save")
-				}
-				a.refreshPinnedList()
-				a.refreshNavList()
-				a.saveState()
-				return a, a.toaster.Success("Unpinned workflow")
+				return a.togglePin(a.config.FindGroupPath(group), item.WorkflowName)
 			}
 		}
 		return a, nil
 
 	case "w":
 		if item := a.sidebar.SelectedItem(); item != nil {
-			if err := a.gh.OpenWorkflowInBrowser(item.WorkflowName); err != nil {
-				a.err = err
-				return a, a.toaster.Error("Failed to open browser")
-			}
-			return a, a.toaster.Info("Opening in browser...")
+			return a, a.openWorkflowInBrowser(item.WorkflowName)
+		}
+		return a, nil
+
+	case "Y":
+		if item := a.sidebar.SelectedItem(); item != nil {
+			return a, a.copyToClipboard(item.WorkflowName)
 		}
 		return a, nil
 
+	case "i":
+		return a.peekLatestRun()
+
 	case "l", "right":
 		a.focusArea = FocusMain
 		a.updateFocus()
@@ -197,24 +306,61 @@ func (a *App) handleGroupsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case " ":
+		return a.toggleMark()
+
 	case "esc", "backspace", "h":
-		if a.navList.HasFilter() {
+		if msg.String() == "esc" && a.navList.MarkedCount() > 0 {
+			a.navList.ClearMarks()
+			return a, nil
+		}
+		// With remembered filters, only esc clears one; h goes back and the
+		// filter is restored when the group is reopened
+		if a.navList.HasFilter() && (msg.String() == "esc" || !a.rememberFilters || len(a.groupPath) == 0) {
 			a.navList.ClearFilter()
 			return a, nil
 		}
 		if len(a.groupPath) > 0 {
-			a.groupPath = a.groupPath[:len(a.groupPath)-1]
-			a.refreshNavList()
+			a.enterGroupPath(a.groupPath[:len(a.groupPath)-1])
 			a.saveState()
 		}
 		return a, nil
 
 	case "p":
+		if a.healthView {
+			return a, a.toaster.Warning("Switch to groups view to pin")
+		}
+		if a.navList.MarkedCount() > 0 {
+			return a.pinMarked()
+		}
 		return a.handlePinInGroups()
 
+	case "f":
+		return a.handleFavoriteInGroups()
+
+	case "H":
+		return a.handleHideInGroups()
+
+	case ".":
+		return a.toggleShowHidden()
+
 	case "w":
 		return a.handleOpenInGroups()
 
+	case "Y":
+		if item := a.navList.SelectedItem(); item != nil {
+			if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
+				return a, a.copyToClipboard(navItem.workflowName)
+			}
+		}
+		return a, nil
+
+	case "i":
+		return a.peekLatestRun()
+
+	case "v":
+		return a.toggleHealthView()
+
 	default:
 		a.navList.Update(msg)
 		return a, nil
@@ -225,59 +371,239 @@ func (a *App) handlePinInGroups() (tea.Model, tea.Cmd) {
 	if len(a.groupPath) > 0 {
 		if item := a.navList.SelectedItem(); item != nil {
 			if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
-				currentGroup := a.groupPath[len(a.groupPath)-1]
-				wasPinned := currentGroup.IsPinned(navItem.workflowName)
-				currentGroup.TogglePin(navItem.workflowName)
-				if err := a.config.Save(a.configPath); err != nil {
-					a.err = fmt.Errorf("failed to save config: %w", err)
-					return a, a.toaster.Error("Failed to save")
-				}
-				a.refreshNavList()
-				a.refreshPinnedList()
-				a.saveState()
-				if wasPinned {
-					return a, a.toaster.Success("Unpinned workflow")
-				}
-				return a, a.toaster.Success("Pinned workflow")
+				return a.togglePin(a.groupPath, navItem.workflowName)
 			}
 		}
 	}
 	return a, nil
 }
 
-func (a *App) handleOpenInGroups() (tea.Model, tea.Cmd) {
-	if item := a.navList.SelectedItem(); item != nil {
-		if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
-			if err := a.gh.OpenWorkflowInBrowser(navItem.workflowName); err != nil {
-				a.err = err
-				return a, a.toaster.Error("Failed to open browser")
-			}
-			return a, a.toaster.Info("Opening in browser...")
+// handleFavoriteInGroups stars or unstars the highlighted group
+func (a *App) handleFavoriteInGroups() (tea.Model, tea.Cmd) {
+	if a.healthView {
+		return a, a.toaster.Warning("Switch to groups view to star groups")
+	}
+	item := a.navList.SelectedItem()
+	if item == nil {
+		return a, nil
+	}
+	navItem, ok := item.Data.(*navItemData)
+	if !ok || !navItem.isGroup {
+		return a, a.toaster.Info("Select a group to star it")
+	}
+	return a.toggleFavoriteGroup(navItem.group)
+}
+
+func (a *App) handleHideInGroups() (tea.Model, tea.Cmd) {
+	item := a.navList.SelectedItem()
+	if item == nil {
+		return a, nil
+	}
+	navItem, ok := item.Data.(*navItemData)
+	if !ok || navItem.isGroup {
+		return a, a.toaster.Info("Select a workflow to hide it")
+	}
+	return a.toggleHiddenWorkflow(navItem.workflowName)
+}
+
+// toggleHiddenWorkflow hides or shows workflow in the group lists and search.
+// Like favorites, hidden workflows are saved to the user-tier config.
+func (a *App) toggleHiddenWorkflow(workflow string) (tea.Model, tea.Cmd) {
+	hidden, err := a.config.ToggleHiddenWorkflow(a.pinConfigPath, workflow)
+	if err != nil {
+		a.err = fmt.Errorf("failed to save config: %w", err)
+		return a, a.toaster.Error("Failed to save")
+	}
+
+	a.invalidateNavItems()
+	a.refreshNavList()
+	a.updateStatusBar()
+	if hidden && !a.showHidden {
+		return a, a.toaster.Success("Hid " + workflow + ", press . to show hidden workflows")
+	}
+	if hidden {
+		return a, a.toaster.Success("Hid " + workflow)
+	}
+	return a, a.toaster.Success("Unhid " + workflow)
+}
+
+// toggleShowHidden lists hidden workflows, marked as hidden, or leaves them
+// out again
+func (a *App) toggleShowHidden() (tea.Model, tea.Cmd) {
+	a.showHidden = !a.showHidden
+	a.invalidateNavItems()
+	a.refreshNavList()
+	a.updateStatusBar()
+	if a.showHidden {
+		return a, a.toaster.Info("Showing hidden workflows")
+	}
+	return a, a.toaster.Info("Hiding hidden workflows")
+}
+
+// toggleFilenames switches the group list and sidebar between workflow
+// display names and filenames
+func (a *App) toggleFilenames() (tea.Model, tea.Cmd) {
+	a.showFilenames = !a.showFilenames
+	a.navList.SetAltTitles(a.showFilenames)
+	a.sidebar.SetAltTitles(a.showFilenames)
+	a.saveState()
+	if a.showFilenames {
+		return a, a.toaster.Info("Showing workflow filenames")
+	}
+	return a, a.toaster.Info("Showing workflow names")
+}
+
+// toggleDescriptions shows or hides the description line of each group list
+// item. The runs table and sidebar are unaffected.
+func (a *App) toggleDescriptions() (tea.Model, tea.Cmd) {
+	hide := !a.navList.DescriptionsHidden()
+	a.navList.SetHideDescriptions(hide)
+	if hide {
+		return a, a.toaster.Info("Hiding descriptions")
+	}
+	return a, a.toaster.Info("Showing descriptions")
+}
+
+// toggleFavoriteGroup stars or unstars group. Like pins, favorites are
+// personal and written to the user-tier config. The cursor follows the group
+// as it moves to or from the top of the root list.
+func (a *App) toggleFavoriteGroup(group *config.Group) (tea.Model, tea.Cmd) {
+	favorite, err := a.config.ToggleFavoriteGroup(a.pinConfigPath, group.ID)
+	if err != nil {
+		a.err = fmt.Errorf("failed to save config: %w", err)
+		return a, a.toaster.Error("Failed to save")
+	}
+
+	a.invalidateNavItems()
+	a.refreshNavList()
+	for i, item := range a.navList.FilteredItems() {
+		if data, ok := item.Data.(*navItemData); ok && data.group == group {
+			a.navList.SetCursor(i)
+			break
 		}
 	}
+	if favorite {
+		return a, a.toaster.Success("Starred " + group.Name)
+	}
+	return a, a.toaster.Success("Unstarred " + group.Name)
+}
+
+// togglePin toggles a workflow's pin on the last group of groupPath.
+// Pins are personal, so the change is written to the user-tier config rather
+// than the (possibly shared) config the groups were loaded from.
+func (a *App) togglePin(groupPath []*config.Group, workflowName string) (tea.Model, tea.Cmd) {
+	pinned, err := a.config.TogglePersonalPin(a.pinTiers, groupPath, workflowName)
+	if errors.Is(err, config.ErrPinnedByTeam) {
+		return a, a.toaster.Warning("Pinned by shared config")
+	}
+	if err != nil {
+		a.err = fmt.Errorf("failed to save config: %w", err)
+		return a, a.toaster.Error("Failed to save")
+	}
+
+	a.invalidateNavItems(groupPath[len(groupPath)-1])
+	a.refreshNavList()
+	a.refreshPinnedList()
+	a.saveState()
+	if pinned {
+		return a, a.toaster.Success("Pinned workflow")
+	}
+	return a, a.toaster.Success("Unpinned workflow")
+}
+
+// askConfirm opens the confirmation dialog and runs onConfirm if the user
+// accepts
+func (a *App) askConfirm(title, message string, onConfirm func() (tea.Model, tea.Cmd)) {
+	a.confirm.Open(title, message)
+	a.onConfirm = onConfirm
+}
+
+// confirmClearPins asks before removing every personal pin
+func (a *App) confirmClearPins() (tea.Model, tea.Cmd) {
+	if len(a.config.GetAllPinnedWorkflows()) == 0 {
+		return a, a.toaster.Info("No pinned workflows")
+	}
+	a.askConfirm("Clear all pins", "Unpin every workflow you pinned? Pins from a shared config stay.", a.clearPins)
 	return a, nil
 }
 
+func (a *App) clearPins() (tea.Model, tea.Cmd) {
+	cleared, err := a.config.ClearPersonalPins(a.pinTiers)
+	if err != nil {
+		a.err = fmt.Errorf("failed to save config: %w", err)
+		return a, a.toaster.Error("Failed to save")
+	}
+	if cleared == 0 {
+		return a, a.toaster.Warning("Pins come from a shared config")
+	}
+
+	a.invalidateNavItems()
+	a.refreshNavList()
+	a.refreshPinnedList()
+	a.saveState()
+	if cleared == 1 {
+		return a, a.toaster.Success("Cleared 1 pin")
+	}
+	return a, a.toaster.Success(fmt.Sprintf("Cleared %d pins", cleared))
+}
+
+func (a *App) handleOpenInGroups() (tea.Model, tea.Cmd) {
+	if a.navList.MarkedCount() > 0 {
+		return a, a.openMarked()
+	}
+	return a, a.openNavItemInBrowser()
+}
+
 func (a *App) handleRunsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "w":
-		runID := a.runsTable.SelectedRunID()
-		if runID > 0 {
-			if err := a.gh.OpenRunInBrowser(runID); err != nil {
-				a.err = err
-				return a, a.toaster.Error("Failed to open browser")
-			}
-			return a, a.toaster.Info("Opening run in browser...")
+	case "w", "enter":
+		return a.openRun(a.openBehavior)
+
+	case "o":
+		return a.openRun(config.OpenBrowser)
+
+	case "v":
+		return a.openRun(config.OpenLogs)
+
+	case "J":
+		return a.openRun(config.OpenJobs)
+
+	case "n":
+		return a.showAnnotations()
+
+	case "T":
+		return a.tagRun()
+
+	case "#":
+		return a.filterRunsByTag()
+
+	case "F":
+		return a.confirmRerunAndWatch()
+
+	case "L":
+		return a.fetchRunLog(false)
+
+	case "S":
+		return a.fetchRunLog(true)
+
+	case "A":
+		if runID := a.runsTable.AttentionRunID(); runID > 0 {
+			return a, a.openRunInBrowser(runID)
 		}
-		return a, nil
+		return a, a.toaster.Info("No runs are waiting for approval")
+
+	case "b":
+		if !a.runsTable.HasBranchMatcher() {
+			return a, a.toaster.Warning("No branch highlights configured")
+		}
+		a.runsTable.ToggleBranchFilter()
+		if a.runsTable.IsBranchFiltered() {
+			return a, a.toaster.Info("Showing highlighted branches only")
+		}
+		return a, a.toaster.Info("Showing all branches")
 
 	case "esc", "h", "backspace":
-		a.viewMode = ViewGroups
-		a.selectedWorkflow = ""
-		a.selectedGroup = nil
-		a.stopRefreshTicker()
-		a.updateFocus()
-		a.updateStatusBar()
+		a.leaveRunsView()
 		return a, nil
 
 	default:
@@ -286,11 +612,20 @@ func (a *App) handleRunsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// handleFilterKey feeds a key to the list being filtered. With autoOpenMatch,
+// confirming a filter that leaves one item opens it.
 func (a *App) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	openMatch := a.autoOpenMatch && msg.String() == "enter"
 	if a.focusArea == FocusSidebar {
 		a.sidebar.Update(msg)
+		if openMatch && len(a.sidebar.FilteredItems()) == 1 {
+			return a.selectWorkflowFromSidebar(a.sidebar.SelectedItem())
+		}
 	} else {
 		a.navList.Update(msg)
+		if openMatch && len(a.navList.FilteredItems()) == 1 {
+			return a.selectNavItem(a.navList.SelectedItem())
+		}
 	}
 	return a, nil
 }
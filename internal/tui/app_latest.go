@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+type latestRunMsg struct {
+	workflow string
+	run      *models.GHRun
+	err      error
+}
+
+// peekLatestRun fetches the selected workflow's newest run and sums it up in
+// a toast, answering "did it pass?" without opening the runs view
+func (a *App) peekLatestRun() (tea.Model, tea.Cmd) {
+	workflow := a.currentWorkflow()
+	if workflow == "" {
+		return a, a.toaster.Info("Select a workflow to see its latest run")
+	}
+	gh := a.gh
+	return a, tea.Batch(a.spinner.Start("Fetching latest run..."), func() tea.Msg {
+		run, err := gh.GetLatestRun(workflow)
+		return latestRunMsg{workflow: workflow, run: run, err: err}
+	})
+}
+
+// handleLatestRun shows the peeked run and caches it for the health view
+func (a *App) handleLatestRun(msg latestRunMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	switch {
+	case errors.Is(msg.err, github.ErrNoRuns):
+		a.cacheLatestRun(msg.workflow, nil)
+		a.refreshNavList()
+		return a, a.toaster.Info(msg.workflow + " has not run yet")
+	case msg.err != nil:
+		a.err = msg.err
+		return a, a.toaster.Error("Failed to fetch the latest run")
+	}
+
+	a.cacheLatestRun(msg.workflow, []models.GHRun{*msg.run})
+	a.refreshNavList()
+	text := latestRunSummary(a.theme, msg.workflow, msg.run, time.Now())
+	switch {
+	case !msg.run.IsTerminal():
+		return a, a.toaster.Info(text)
+	case msg.run.IsSuccess():
+		return a, a.toaster.Success(text)
+	}
+	return a, a.toaster.Error(text)
+}
+
+// latestRunSummary describes a run in one line, such as
+// "build.yml: ✓ success on main, 3h ago"
+func latestRunSummary(t *theme.Theme, workflow string, run *models.GHRun, now time.Time) string {
+	icon, _ := t.StatusIcon(run.Status, run.Conclusion)
+	outcome := run.Status
+	if run.IsTerminal() {
+		outcome = run.Conclusion
+	}
+	age := components.RunTimeFormat{Relative: true}.Format(run.CreatedAt, now)
+	text := fmt.Sprintf("%s: %s %s on %s, %s", workflow, icon, outcome, run.HeadBranch, age)
+	if badge := run.AttemptBadge(); badge != "" {
+		text += " " + badge
+	}
+	return text
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Smallest terminal the layout is designed for. Below this the app shows a
+// message instead of a squashed (or broken) layout.
+const (
+	minTerminalWidth  = 80
+	minTerminalHeight = 20
+)
+
+// layout holds the panel dimensions derived from the terminal size
+type layout struct {
+	sidebarWidth int
+	mainWidth    int
+	panelHeight  int
+	tooSmall     bool
+}
+
+// computeLayout derives panel sizes from the terminal size. Widths and
+// heights include the panel borders.
+func computeLayout(width, height int, showSidebar bool) layout {
+	barHeight := 2
+	l := layout{
+		panelHeight: max(0, height-barHeight-2),
+		tooSmall:    width < minTerminalWidth || height < minTerminalHeight,
+	}
+
+	if showSidebar {
+		// Never let the sidebar take more than half the terminal
+		l.sidebarWidth = min(max(25, width/5), max(0, width/2))
+	}
+	l.mainWidth = width - l.sidebarWidth
+	if showSidebar {
+		l.mainWidth -= 2
+	}
+	l.mainWidth = max(0, l.mainWidth)
+
+	return l
+}
+
+// inner returns the content size of a panel, excluding its border
+func inner(size int) int {
+	return max(0, size-2)
+}
+
+// renderTooSmall explains why the layout isn't shown on a tiny terminal
+func (a *App) renderTooSmall() string {
+	msg := a.theme.StatusWarning.Render("Terminal too small") + "\n" +
+		a.theme.TextDim.Render(fmt.Sprintf("need ≥ %dx%d, have %dx%d",
+			minTerminalWidth, minTerminalHeight, a.width, a.height))
+
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, msg)
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	cfg := &config.Config{
+		Repository: "owner/repo",
+		Groups: []config.Group{
+			{ID: "ci", Name: "CI", Workflows: []string{"ci.yml"}},
+		},
+	}
+	return NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), github.NewClient("owner/repo"), AppOptions{
+		StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+		NoAutoOpen:     true,
+	})
+}
+
+func TestComputeLayoutTooSmall(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          bool
+	}{
+		{width: 1, height: 1, want: true},
+		{width: 40, height: 10, want: true},
+		{width: 79, height: 40, want: true},
+		{width: 120, height: 19, want: true},
+		{width: 80, height: 20, want: false},
+		{width: 200, height: 60, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := computeLayout(tt.width, tt.height, true).tooSmall; got != tt.want {
+			t.Errorf("computeLayout(%d, %d).tooSmall = %v, want %v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestComputeLayoutFitsTerminal(t *testing.T) {
+	for _, showSidebar := range []bool{true, false} {
+		for width := minTerminalWidth; width <= 240; width += 7 {
+			l := computeLayout(width, minTerminalHeight, showSidebar)
+			total := l.sidebarWidth + l.mainWidth
+			if showSidebar {
+				total += 2
+			}
+			if total != width {
+				t.Errorf("width %d (sidebar %v): panels take %d columns", width, showSidebar, total)
+			}
+			if l.mainWidth <= 0 || l.panelHeight <= 0 {
+				t.Errorf("width %d (sidebar %v): non-positive panel size %+v", width, showSidebar, l)
+			}
+		}
+	}
+}
+
+func TestViewTooSmallAndRestore(t *testing.T) {
+	app := newTestApp(t)
+
+	app.Update(tea.WindowSizeMsg{Width: 30, Height: 8})
+	if view := app.View(); !strings.Contains(view, "Terminal too small") {
+		t.Fatalf("expected too-small message, got:\n%s", view)
+	}
+
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if view := app.View(); strings.Contains(view, "Terminal too small") {
+		t.Fatalf("expected layout after resizing up, got:\n%s", view)
+	}
+}
+
+func TestComputeLayoutNarrowTerminals(t *testing.T) {
+	for _, width := range []int{0, 1, 20, 26, 30, 50} {
+		for _, showSidebar := range []bool{true, false} {
+			l := computeLayout(width, 5, showSidebar)
+			if l.sidebarWidth < 0 || l.mainWidth < 0 || l.panelHeight < 0 {
+				t.Errorf("computeLayout(%d, 5, %v) has negative sizes: %+v", width, showSidebar, l)
+			}
+			if l.sidebarWidth > width/2 && width > 0 {
+				t.Errorf("computeLayout(%d, 5, %v) sidebar %d exceeds half the terminal", width, showSidebar, l.sidebarWidth)
+			}
+		}
+	}
+}
+
+func TestRenderLayoutNarrowTerminals(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Groups[0].Workflows = []string{"a-workflow-with-a-very-long-file-name.yml"}
+	app.config.Groups[0].PinnedWorkflows = []string{"a-workflow-with-a-very-long-file-name.yml"}
+	app.refreshNavList()
+	app.refreshPinnedList()
+
+	for _, width := range []int{20, 26, 30} {
+		for _, height := range []int{3, 10} {
+			app.Update(tea.WindowSizeMsg{Width: width, Height: height})
+			// Render the full layout even though View would show the
+			// too-small message, to exercise the truncation paths
+			_ = app.renderLayout()
+
+			app.viewMode = ViewRuns
+			_ = app.renderLayout()
+			app.viewMode = ViewGroups
+		}
+	}
+}
+
+func TestClassicLayoutShowsDetails(t *testing.T) {
+	app := newTestApp(t)
+	app.classicLayout = true
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	// Enter the CI group so ci.yml is highlighted
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a debounced fetch for the highlighted workflow")
+	}
+	if app.detailsWorkflow != "ci.yml" {
+		t.Fatalf("details workflow = %q, want ci.yml", app.detailsWorkflow)
+	}
+
+	app.Update(detailsRunsMsg{workflow: "ci.yml", runs: []models.GHRun{
+		{DatabaseID: 42, HeadBranch: "main", Status: models.StatusCompleted, Conclusion: models.ConclusionSuccess},
+	}})
+
+	view := app.View()
+	if !strings.Contains(view, "Details") || !strings.Contains(view, "#42 main") {
+		t.Fatalf("expected details panel with the run, got:\n%s", view)
+	}
+
+	app.classicLayout = false
+	if view := app.View(); strings.Contains(view, "Details") {
+		t.Fatalf("modern layout should not show details, got:\n%s", view)
+	}
+}
+
+func TestKeyLegendKeepsLayoutHeight(t *testing.T) {
+	h := newNavHarness(t)
+	before := lipgloss.Height(h.app.View())
+
+	h.press("K")
+	view := h.app.View()
+	if !strings.Contains(view, "[/] Filter the list") {
+		t.Errorf("expected the legend to describe the context's bindings, got:\n%s", view)
+	}
+	if got := lipgloss.Height(view); got != before {
+		t.Errorf("expected the legend to keep the view %d lines high, got %d", before, got)
+	}
+
+	h.press("K")
+	if strings.Contains(h.app.View(), "[/] Filter the list") {
+		t.Error("expected K to collapse the legend")
+	}
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+)
+
+const (
+	// logConfirmSize is the log size above which copying asks first
+	logConfirmSize = 256 << 10
+	// logClipboardLimit is the log size above which the log is saved to a
+	// file instead, since clipboard tools struggle with very large text
+	logClipboardLimit = 4 << 20
+)
+
+type runLogMsg struct {
+	runID int
+	log   []byte
+	save  bool
+	err   error
+}
+
+// fetchRunLog fetches the selected run's full log to copy it to the
+// clipboard, or with save to write it to a file
+func (a *App) fetchRunLog(save bool) (tea.Model, tea.Cmd) {
+	runID := a.runsTable.SelectedRunID()
+	if runID == 0 {
+		return a, nil
+	}
+	gh := a.gh
+	return a, tea.Batch(
+		a.spinner.Start(fmt.Sprintf("Fetching log of run #%d...", runID)),
+		func() tea.Msg {
+			log, err := gh.GetRunLog(runID)
+			return runLogMsg{runID: runID, log: log, save: save, err: err}
+		},
+	)
+}
+
+func (a *App) handleRunLog(msg runLogMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	if msg.err != nil {
+		a.err = msg.err
+		return a, a.toaster.Error("Failed to fetch the run log")
+	}
+
+	switch {
+	case msg.save:
+		return a, a.saveRunLog(msg.runID, msg.log, "Saved log to ")
+	case len(msg.log) > logClipboardLimit:
+		return a, a.saveRunLog(msg.runID, msg.log, "Log too large to copy, saved to ")
+	case len(msg.log) > logConfirmSize:
+		a.askConfirm("Copy log",
+			fmt.Sprintf("The log of run #%d is %s. Copy it to the clipboard?", msg.runID, formatSize(len(msg.log))),
+			func() (tea.Model, tea.Cmd) {
+				return a, a.copyRunLog(msg.runID, msg.log)
+			})
+		return a, nil
+	}
+	return a, a.copyRunLog(msg.runID, msg.log)
+}
+
+func (a *App) copyRunLog(runID int, log []byte) tea.Cmd {
+	return func() tea.Msg {
+		return actionResultMsg{
+			success: fmt.Sprintf("Copied log of run #%d (%s)", runID, formatSize(len(log))),
+			failure: "Failed to copy to clipboard",
+			err:     clipboard.WriteAll(string(log)),
+		}
+	}
+}
+
+// saveRunLog writes the log to run-<id>.log in the cache directory and
+// toasts success followed by the file's path
+func (a *App) saveRunLog(runID int, log []byte, success string) tea.Cmd {
+	return func() tea.Msg {
+		p, err := paths.New()
+		if err != nil {
+			return actionResultMsg{failure: "Failed to find the cache directory", err: err}
+		}
+		dir := p.RunLogDir()
+		path := filepath.Join(dir, fmt.Sprintf("run-%d.log", runID))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return actionResultMsg{failure: "Failed to save the run log", err: err}
+		}
+		err = os.WriteFile(path, log, 0644)
+		return actionResultMsg{success: success + path, failure: "Failed to save the run log", err: err}
+	}
+}
+
+// formatSize renders a byte count in KB or MB
+func formatSize(n int) string {
+	if n >= 1<<20 {
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	}
+	return fmt.Sprintf("%d KB", max(1, n>>10))
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleMark marks or unmarks the highlighted workflow for a batch pin or
+// open. Groups cannot be marked.
+func (a *App) toggleMark() (tea.Model, tea.Cmd) {
+	item := a.navList.SelectedItem()
+	if item == nil {
+		return a, nil
+	}
+	if navItem, ok := item.Data.(*navItemData); !ok || navItem.isGroup {
+		return a, a.toaster.Info("Only workflows can be marked")
+	}
+	a.navList.ToggleMark()
+	return a, nil
+}
+
+// markedWorkflows returns the workflow files of the marked nav list items
+func (a *App) markedWorkflows() []string {
+	var workflows []string
+	for _, item := range a.navList.MarkedItems() {
+		if navItem, ok := item.Data.(*navItemData); ok && !navItem.isGroup {
+			workflows = append(workflows, navItem.workflowName)
+		}
+	}
+	return workflows
+}
+
+// pinMarked toggles the pin of every marked workflow, saving the user-tier
+// config once, then unmarks them
+func (a *App) pinMarked() (tea.Model, tea.Cmd) {
+	if len(a.groupPath) == 0 {
+		return a, nil
+	}
+	workflows := a.markedWorkflows()
+	pinned, unpinned, err := a.config.TogglePersonalPins(a.pinTiers, a.groupPath, workflows)
+	if err != nil {
+		a.err = fmt.Errorf("failed to save config: %w", err)
+		return a, a.toaster.Error("Failed to save")
+	}
+
+	a.navList.ClearMarks()
+	a.invalidateNavItems(a.groupPath[len(a.groupPath)-1])
+	a.refreshNavList()
+	a.refreshPinnedList()
+	a.saveState()
+
+	message := fmt.Sprintf("Pinned %d, unpinned %d", pinned, unpinned)
+	if skipped := len(workflows) - pinned - unpinned; skipped > 0 {
+		return a, a.toaster.Warning(fmt.Sprintf("%s, %d pinned by shared config", message, skipped))
+	}
+	return a, a.toaster.Success(message)
+}
+
+// openMarked opens every marked workflow in the browser, then unmarks them
+func (a *App) openMarked() tea.Cmd {
+	workflows := a.markedWorkflows()
+	a.navList.ClearMarks()
+	label := fmt.Sprintf("Opening %d workflows...", len(workflows))
+	return a.runAction(label, "Opened in browser", "Failed to open browser", func() error {
+		var errs []error
+		for _, workflow := range workflows {
+			if err := a.gh.OpenWorkflowInBrowser(workflow); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", workflow, err))
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
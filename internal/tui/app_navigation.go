@@ -15,10 +15,17 @@ type navItemData struct {
 }
 
 func (a *App) refreshNavList() {
+	// Marks belong to the list they were made in
+	if key := a.navItemsKey(); key != a.navListKey {
+		a.navList.ClearMarks()
+		a.navListKey = key
+	}
 	items := a.buildNavItems()
 	a.navList.SetItems(items)
 
-	if len(a.groupPath) == 0 {
+	if len(a.groupPath) == 0 && a.healthView {
+		a.navList.SetTitle("🩺 Health")
+	} else if len(a.groupPath) == 0 {
 		a.navList.SetTitle("📁 Groups")
 	} else {
 		current := a.groupPath[len(a.groupPath)-1]
@@ -26,22 +33,81 @@ func (a *App) refreshNavList() {
 	}
 }
 
+// navItemsKey identifies the items of one nav list: a group's, or the root
+// list's when group is nil
+type navItemsKey struct {
+	group  *config.Group
+	health bool
+}
+
+func (a *App) navItemsKey() navItemsKey {
+	key := navItemsKey{health: a.healthView}
+	if len(a.groupPath) > 0 {
+		key.group = a.groupPath[len(a.groupPath)-1]
+	}
+	return key
+}
+
+// buildNavItems returns the items of the current group, reusing them if
+// they were built before
 func (a *App) buildNavItems() []components.ListItem {
-	var items []components.ListItem
+	key := a.navItemsKey()
+	if items, ok := a.navItems[key]; ok {
+		return items
+	}
 
+	var items []components.ListItem
 	if len(a.groupPath) == 0 {
 		items = a.buildRootGroupItems()
 	} else {
 		items = a.buildCurrentGroupItems()
 	}
-
+	a.navItems[key] = items
 	return items
 }
 
+// invalidateNavItems drops the built items of groups so the next refresh
+// rebuilds them. Without groups, every list is dropped, as needed when the
+// config, badges or favorites change.
+func (a *App) invalidateNavItems(groups ...*config.Group) {
+	if len(groups) == 0 {
+		clear(a.navItems)
+		return
+	}
+	for _, group := range groups {
+		delete(a.navItems, navItemsKey{group: group})
+		delete(a.navItems, navItemsKey{group: group, health: true})
+	}
+}
+
 func (a *App) buildRootGroupItems() []components.ListItem {
+	groups := a.config.Groups
+	if a.healthView {
+		groups = a.healthGroups
+	}
+
+	// Favorites come first, nested ones included, so they are one key away
 	var items []components.ListItem
-	for i := range a.config.Groups {
-		group := &a.config.Groups[i]
+	listed := make(map[*config.Group]bool)
+	if !a.healthView {
+		for _, id := range a.config.GetFavoriteGroups() {
+			if group := a.config.FindGroupByID(id); group != nil && !listed[group] {
+				listed[group] = true
+				items = append(items, a.createGroupListItem(group))
+			}
+		}
+	}
+	var rest []*config.Group
+	for i := range groups {
+		if group := &groups[i]; !listed[group] {
+			rest = append(rest, group)
+		}
+	}
+	// Health buckets keep their order, from failing to passing
+	if !a.healthView {
+		a.order.groups(rest)
+	}
+	for _, group := range rest {
 		items = append(items, a.createGroupListItem(group))
 	}
 	return items
@@ -54,12 +120,18 @@ func (a *App) buildCurrentGroupItems() []components.ListItem {
 	workflows := a.collectWorkflows(currentGroup)
 	workflowDefs := a.buildWorkflowDefsMap(currentGroup)
 	pinnedWorkflows, unpinnedWorkflows := a.separatePinnedWorkflows(currentGroup, workflows)
+	a.order.workflows(currentGroup, pinnedWorkflows)
+	a.order.workflows(currentGroup, unpinnedWorkflows)
 
 	items = append(items, a.createWorkflowItems(pinnedWorkflows, workflowDefs, true)...)
 	items = append(items, a.createWorkflowItems(unpinnedWorkflows, workflowDefs, false)...)
 
+	groups := make([]*config.Group, len(currentGroup.Groups))
 	for i := range currentGroup.Groups {
-		group := &currentGroup.Groups[i]
+		groups[i] = &currentGroup.Groups[i]
+	}
+	a.order.groups(groups)
+	for _, group := range groups {
 		items = append(items, a.createGroupListItem(group))
 	}
 
@@ -67,12 +139,17 @@ func (a *App) buildCurrentGroupItems() []components.ListItem {
 }
 
 func (a *App) collectWorkflows(group *config.Group) []string {
-	workflows := make([]string, 0)
+	workflows := make([]string, 0, len(group.Workflows)+len(group.WorkflowDefs))
 	workflows = append(workflows, group.Workflows...)
 
+	seen := make(map[string]bool, len(workflows))
+	for _, wf := range workflows {
+		seen[wf] = true
+	}
 	for i := range group.WorkflowDefs {
 		wf := &group.WorkflowDefs[i]
-		if !contains(workflows, wf.File) {
+		if !seen[wf.File] {
+			seen[wf.File] = true
 			workflows = append(workflows, wf.File)
 		}
 	}
@@ -108,6 +185,11 @@ func (a *App) createWorkflowItems(workflows []string, workflowDefs map[string]*c
 	items := make([]components.ListItem, 0, len(workflows))
 
 	for _, wf := range workflows {
+		hidden := a.config.IsHiddenWorkflow(wf)
+		if hidden && !a.showHidden {
+			continue
+		}
+
 		displayName := wf
 		if wfDef, ok := workflowDefs[wf]; ok && wfDef.Name != "" {
 			displayName = wfDef.Name
@@ -117,12 +199,28 @@ func (a *App) createWorkflowItems(workflows []string, workflowDefs map[string]*c
 		if isPinned {
 			icon = a.theme.Icons.Pin
 		}
+		if hidden {
+			icon = a.theme.Icons.Hidden
+		}
+		if badge := a.workflowBadge(wf); badge != "" {
+			icon += " " + badge
+		}
+
+		var altTitle string
+		if displayName != wf {
+			altTitle = wf
+		}
+		description := wf
+		if reason := a.healthError(wf); reason != "" && a.healthView {
+			description = "⚠ " + reason
+		}
 
 		items = append(items, components.ListItem{
 			ID:          wf,
 			Title:       displayName,
-			Description: wf,
+			Description: description,
 			Icon:        icon,
+			AltTitle:    altTitle,
 			Data: &navItemData{
 				isGroup:      false,
 				workflowName: wf,
@@ -135,11 +233,15 @@ func (a *App) createWorkflowItems(workflows []string, workflowDefs map[string]*c
 }
 
 func (a *App) createGroupListItem(group *config.Group) components.ListItem {
+	icon := a.theme.Icons.Folder
+	if !a.healthView && a.config.IsFavoriteGroup(group.ID) {
+		icon = a.theme.Icons.Favorite
+	}
 	return components.ListItem{
 		ID:          group.ID,
 		Title:       group.Name,
 		Description: fmt.Sprintf("%d workflows", a.countWorkflows(group)),
-		Icon:        a.theme.Icons.Folder,
+		Icon:        icon,
 		Data: &navItemData{
 			isGroup: true,
 			group:   group,
@@ -160,8 +262,13 @@ func (a *App) refreshPinnedList() {
 	items := make([]components.PinnedItem, len(pinnedWorkflows))
 
 	for i, pw := range pinnedWorkflows {
+		var displayName string
+		if def := pw.Group.GetWorkflowDef(pw.WorkflowName); def != nil {
+			displayName = def.Name
+		}
 		items[i] = components.PinnedItem{
 			WorkflowName: pw.WorkflowName,
+			DisplayName:  displayName,
 			GroupName:    pw.Group.Name,
 			GroupID:      pw.Group.ID,
 			Data:         pw.Group,
@@ -170,12 +277,3 @@ func (a *App) refreshPinnedList() {
 
 	a.sidebar.SetItems(items)
 }
-
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
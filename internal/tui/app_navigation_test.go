@@ -0,0 +1,1444 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// stubRuns is what the stub gh prints for `gh run list`
+const stubRuns = `[
+	{"databaseId": 2, "displayTitle": "Second", "workflowName": "Build", "status": "completed", "conclusion": "success", "createdAt": "2025-03-14T10:00:00Z", "headBranch": "main"},
+	{"databaseId": 1, "displayTitle": "First", "workflowName": "Build", "status": "completed", "conclusion": "failure", "createdAt": "2025-03-14T09:00:00Z", "headBranch": "main"}
+]`
+
+// stubDispatchedRuns is what the stub gh prints for `gh run list` once a
+// workflow was dispatched with STUB_DISPATCHED set
+const stubDispatchedRuns = `[
+	{"databaseId": 3, "displayTitle": "Build", "workflowName": "Build", "status": "queued", "createdAt": "2025-03-14T11:00:00Z", "headBranch": "feature/x"},
+	{"databaseId": 2, "displayTitle": "Second", "workflowName": "Build", "status": "completed", "conclusion": "success", "createdAt": "2025-03-14T10:00:00Z", "headBranch": "main"},
+	{"databaseId": 1, "displayTitle": "First", "workflowName": "Build", "status": "completed", "conclusion": "failure", "createdAt": "2025-03-14T09:00:00Z", "headBranch": "main"}
+]`
+
+// stubRerun is what the stub gh prints for `gh run view` of a single run:
+// the failed run after its failed jobs passed on a second attempt
+const stubRerun = `{"databaseId": 1, "displayTitle": "First", "workflowName": "Build", "status": "completed", "conclusion": "success", "createdAt": "2025-03-14T09:00:00Z", "headBranch": "main", "attempt": 2}`
+
+// stubWorkflow is what the stub gh prints for `gh workflow view --yaml`
+const stubWorkflow = `name: Build
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+`
+
+// stubLog is what the stub gh prints for `gh run view --log`
+const stubLog = "lint\tRun go vet\tundefined: foo\n"
+
+// stubJobs and stubAnnotations are what the stub gh prints for a run's jobs
+// and for the annotations of job 7, one object per line as --jq '.[]' does
+const (
+	stubJobs        = `{"jobs": [{"databaseId": 7, "name": "lint", "status": "completed", "conclusion": "failure"}]}`
+	stubAnnotations = `{"path": "main.go", "start_line": 12, "annotation_level": "failure", "message": "undefined: foo"}
+{"path": "util.go", "start_line": 3, "annotation_level": "warning", "message": "unused variable"}
+`
+)
+
+// TestHelperProcess is not a real test. It stands in for gh when a stub
+// client runs the test binary with GO_WANT_HELPER_PROCESS set.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	// Arguments after "--" are the gh command line, starting with "gh"
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	if len(args) > 3 && args[2] == "run" && args[3] == "list" {
+		if failing := os.Getenv("STUB_FAIL_WORKFLOW"); failing != "" && slices.Contains(args, failing) {
+			fmt.Fprint(os.Stderr, "HTTP 502: Bad Gateway")
+			os.Exit(1)
+		}
+		if os.Getenv("STUB_DISPATCHED") == "1" {
+			fmt.Print(stubDispatchedRuns)
+		} else {
+			fmt.Print(stubRuns)
+		}
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "run" && args[3] == "view" && slices.Contains(args, "jobs") {
+		fmt.Print(stubJobs)
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "run" && args[3] == "view" && slices.Contains(args, "--log") {
+		fmt.Print(stubLog)
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "run" && args[3] == "rerun" {
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "run" && args[3] == "view" {
+		fmt.Print(stubRerun)
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "workflow" && args[3] == "view" && slices.Contains(args, "--yaml") {
+		fmt.Print(stubWorkflow)
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "api" {
+		switch path := args[3]; {
+		case path == "repos/owner/repo":
+			fmt.Println("main")
+			os.Exit(0)
+		case strings.HasPrefix(path, "repos/owner/repo/branches"):
+			fmt.Println("feature/x\nmain")
+			os.Exit(0)
+		case path == "repos/owner/repo/commits/feature/x":
+			fmt.Println("abc123")
+			os.Exit(0)
+		case strings.HasPrefix(path, "repos/owner/repo/commits/"):
+			fmt.Fprint(os.Stderr, "gh: Not Found (HTTP 404)")
+			os.Exit(1)
+		}
+	}
+	if len(args) > 3 && args[2] == "api" && slices.Contains(args, "repos/owner/repo/actions/workflows") {
+		if os.Getenv("STUB_NEW_WORKFLOWS") == "1" {
+			fmt.Println(".github/workflows/build.yml")
+		}
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "browse" && args[3] == "--actions" {
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "workflow" && args[3] == "run" && slices.Contains(args, "--ref") {
+		os.Exit(0)
+	}
+	if len(args) > 3 && args[2] == "api" && slices.Contains(args, "repos/owner/repo/check-runs/7/annotations") {
+		fmt.Print(stubAnnotations)
+		os.Exit(0)
+	}
+	fmt.Fprintf(os.Stderr, "unexpected gh call: %v", args[1:])
+	os.Exit(1)
+}
+
+// stubClient returns a client whose gh calls run TestHelperProcess
+func stubClient() *github.Client {
+	gh := github.NewClient("owner/repo")
+	gh.SetCommandFunc(stubCommand)
+	return gh
+}
+
+func stubCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmdArgs := append([]string{"-test.run=TestHelperProcess", "--", name}, args...)
+	cmd := exec.CommandContext(ctx, os.Args[0], cmdArgs...)
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+	return cmd
+}
+
+// navHarness feeds key presses to an App and runs the commands they return,
+// feeding the results back the way the Bubble Tea runtime would
+type navHarness struct {
+	t   *testing.T
+	app *App
+}
+
+func newNavHarness(t *testing.T) *navHarness {
+	t.Helper()
+	cfg := &config.Config{
+		Repository: "owner/repo",
+		Groups: []config.Group{
+			{
+				ID:           "ci",
+				Name:         "CI",
+				WorkflowDefs: []config.Workflow{{File: "build.yml", Name: "Build"}},
+				Groups: []config.Group{
+					{ID: "nightly", Name: "Nightly", Workflows: []string{"nightly.yml"}},
+				},
+			},
+			{ID: "deploy", Name: "Deploy", Workflows: []string{"deploy.yml"}},
+		},
+	}
+	app := NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), stubClient(), AppOptions{
+		StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+	})
+	h := &navHarness{t: t, app: app}
+	h.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return h
+}
+
+// press sends each key in order. Names such as "enter" and "esc" map to
+// their key types; anything else is typed as runes.
+func (h *navHarness) press(keys ...string) {
+	h.t.Helper()
+	special := map[string]tea.KeyType{
+		"enter":     tea.KeyEnter,
+		"esc":       tea.KeyEsc,
+		"backspace": tea.KeyBackspace,
+		"tab":       tea.KeyTab,
+		"ctrl+l":    tea.KeyCtrlL,
+		"space":     tea.KeySpace,
+	}
+	for _, key := range keys {
+		if keyType, ok := special[key]; ok {
+			h.send(tea.KeyMsg{Type: keyType})
+		} else {
+			h.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+}
+
+func (h *navHarness) send(msg tea.Msg) {
+	_, cmd := h.app.Update(msg)
+	h.drain(cmd, 0)
+}
+
+// drain runs cmd and feeds its messages back into the app. Commands that do
+// not finish promptly are timers (toasts, refresh ticks) and are dropped,
+// as are spinner animation frames.
+func (h *navHarness) drain(cmd tea.Cmd, depth int) {
+	if cmd == nil || depth > 10 {
+		return
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(200 * time.Millisecond):
+		return
+	}
+
+	switch msg := msg.(type) {
+	case nil, spinner.TickMsg:
+	case tea.BatchMsg:
+		for _, c := range msg {
+			h.drain(c, depth+1)
+		}
+	default:
+		_, next := h.app.Update(msg)
+		h.drain(next, depth+1)
+	}
+}
+
+func (h *navHarness) assertGroupPath(want ...string) {
+	h.t.Helper()
+	var got []string
+	for _, g := range h.app.groupPath {
+		got = append(got, g.ID)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		h.t.Fatalf("groupPath = %v, want %v", got, want)
+	}
+}
+
+func (h *navHarness) assertViewMode(want ViewMode) {
+	h.t.Helper()
+	if h.app.viewMode != want {
+		h.t.Fatalf("viewMode = %v, want %v", h.app.viewMode, want)
+	}
+}
+
+func TestNavigateNestedGroupsAndBack(t *testing.T) {
+	h := newNavHarness(t)
+	h.assertGroupPath()
+
+	h.press("enter")
+	h.assertGroupPath("ci")
+
+	// Build is listed first, then the Nightly subgroup
+	h.press("j", "enter")
+	h.assertGroupPath("ci", "nightly")
+	h.assertViewMode(ViewGroups)
+
+	h.press("h")
+	h.assertGroupPath("ci")
+	h.press("esc")
+	h.assertGroupPath()
+}
+
+func TestFilterAndEnterGroup(t *testing.T) {
+	h := newNavHarness(t)
+
+	h.press("/", "d", "e", "p", "enter")
+	if item := h.app.navList.SelectedItem(); item == nil || item.ID != "deploy" {
+		t.Fatalf("expected Deploy to be selected after filtering, got %+v", item)
+	}
+
+	h.press("enter")
+	h.assertGroupPath("deploy")
+}
+
+func TestSelectWorkflowLoadsRuns(t *testing.T) {
+	h := newNavHarness(t)
+
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+	if h.app.selectedWorkflow != "build.yml" {
+		t.Fatalf("selectedWorkflow = %q, want build.yml", h.app.selectedWorkflow)
+	}
+	if len(h.app.workflowRuns) != 2 || h.app.workflowRuns[0].DatabaseID != 2 {
+		t.Fatalf("expected stubbed runs newest first, got %+v", h.app.workflowRuns)
+	}
+
+	h.press("esc")
+	h.assertViewMode(ViewGroups)
+	h.assertGroupPath("ci")
+}
+
+func TestLeavingWorkflowCancelsRunsFetch(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	// Start a refresh but go back before its gh call runs
+	h.app.loading = true
+	fetch := h.app.fetchWorkflowRuns()
+	h.press("esc")
+	h.assertViewMode(ViewGroups)
+	if h.app.loading {
+		t.Error("expected leaving the workflow to stop loading")
+	}
+
+	msg, ok := fetch().(workflowRunsMsg)
+	if !ok || !errors.Is(msg.err, context.Canceled) {
+		t.Fatalf("expected the fetch to be cancelled, got %+v", msg)
+	}
+	h.send(msg)
+	if h.app.err != nil {
+		t.Errorf("expected a cancelled fetch not to be reported, got %v", h.app.err)
+	}
+}
+
+func TestStaleRunsAreIgnored(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	// Runs of the previously selected workflow arrive after its successor's
+	h.app.loading = true
+	h.send(workflowRunsMsg{workflow: "deploy.yml", runs: []models.GHRun{{DatabaseID: 99}}})
+	if len(h.app.workflowRuns) != 2 || h.app.workflowRuns[0].DatabaseID != 2 {
+		t.Fatalf("expected build.yml's runs to stay, got %+v", h.app.workflowRuns)
+	}
+	if !h.app.loading {
+		t.Error("expected stale runs not to end loading")
+	}
+	if _, ok := h.app.detailsRuns["deploy.yml"]; ok {
+		t.Error("expected stale runs not to be cached as deploy.yml's")
+	}
+
+	h.send(workflowRunsMsg{workflow: "build.yml", runs: []models.GHRun{{DatabaseID: 3}}})
+	if len(h.app.workflowRuns) != 1 || h.app.workflowRuns[0].DatabaseID != 3 || h.app.loading {
+		t.Errorf("expected the current workflow's runs to apply, got %+v", h.app.workflowRuns)
+	}
+}
+
+func TestRunsArrivingAfterLeavingAreIgnored(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	h.press(":", "b", "a", "c", "k", "enter")
+	h.assertViewMode(ViewGroups)
+	if h.app.loading {
+		t.Error("expected leaving the runs to stop loading")
+	}
+
+	h.send(workflowRunsMsg{workflow: "build.yml", runs: []models.GHRun{{DatabaseID: 99}}})
+	if h.app.viewMode != ViewGroups || h.app.runsTable.SelectedRunID() == 99 {
+		t.Error("expected runs arriving after leaving not to reopen the table")
+	}
+	if run := h.app.latestRuns["build.yml"]; run != nil && run.DatabaseID == 99 {
+		t.Error("expected runs arriving after leaving not to be cached")
+	}
+}
+
+func TestEnterGroupOpensDefaultWorkflow(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.config.Groups[1].DefaultWorkflow = "deploy.yml"
+
+	h.press("j", "enter")
+	h.assertViewMode(ViewRuns)
+	if h.app.selectedWorkflow != "deploy.yml" {
+		t.Fatalf("selectedWorkflow = %q, want deploy.yml", h.app.selectedWorkflow)
+	}
+
+	h.press("esc")
+	h.assertViewMode(ViewGroups)
+	h.assertGroupPath("deploy")
+}
+
+func TestTagRuns(t *testing.T) {
+	dir := t.TempDir()
+	paths.SetBaseDir(dir)
+	t.Cleanup(func() { paths.SetBaseDir("") })
+
+	h := newNavHarness(t)
+	h.press("enter", "enter", "T", "f", "l", "a", "k", "y", "enter")
+	if view := h.app.View(); !strings.Contains(view, "[flaky]") {
+		t.Fatalf("expected the tag as a badge, got:\n%s", view)
+	}
+	saved, err := state.LoadTags(filepath.Join(dir, "state", "owner_repo.tags.yaml"))
+	if err != nil || !slices.Equal(saved[2], []string{"flaky"}) {
+		t.Fatalf("expected run #2 tagged in the tags file, got %v (%v)", saved, err)
+	}
+
+	h.press("#", "enter")
+	if runs := h.app.runsTable.VisibleRuns(); len(runs) != 1 || runs[0].DatabaseID != 2 {
+		t.Errorf("expected only the tagged run shown, got %+v", runs)
+	}
+	h.press("#")
+	if len(h.app.runsTable.VisibleRuns()) != 2 {
+		t.Error("expected # to clear the tag filter")
+	}
+
+	// Picking a tag the run has removes it
+	h.press("T", "enter")
+	if len(h.app.runTags) != 0 {
+		t.Errorf("expected the tag removed, got %v", h.app.runTags)
+	}
+}
+
+func TestShowRunAnnotations(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	h.press("n")
+	view := h.app.View()
+	for _, want := range []string{"Annotations · run #2", "main.go:12 · lint", "undefined: foo", "1 errors", "1 warnings"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in the annotations overlay, got:\n%s", want, view)
+		}
+	}
+
+	h.press("esc")
+	if view := h.app.View(); !strings.Contains(view, "✗1 !1 Second") {
+		t.Errorf("expected the annotation counts in the runs table, got:\n%s", view)
+	}
+}
+
+func TestSaveRunLog(t *testing.T) {
+	dir := t.TempDir()
+	paths.SetBaseDir(dir)
+	t.Cleanup(func() { paths.SetBaseDir("") })
+
+	h := newNavHarness(t)
+	h.press("enter", "enter", "S")
+	if h.app.err != nil {
+		t.Fatalf("unexpected error: %v", h.app.err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "cache", "logs", "run-2.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != stubLog {
+		t.Errorf("saved log = %q, want %q", data, stubLog)
+	}
+}
+
+func TestHealthKeywordFilter(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.latestRuns["build.yml"] = &models.GHRun{Status: "completed", Conclusion: "success"}
+	h.app.latestRuns["deploy.yml"] = &models.GHRun{Status: "completed", Conclusion: "failure"}
+	h.app.refreshNavList()
+
+	filtered := func() []string {
+		var ids []string
+		for _, item := range h.app.navList.FilteredItems() {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	h.press("/", "f", "a", "i", "l", "i", "n", "g", "enter")
+	if got := filtered(); fmt.Sprint(got) != "[deploy]" {
+		t.Fatalf("failing filter = %v, want [deploy]", got)
+	}
+	if view := h.app.View(); !strings.Contains(view, "Showing failing workflows") {
+		t.Errorf("expected the health filter to be indicated, got:\n%s", view)
+	}
+
+	// CI matches through its Build workflow; Nightly has no cached run
+	h.press("esc", "/", "p", "a", "s", "s", "i", "n", "g", "enter", "enter")
+	h.assertGroupPath("ci")
+	h.press("/", "p", "a", "s", "s", "i", "n", "g", "enter")
+	if got := filtered(); fmt.Sprint(got) != "[build.yml]" {
+		t.Fatalf("passing filter inside CI = %v, want [build.yml]", got)
+	}
+
+	// Anything else is still a fuzzy name filter
+	h.press("esc", "/", "n", "i", "g", "h", "t")
+	if got := filtered(); fmt.Sprint(got) != "[nightly]" {
+		t.Fatalf("fuzzy filter = %v, want [nightly]", got)
+	}
+}
+
+func TestHealthViewUnknownBucket(t *testing.T) {
+	t.Setenv("STUB_FAIL_WORKFLOW", "deploy.yml")
+	h := newNavHarness(t)
+
+	h.press("v")
+	if h.app.err == nil || !strings.Contains(h.app.err.Error(), "deploy.yml") {
+		t.Errorf("expected the failed fetch to be reported, got %v", h.app.err)
+	}
+	if view := h.app.View(); !strings.Contains(view, "Could not check 1 workflows") || !strings.Contains(view, "Unknown") {
+		t.Errorf("expected a warning and an Unknown bucket, got:\n%s", view)
+	}
+
+	var unknown *config.Group
+	for i := range h.app.healthGroups {
+		if h.app.healthGroups[i].ID == "health-unknown" {
+			unknown = &h.app.healthGroups[i]
+		}
+	}
+	if unknown == nil || fmt.Sprint(unknown.GetAllWorkflows()) != "[deploy.yml]" {
+		t.Fatalf("expected deploy.yml in the Unknown bucket, got %+v", unknown)
+	}
+	items := h.app.createWorkflowItems([]string{"deploy.yml"}, nil, false)
+	if !strings.Contains(items[0].Description, "HTTP 502") {
+		t.Errorf("expected the error as the workflow's description, got %q", items[0].Description)
+	}
+
+	// A later successful fetch moves the workflow out of Unknown
+	h.app.cacheLatestRun("deploy.yml", nil)
+	if _, failed := h.app.healthErrors["deploy.yml"]; failed {
+		t.Error("expected the error cleared once the workflow's runs were fetched")
+	}
+}
+
+func TestJumpToGroup(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	h.send(tea.KeyMsg{Type: tea.KeyCtrlG})
+	h.press("c", "i", "n", "i")
+	if view := h.app.View(); !strings.Contains(view, "→ CI > Nightly") {
+		t.Fatalf("expected the resolved path to be previewed, got:\n%s", view)
+	}
+
+	h.press("enter")
+	h.assertGroupPath("ci", "nightly")
+	h.assertViewMode(ViewGroups)
+}
+
+func TestStatusBarShowsFilterCounts(t *testing.T) {
+	h := newNavHarness(t)
+
+	h.press("/", "d", "e", "p")
+	if view := h.app.View(); !strings.Contains(view, "showing 1 of 2") {
+		t.Fatalf("expected the filtered count in the status bar, got:\n%s", view)
+	}
+
+	h.press("esc")
+	if view := h.app.View(); strings.Contains(view, "showing") {
+		t.Errorf("expected the count to clear with the filter, got:\n%s", view)
+	}
+}
+
+func TestFilterMovesCursorToFirstMatch(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "j")
+	if h.app.navList.Cursor() != 1 {
+		t.Fatalf("expected the cursor on the second item, got %d", h.app.navList.Cursor())
+	}
+
+	// Both Build and Nightly match, so the old index would still be valid
+	h.press("/", "l")
+	if len(h.app.navList.FilteredItems()) != 2 || h.app.navList.Cursor() != 0 {
+		t.Fatalf("expected the cursor on the first of 2 matches, got %d of %d",
+			h.app.navList.Cursor(), len(h.app.navList.FilteredItems()))
+	}
+}
+
+func TestAutoOpenSingleMatch(t *testing.T) {
+	h := newNavHarness(t)
+
+	h.press("/", "d", "e", "p", "enter")
+	h.assertGroupPath()
+
+	h.app.autoOpenMatch = true
+	h.press("esc", "/", "d", "e", "p", "enter")
+	h.assertGroupPath("deploy")
+}
+
+func TestClearPinsAsksFirst(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "p")
+	if len(h.app.config.GetAllPinnedWorkflows()) != 1 {
+		t.Fatal("expected Build to be pinned")
+	}
+
+	clearPins := func() {
+		h.press(":")
+		h.press(strings.Split("clear-pins", "")...)
+		h.press("enter")
+	}
+
+	clearPins()
+	if view := h.app.View(); !strings.Contains(view, "Clear all pins") {
+		t.Fatalf("expected a confirmation dialog, got:\n%s", view)
+	}
+	h.press("n")
+	if len(h.app.config.GetAllPinnedWorkflows()) != 1 {
+		t.Fatal("expected declining to keep the pin")
+	}
+
+	clearPins()
+	h.press("y")
+	if len(h.app.config.GetAllPinnedWorkflows()) != 0 || len(h.app.sidebar.Items()) != 0 {
+		t.Fatal("expected confirming to clear the pin and the sidebar")
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "j")
+	h.assertGroupPath("ci")
+
+	edited := `repository: other/repo
+groups:
+  - id: ci
+    name: CI
+    workflows: [build.yml, lint.yml]
+  - id: deploy
+    name: Deploy
+    workflows: [deploy.yml]
+`
+	if err := os.WriteFile(h.app.configPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	h.press("R")
+	h.assertGroupPath("ci")
+	if h.app.config.Repository != "owner/repo" {
+		t.Errorf("expected the viewed repository to stay, got %s", h.app.config.Repository)
+	}
+	var names []string
+	for _, item := range h.app.navList.Items() {
+		names = append(names, item.Title)
+	}
+	if !slices.Equal(names, []string{"build.yml", "lint.yml"}) {
+		t.Errorf("expected the reloaded workflows, got %v", names)
+	}
+	if h.app.navList.Cursor() != 1 {
+		t.Errorf("expected the cursor to stay on the second item, got %d", h.app.navList.Cursor())
+	}
+
+	if err := os.WriteFile(h.app.configPath, []byte("repository: owner/repo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	h.press("R")
+	if h.app.err == nil || len(h.app.config.Groups) != 2 {
+		t.Error("expected an invalid config to be reported and the current one kept")
+	}
+}
+
+func TestFavoriteGroups(t *testing.T) {
+	h := newNavHarness(t)
+	titles := func() []string {
+		var names []string
+		for _, item := range h.app.navList.Items() {
+			names = append(names, item.Title)
+		}
+		return names
+	}
+
+	h.press("j", "f")
+	if got := titles(); !slices.Equal(got, []string{"Deploy", "CI"}) {
+		t.Fatalf("expected the starred group first, got %v", got)
+	}
+	if item := h.app.navList.SelectedItem(); item == nil || item.Title != "Deploy" {
+		t.Errorf("expected the cursor to follow the starred group, got %v", item)
+	}
+
+	// A nested favorite is listed at the root and opens at its full path
+	h.press("j", "enter", "j", "f", "h")
+	if got := titles(); !slices.Equal(got, []string{"Deploy", "Nightly", "CI"}) {
+		t.Fatalf("expected the nested favorite at the root, got %v", got)
+	}
+	h.press("g", "j", "enter")
+	h.assertGroupPath("ci", "nightly")
+
+	saved, err := config.LoadFromPath(h.app.pinConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(saved.GetFavoriteGroups(), []string{"deploy", "nightly"}) {
+		t.Errorf("expected favorites saved to the user config, got %v", saved.GetFavoriteGroups())
+	}
+}
+
+func TestHideWorkflow(t *testing.T) {
+	h := newNavHarness(t)
+	workflows := func() []string {
+		var files []string
+		for _, item := range h.app.navList.Items() {
+			if data := item.Data.(*navItemData); !data.isGroup {
+				files = append(files, data.workflowName)
+			}
+		}
+		return files
+	}
+
+	h.press("enter", "H")
+	if got := workflows(); len(got) != 0 {
+		t.Fatalf("expected build.yml to be hidden, got %v", got)
+	}
+	if view := h.app.View(); !strings.Contains(view, "1 hidden") {
+		t.Errorf("expected the hidden count in the status bar, got:\n%s", view)
+	}
+	for _, result := range h.app.performGlobalSearch("build") {
+		if result.WorkflowName == "build.yml" {
+			t.Error("expected the hidden workflow to be left out of search")
+		}
+	}
+
+	h.press(".")
+	if got := workflows(); !slices.Equal(got, []string{"build.yml"}) {
+		t.Fatalf("expected hidden workflows to be shown, got %v", got)
+	}
+	if item := h.app.navList.Items()[0]; !strings.HasPrefix(item.Icon, h.app.theme.Icons.Hidden) {
+		t.Errorf("expected the hidden icon, got %q", item.Icon)
+	}
+
+	saved, err := config.LoadFromPath(h.app.pinConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(saved.GetHiddenWorkflows(), []string{"build.yml"}) {
+		t.Errorf("expected hidden workflows saved to the user config, got %v", saved.GetHiddenWorkflows())
+	}
+}
+
+func TestToggleFilenames(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "t", "/", "y", "m", "l")
+	if got := h.app.navList.FilteredItems(); len(got) != 1 || got[0].ID != "build.yml" {
+		t.Fatalf("expected the filter to match the shown filename, got %v", got)
+	}
+	if !strings.Contains(h.app.View(), "build.yml") {
+		t.Errorf("expected the filename to be shown")
+	}
+
+	saved, err := state.Load(h.app.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.ShowFilenames {
+		t.Error("expected the filename toggle to be saved")
+	}
+
+	h.press("esc", "t")
+	if h.app.showFilenames {
+		t.Error("expected t to switch back to display names")
+	}
+}
+
+func TestToggleDescriptions(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter")
+	if !strings.Contains(h.app.navList.View(), "build.yml") {
+		t.Fatal("expected the workflow description to be shown")
+	}
+
+	h.press("D")
+	if strings.Contains(h.app.navList.View(), "build.yml") {
+		t.Error("expected D to hide the workflow description")
+	}
+	if !strings.Contains(h.app.navList.View(), "Build") {
+		t.Error("expected the workflow title to stay")
+	}
+
+	h.press("D")
+	if !strings.Contains(h.app.navList.View(), "build.yml") {
+		t.Error("expected D to show the description again")
+	}
+}
+
+func TestPeekLatestRun(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "i")
+	h.assertViewMode(ViewGroups)
+	if run := h.app.latestRuns["build.yml"]; run == nil || run.DatabaseID != 2 {
+		t.Fatalf("expected the newest run cached, got %+v", run)
+	}
+	if view := h.app.View(); !strings.Contains(view, "build.yml: ✓ success on main") {
+		t.Errorf("expected a summary of the latest run, got:\n%s", view)
+	}
+}
+
+func TestRerunFailedAndFollow(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.rerunPoll = time.Millisecond
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	h.press("F")
+	if h.app.confirm.IsActive() {
+		t.Fatal("expected no confirmation for a successful run")
+	}
+
+	h.press("j", "F", "y")
+	if h.app.followed.id != 0 {
+		t.Fatalf("expected the re-run to be followed until it finished, still following #%d", h.app.followed.id)
+	}
+	if view := h.app.View(); !strings.Contains(view, "Re-run of #1 passed") {
+		t.Errorf("expected the re-run's result toasted, got:\n%s", view)
+	}
+}
+
+func TestRerunFollowsAttemptNotNewestRun(t *testing.T) {
+	// The newest run is another one still queued; the re-run of #1 is a new
+	// attempt of #1, so that is the run followed
+	t.Setenv("STUB_DISPATCHED", "1")
+	h := newNavHarness(t)
+	h.app.rerunPoll = time.Millisecond
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	h.press("j", "j", "F", "y")
+	if h.app.followed.id != 0 {
+		t.Fatalf("expected the re-run to be followed until it finished, still following #%d", h.app.followed.id)
+	}
+	if view := h.app.View(); !strings.Contains(view, "Re-run of #1 passed") {
+		t.Errorf("expected the re-run of #1 toasted, got:\n%s", view)
+	}
+}
+
+func TestOpenBehavior(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.openBehavior = config.OpenLogs
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	h.press("enter")
+	if !h.app.runView.IsActive() {
+		t.Fatal("expected enter to view the run's log")
+	}
+	if view := h.app.View(); !strings.Contains(view, "Log · Run #2") || !strings.Contains(view, "undefined: foo") {
+		t.Errorf("expected the log in the run view, got:\n%s", view)
+	}
+
+	h.press("esc", "J")
+	if view := h.app.View(); !strings.Contains(view, "Jobs · Run #2") || !strings.Contains(view, "lint") {
+		t.Errorf("expected J to show the run's jobs, got:\n%s", view)
+	}
+	h.press("esc")
+	if h.app.runView.IsActive() {
+		t.Error("expected esc to close the run view")
+	}
+}
+
+func TestPinMarkedWorkflows(t *testing.T) {
+	h := newNavHarness(t)
+	// Build is listed first, then the Nightly subgroup
+	h.press("enter", "j", "space")
+	if h.app.navList.MarkedCount() != 0 {
+		t.Fatal("expected a group not to be marked")
+	}
+
+	h.press("/", "b", "u", "enter", "space")
+	if !strings.Contains(h.app.navList.View(), "1 marked") {
+		t.Error("expected the marked count to be shown")
+	}
+	h.press("esc")
+	if h.app.navList.MarkedCount() != 0 || !h.app.navList.HasFilter() {
+		t.Fatal("expected esc to unmark before clearing the filter")
+	}
+
+	h.press("space", "p")
+	if !h.app.config.Groups[0].IsPinned("build.yml") {
+		t.Error("expected p to pin the marked workflow")
+	}
+	if h.app.navList.MarkedCount() != 0 {
+		t.Error("expected pinning to unmark the workflows")
+	}
+
+	// The first h clears the filter, the second goes back
+	h.press("space", "h", "h", "enter")
+	h.assertGroupPath("ci")
+	if h.app.navList.MarkedCount() != 0 {
+		t.Error("expected marks to be dropped when leaving the group")
+	}
+}
+
+func TestRememberFilters(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.rememberFilters = true
+
+	h.press("enter", "/", "b", "u", "enter", "h")
+	h.assertGroupPath()
+	if h.app.navList.HasFilter() {
+		t.Fatalf("expected the root list unfiltered, got %q", h.app.navList.FilterInput())
+	}
+
+	h.press("enter")
+	h.assertGroupPath("ci")
+	if got := h.app.navList.FilterInput(); got != "bu" {
+		t.Fatalf("expected the CI filter restored, got %q", got)
+	}
+
+	saved, err := state.Load(h.app.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Filters["ci"] != "bu" || saved.FiltersRepository != "owner/repo" {
+		t.Errorf("expected the filter saved for owner/repo, got %v for %q", saved.Filters, saved.FiltersRepository)
+	}
+	if got := loadFilters(h.app.statePath, "other/repo"); len(got) != 0 {
+		t.Errorf("expected filters of another repository to be dropped, got %v", got)
+	}
+
+	h.press("esc", "h", "enter")
+	if h.app.navList.HasFilter() {
+		t.Errorf("expected esc to forget the filter, got %q", h.app.navList.FilterInput())
+	}
+}
+
+func TestViewWorkflowSource(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "V")
+	if !h.app.sourceView.IsActive() {
+		t.Fatal("expected the source view to open")
+	}
+	view := h.app.View()
+	for _, want := range []string{"Source · build.yml", "workflow_dispatch:", "5 "} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the source view, got:\n%s", want, view)
+		}
+	}
+	if h.app.workflowSources["build.yml"] != stubWorkflow {
+		t.Errorf("expected the source to be cached, got %q", h.app.workflowSources["build.yml"])
+	}
+
+	h.press("esc")
+	if h.app.sourceView.IsActive() {
+		t.Error("expected esc to close the source view")
+	}
+}
+
+func TestSwitchToLocalRepository(t *testing.T) {
+	h := newNavHarness(t)
+	if strings.Contains(h.app.View(), "local repo") {
+		t.Fatal("expected no warning without a local repository")
+	}
+
+	h.app.localRepository = "Owner/Repo"
+	if strings.Contains(h.app.View(), "local repo") {
+		t.Fatal("expected no warning when the local repository is the one viewed")
+	}
+
+	h.app.localRepository = "owner/local"
+	if view := h.app.View(); !strings.Contains(view, "local repo is owner/local") {
+		t.Fatalf("expected a warning about the local repository, got:\n%s", view)
+	}
+
+	h.press("ctrl+l", "esc")
+	if h.app.SwitchRepository() != "" {
+		t.Fatal("expected no switch when the confirmation is dismissed")
+	}
+	h.press("ctrl+l", "y")
+	if got := h.app.SwitchRepository(); got != "owner/local" {
+		t.Errorf("expected a switch to owner/local, got %q", got)
+	}
+}
+
+func TestOpenPinnedAtStartup(t *testing.T) {
+	cfg := &config.Config{
+		Repository: "owner/repo",
+		Groups: []config.Group{
+			{ID: "ci", Name: "CI", Workflows: []string{"build.yml", "lint.yml"}, PinnedWorkflows: []string{"build.yml", "lint.yml"}},
+		},
+	}
+	app := NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), stubClient(), AppOptions{
+		StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+		OpenPinned:     2,
+	})
+	h := &navHarness{t: t, app: app}
+	h.drain(app.Init(), 0)
+
+	h.assertViewMode(ViewRuns)
+	if app.selectedWorkflow != "lint.yml" {
+		t.Errorf("expected the second pin opened, got %q", app.selectedWorkflow)
+	}
+	if app.loading || len(app.workflowRuns) == 0 {
+		t.Error("expected the runs to be fetched on startup")
+	}
+}
+
+func TestOpenWorkflowAtStartup(t *testing.T) {
+	cfg := &config.Config{
+		Repository: "owner/repo",
+		Groups: []config.Group{
+			{ID: "ci", Name: "CI", Workflows: []string{"build.yml", "lint.yml"}},
+		},
+	}
+	app := NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), stubClient(), AppOptions{
+		StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+		OpenWorkflow:   "lint.yml",
+	})
+	h := &navHarness{t: t, app: app}
+	h.drain(app.Init(), 0)
+
+	h.assertViewMode(ViewRuns)
+	if app.selectedWorkflow != "lint.yml" {
+		t.Errorf("expected lint.yml opened, got %q", app.selectedWorkflow)
+	}
+	if app.loading || len(app.workflowRuns) == 0 {
+		t.Error("expected the runs to be fetched on startup")
+	}
+}
+
+func TestDuplicateWorkflowKeepsItsGroup(t *testing.T) {
+	newConfig := func() *config.Config {
+		return &config.Config{
+			Repository: "owner/repo",
+			Groups: []config.Group{
+				{ID: "ci", Name: "CI", Workflows: []string{"shared.yml"}},
+				{ID: "release", Name: "Release", Workflows: []string{"shared.yml"}},
+			},
+		}
+	}
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	app := NewApp(newConfig(), configPath, stubClient(), AppOptions{StatePath: statePath, NoRestoreState: true, NoAutoOpen: true})
+	h := &navHarness{t: t, app: app}
+	h.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	h.press("j", "enter", "p")
+	if app.config.FindGroupByID("ci").IsPinned("shared.yml") || !app.config.FindGroupByID("release").IsPinned("shared.yml") {
+		t.Fatal("expected only the release group's copy pinned")
+	}
+
+	h.press("tab", "enter")
+	if app.selectedGroup == nil || app.selectedGroup.ID != "release" {
+		t.Fatalf("expected the release group's pin opened, got %+v", app.selectedGroup)
+	}
+	app.shutdown()
+
+	restored := NewApp(newConfig(), configPath, stubClient(), AppOptions{StatePath: statePath})
+	if restored.selectedWorkflow != "shared.yml" {
+		t.Fatalf("expected shared.yml restored, got %q", restored.selectedWorkflow)
+	}
+	if restored.selectedGroup == nil || restored.selectedGroup.ID != "release" {
+		t.Errorf("expected the release group restored, got %+v", restored.selectedGroup)
+	}
+}
+
+func TestAutoOpenSingleWorkflow(t *testing.T) {
+	newApp := func(noAutoOpen bool) *App {
+		cfg := &config.Config{
+			Repository: "owner/repo",
+			Groups: []config.Group{
+				{ID: "ci", Name: "CI", Groups: []config.Group{
+					{ID: "build", Name: "Build", Workflows: []string{"build.yml"}},
+				}},
+			},
+		}
+		return NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), stubClient(), AppOptions{
+			StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+			NoRestoreState: true,
+			NoAutoOpen:     noAutoOpen,
+		})
+	}
+
+	app := newApp(false)
+	h := &navHarness{t: t, app: app}
+	h.drain(app.Init(), 0)
+	h.assertViewMode(ViewRuns)
+	if app.selectedWorkflow != "build.yml" || len(app.workflowRuns) == 0 {
+		t.Fatalf("expected the only workflow's runs opened, got %q", app.selectedWorkflow)
+	}
+	h.press("esc")
+	h.assertGroupPath("ci", "build")
+
+	h = &navHarness{t: t, app: newApp(true)}
+	h.assertViewMode(ViewGroups)
+	h.assertGroupPath()
+}
+
+func TestRunsHeaderShowsWorkflowInfo(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+
+	view := h.app.View()
+	for _, want := range []string{".github/workflows/build.yml", "on: push, workflow_dispatch", "[x]dispatch"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the runs view, got:\n%s", want, view)
+		}
+	}
+	if info := h.app.workflowInfo["build.yml"]; info == nil || !info.CanDispatch() {
+		t.Errorf("expected the workflow info to be cached, got %+v", info)
+	}
+}
+
+func TestDispatchDisabledWithoutTrigger(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.workflowInfo["build.yml"] = &models.WorkflowInfo{File: "build.yml", Triggers: []string{"push"}}
+
+	h.press("enter", "enter")
+	h.assertViewMode(ViewRuns)
+	if view := h.app.View(); strings.Contains(view, "[x]dispatch") {
+		t.Errorf("expected no dispatch hint for a workflow without workflow_dispatch, got:\n%s", view)
+	}
+
+	h.press("x")
+	if view := h.app.View(); !strings.Contains(view, "no workflow_dispatch trigger") {
+		t.Errorf("expected a toast explaining why, got:\n%s", view)
+	}
+	if h.app.dispatchForm.IsActive() {
+		t.Error("expected the dispatch form to stay closed")
+	}
+}
+
+func TestDispatchOnRef(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", "x")
+	if !h.app.branchPicker.IsActive() {
+		t.Fatal("expected the branch picker to open")
+	}
+	if want := []string{"main", "feature/x"}; !slices.Equal(h.app.branches, want) {
+		t.Fatalf("expected branches %v with the default first, got %v", want, h.app.branches)
+	}
+
+	// A ref matching no branch is used as typed, and reported as unknown
+	h.press("n", "o", "p", "e", "enter")
+	if !h.app.dispatchForm.IsActive() {
+		t.Fatal("expected the dispatch form to open")
+	}
+	if view := h.app.View(); !strings.Contains(view, "nope█") {
+		t.Fatalf("expected the typed ref, got:\n%s", view)
+	}
+	h.press("enter")
+	if h.app.err == nil || !strings.Contains(h.app.err.Error(), `"nope"`) {
+		t.Fatalf("expected an unknown ref error, got %v", h.app.err)
+	}
+
+	h.app.err = nil
+	h.press("x", "f", "e", "a", "t", "enter", "enter")
+	if h.app.err != nil {
+		t.Fatalf("unexpected dispatch error: %v", h.app.err)
+	}
+	if got := h.app.dispatchRefs["build.yml"]; got != "feature/x" {
+		t.Errorf("expected the ref to be remembered, got %q", got)
+	}
+
+	// The remembered ref is highlighted next time
+	h.press("x", "enter")
+	if view := h.app.View(); !strings.Contains(view, "feature/x█") {
+		t.Errorf("expected the last ref to be preselected, got:\n%s", view)
+	}
+}
+
+func TestOpenDispatchedRun(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.newRunPoll = time.Millisecond
+	// Runs listed after the dispatch include the run it started
+	dispatched := false
+	h.app.gh.SetCommandFunc(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		cmd := stubCommand(ctx, name, args...)
+		if dispatched {
+			cmd.Env = append(cmd.Env, "STUB_DISPATCHED=1")
+		}
+		dispatched = dispatched || slices.Equal(args[:2], []string{"workflow", "run"})
+		return cmd
+	})
+
+	h.press("enter", "x", "f", "e", "a", "t", "enter", "enter")
+	if h.app.offer.id != 3 {
+		t.Fatalf("expected the dispatched run to be offered, got %+v", h.app.offer)
+	}
+	if view := h.app.View(); !strings.Contains(view, "Run #3 of build.yml started") {
+		t.Errorf("expected a toast offering the run, got:\n%s", view)
+	}
+
+	h.send(tea.KeyMsg{Type: tea.KeyCtrlO})
+	h.assertViewMode(ViewRuns)
+	if got := h.app.runsTable.SelectedRunID(); got != 3 {
+		t.Errorf("expected ctrl+o to highlight the new run, got #%d", got)
+	}
+	if h.app.offer.id != 0 {
+		t.Error("expected the offer to be used up")
+	}
+}
+
+func TestDispatchedRunNotFound(t *testing.T) {
+	h := newNavHarness(t)
+	h.app.newRunPoll = time.Millisecond
+	h.app.newRunWait = time.Millisecond
+
+	h.press("enter", "x", "f", "e", "a", "t", "enter", "enter")
+	if h.app.offer.id != 0 {
+		t.Errorf("expected no run to be offered, got %+v", h.app.offer)
+	}
+	if view := h.app.View(); !strings.Contains(view, "has not shown up yet") {
+		t.Errorf("expected a toast saying the run was not found, got:\n%s", view)
+	}
+}
+
+func TestOpenGroupActions(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("w")
+	if view := h.app.View(); !strings.Contains(view, "Opened Actions in browser") {
+		t.Errorf("expected w on a group to open the Actions tab, got:\n%s", view)
+	}
+
+	// Inside a group, w on a subgroup does the same
+	h = newNavHarness(t)
+	h.press("enter", "j", "w")
+	if view := h.app.View(); !strings.Contains(view, "Opened Actions in browser") {
+		t.Errorf("expected w on a subgroup to open the Actions tab, got:\n%s", view)
+	}
+}
+
+func TestNoWorkflowsYet(t *testing.T) {
+	cfg := &config.Config{
+		Repository: "owner/repo",
+		Groups:     []config.Group{{ID: "workflows", Name: "Workflows", Description: "All workflows"}},
+	}
+	app := NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), stubClient(), AppOptions{
+		StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+	})
+	h := &navHarness{t: t, app: app}
+	h.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	if view := h.app.View(); !strings.Contains(view, "No workflows yet") || !strings.Contains(view, "rivet init") {
+		t.Errorf("expected guidance for a repository without workflows, got:\n%s", view)
+	}
+
+	h.send(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if view := h.app.View(); !strings.Contains(view, "Still no workflows in owner/repo") {
+		t.Errorf("expected ctrl+r to find no workflows yet, got:\n%s", view)
+	}
+
+	t.Setenv("STUB_NEW_WORKFLOWS", "1")
+	h.send(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if view := h.app.View(); !strings.Contains(view, "Found build.yml") {
+		t.Errorf("expected ctrl+r to find the new workflow, got:\n%s", view)
+	}
+}
+
+func TestStickyCommandPalette(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter", ":")
+	h.send(tea.KeyMsg{Type: tea.KeyCtrlS})
+	h.press("p", "i", "n", "enter")
+	if !h.app.config.Groups[0].IsPinned("build.yml") {
+		t.Error("expected the pin command to run")
+	}
+	if !h.app.cmdPalette.IsActive() || !strings.Contains(h.app.View(), "Ran pin") {
+		t.Fatal("expected a sticky palette to stay open after pin")
+	}
+
+	// Commands that open something else close it anyway
+	h.press("s", "e", "a", "r", "c", "h", "enter")
+	if h.app.cmdPalette.IsActive() || !h.app.search.IsActive() {
+		t.Fatal("expected search to replace the palette")
+	}
+	h.press("esc", ":")
+	h.send(tea.KeyMsg{Type: tea.KeyCtrlS})
+	h.press("p", "i", "n", "enter")
+	if h.app.cmdPalette.IsActive() {
+		t.Fatal("expected the palette to close once sticky mode is off")
+	}
+
+	// alt+enter keeps it open for one command
+	h.press(":", "p", "i", "n")
+	h.send(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	if !h.app.cmdPalette.IsActive() {
+		t.Fatal("expected alt+enter to keep the palette open")
+	}
+	h.press("esc")
+	if h.app.cmdPalette.IsActive() {
+		t.Error("expected esc to close the palette")
+	}
+}
+
+func TestSortMode(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_COLLATE", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	saved := &state.NavigationState{OpenCounts: map[string]int{"zeta.yml": 5, "check.yml": 2, "build.yml": 1}}
+	if err := saved.Save(statePath); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		mode      string
+		root      []string
+		alpha     []string
+		searchFor []string
+	}{
+		{
+			mode:      config.SortConfig,
+			root:      []string{"Zeta", "Édition", "alpha"},
+			alpha:     []string{"release.yml", "check.yml", "Build", "Nested", "more"},
+			searchFor: []string{"Zeta", "Édition", "alpha", "Nested", "more"},
+		},
+		{
+			mode:      config.SortAlpha,
+			root:      []string{"alpha", "Édition", "Zeta"},
+			alpha:     []string{"release.yml", "Build", "check.yml", "more", "Nested"},
+			searchFor: []string{"alpha", "more", "Nested", "Édition", "Zeta"},
+		},
+		{
+			mode:      config.SortFrequency,
+			root:      []string{"Zeta", "alpha", "Édition"},
+			alpha:     []string{"release.yml", "check.yml", "Build", "Nested", "more"},
+			searchFor: []string{"Zeta", "alpha", "Nested", "more", "Édition"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cfg := &config.Config{
+				Repository:  "owner/repo",
+				Preferences: &config.Preferences{SortMode: tt.mode},
+				Groups: []config.Group{
+					{ID: "zeta", Name: "Zeta", Workflows: []string{"zeta.yml"}},
+					{ID: "edition", Name: "Édition", Workflows: []string{"edition.yml"}},
+					{
+						ID:              "alpha",
+						Name:            "alpha",
+						Workflows:       []string{"release.yml", "check.yml"},
+						WorkflowDefs:    []config.Workflow{{File: "build.yml", Name: "Build"}},
+						PinnedWorkflows: []string{"release.yml"},
+						Groups: []config.Group{
+							{ID: "nested", Name: "Nested", Workflows: []string{"nested.yml"}},
+							{ID: "more", Name: "more", Workflows: []string{"more.yml"}},
+						},
+					},
+				},
+			}
+			app := NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), stubClient(), AppOptions{
+				StatePath:      statePath,
+				NoRestoreState: true,
+			})
+
+			if got := itemTitles(app); !slices.Equal(got, tt.root) {
+				t.Errorf("root = %v, want %v", got, tt.root)
+			}
+			app.enterGroupPath([]*config.Group{cfg.FindGroupByID("alpha")})
+			if got := itemTitles(app); !slices.Equal(got, tt.alpha) {
+				t.Errorf("alpha = %v, want %v (pins first)", got, tt.alpha)
+			}
+
+			var groups []string
+			for _, result := range app.groupSearchIndex().Search("") {
+				if result.Type == "group" {
+					groups = append(groups, result.Name)
+				}
+			}
+			if !slices.Equal(groups, tt.searchFor) {
+				t.Errorf("search lists groups %v, want %v", groups, tt.searchFor)
+			}
+		})
+	}
+}
+
+func itemTitles(app *App) []string {
+	var titles []string
+	for _, item := range app.navList.Items() {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestNavItemsReusedUntilInvalidated(t *testing.T) {
+	h := newNavHarness(t)
+	h.press("enter")
+	built := h.app.navList.Items()
+
+	h.press("esc", "enter")
+	if got := h.app.navList.Items(); &got[0] != &built[0] {
+		t.Error("expected the group's items to be reused")
+	}
+
+	h.press("p")
+	if got := h.app.navList.Items(); &got[0] == &built[0] {
+		t.Error("expected pinning to rebuild the group's items")
+	}
+	if got := h.app.navList.Items()[0].Data.(*navItemData); !got.isPinned {
+		t.Error("expected the rebuilt item to be pinned")
+	}
+}
+
+func TestKeyLoggingSkipsTypedText(t *testing.T) {
+	var buf strings.Builder
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	h := newNavHarness(t)
+	h.press("/", "s", "e", "c", "r", "e", "t", "esc")
+
+	logged := buf.String()
+	if strings.Contains(logged, "key=s") || strings.Contains(logged, "key=/") {
+		t.Errorf("expected typed characters not to be logged, got:\n%s", logged)
+	}
+	if !strings.Contains(logged, "key=esc") {
+		t.Errorf("expected named keys to be logged, got:\n%s", logged)
+	}
+}
+
+// largeConfig returns groups top-level groups, each holding groups subgroups
+// of workflows workflows
+func largeConfig(groups, workflows int) *config.Config {
+	cfg := &config.Config{Repository: "owner/repo"}
+	for i := range groups {
+		group := config.Group{ID: fmt.Sprintf("g%d", i), Name: fmt.Sprintf("Group %d", i)}
+		for j := range groups {
+			sub := config.Group{ID: fmt.Sprintf("g%d-%d", i, j), Name: fmt.Sprintf("Group %d.%d", i, j)}
+			for k := range workflows {
+				sub.Workflows = append(sub.Workflows, fmt.Sprintf("wf-%d-%d-%d.yml", i, j, k))
+			}
+			group.Groups = append(group.Groups, sub)
+		}
+		cfg.Groups = append(cfg.Groups, group)
+	}
+	return cfg
+}
+
+func BenchmarkNavigateLargeConfig(b *testing.B) {
+	app := NewApp(largeConfig(50, 50), filepath.Join(b.TempDir(), "config.yaml"), github.NewClient("owner/repo"), AppOptions{
+		StatePath:      filepath.Join(b.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+	})
+	group := &app.config.Groups[0]
+
+	b.ResetTimer()
+	for range b.N {
+		app.groupPath = []*config.Group{group, &group.Groups[0]}
+		app.refreshNavList()
+		app.groupPath = nil
+		app.refreshNavList()
+	}
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+const (
+	// newRunPollInterval and newRunWait are how often and how long to look
+	// for the run a dispatch started, which GitHub takes a few seconds to
+	// list
+	newRunPollInterval = 2 * time.Second
+	newRunWait         = 30 * time.Second
+
+	// offerDuration is how long ctrl+o opens a run that was just started
+	offerDuration = 10 * time.Second
+)
+
+// offeredRun is a run just started by a dispatch or re-run, which ctrl+o
+// opens while its toast is shown. Its ID is 0 when no run is offered.
+type offeredRun struct {
+	id       int
+	workflow string
+	seq      int // Tells apart offers of the same run, for expiring them
+}
+
+type newRunMsg struct {
+	workflow string
+	run      *models.GHRun // nil when no new run showed up in time
+}
+
+type offerExpiredMsg struct {
+	seq int
+}
+
+// latestRunID returns the ID of the workflow's newest run, 0 when it has
+// none, or -1 when it cannot be told
+func latestRunID(gh *github.Client, workflow string) int {
+	run, err := gh.GetLatestRun(workflow)
+	switch {
+	case errors.Is(err, github.ErrNoRuns):
+		return 0
+	case err != nil:
+		return -1
+	}
+	return run.DatabaseID
+}
+
+// findNewRunCmd waits for the workflow to list a run newer than afterID
+func (a *App) findNewRunCmd(workflow string, afterID int) tea.Cmd {
+	gh, interval, wait := a.gh, a.newRunPoll, a.newRunWait
+	return func() tea.Msg {
+		deadline := time.Now().Add(wait)
+		for {
+			if run, err := gh.GetLatestRun(workflow); err == nil && run.DatabaseID > afterID {
+				return newRunMsg{workflow: workflow, run: run}
+			}
+			if time.Now().Add(interval).After(deadline) {
+				return newRunMsg{workflow: workflow}
+			}
+			time.Sleep(interval)
+		}
+	}
+}
+
+func (a *App) handleNewRun(msg newRunMsg) (tea.Model, tea.Cmd) {
+	if msg.run == nil {
+		return a, a.toaster.Info("The dispatched run has not shown up yet, refresh the runs to find it")
+	}
+	return a, tea.Batch(
+		a.offerRun(msg.run.DatabaseID, msg.workflow, fmt.Sprintf("Run #%d of %s started", msg.run.DatabaseID, msg.workflow)),
+		a.refreshRunsOf(msg.workflow),
+	)
+}
+
+// offerRun toasts text with a hint that ctrl+o opens the run, which it
+// does until the toast expires
+func (a *App) offerRun(runID int, workflow, text string) tea.Cmd {
+	seq := a.offer.seq + 1
+	a.offer = offeredRun{id: runID, workflow: workflow, seq: seq}
+	return tea.Batch(
+		a.toaster.Show(text+" · ctrl+o to open it", components.ToastSuccess, offerDuration),
+		tea.Tick(offerDuration, func(time.Time) tea.Msg {
+			return offerExpiredMsg{seq: seq}
+		}),
+	)
+}
+
+func (a *App) handleOfferExpired(msg offerExpiredMsg) (tea.Model, tea.Cmd) {
+	if msg.seq == a.offer.seq {
+		a.offer = offeredRun{}
+	}
+	return a, nil
+}
+
+// openOfferedRun shows the offered run highlighted among its workflow's
+// runs, fetching them first if it is not listed yet
+func (a *App) openOfferedRun() (tea.Model, tea.Cmd) {
+	offer := a.offer
+	if offer.id == 0 {
+		return a, nil
+	}
+	a.offer = offeredRun{}
+
+	a.pendingRunID = offer.id
+	if a.viewMode == ViewRuns && a.selectedWorkflow == offer.workflow {
+		if a.runsTable.SelectRun(offer.id) {
+			a.pendingRunID = 0
+			return a, nil
+		}
+		return a, a.refreshRunsOf(offer.workflow)
+	}
+	if path := a.config.FindWorkflowGroupPath(offer.workflow); path != nil {
+		a.enterGroupPath(path)
+	}
+	return a.selectWorkflow(offer.workflow, nil)
+}
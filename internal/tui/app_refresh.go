@@ -1,36 +1,123 @@
 package tui
 
 import (
+	"context"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/github"
 )
 
+// clock creates the refresh ticker. Tests swap in a fake to fire ticks
+// without sleeping.
+type clock interface {
+	NewTicker(d time.Duration) ticker
+}
+
+// ticker is the part of time.Ticker the refresh loop uses
+type ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) NewTicker(d time.Duration) ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
 func (a *App) startRefreshTicker() {
 	if a.refreshInterval <= 0 || !a.autoRefreshEnabled {
 		return
 	}
 	a.stopRefreshTicker()
-	a.refreshTicker = time.NewTicker(time.Duration(a.refreshInterval) * time.Second)
+	a.refreshTicker = a.clock.NewTicker(time.Duration(a.refreshInterval) * time.Second)
+	a.refreshStop = make(chan struct{})
+	a.refreshGen++
 }
 
+// stopRefreshTicker stops the ticker and releases any command still waiting
+// on it. A stopped ticker's channel is never closed, so without refreshStop
+// that command's goroutine would block forever.
 func (a *App) stopRefreshTicker() {
 	if a.refreshTicker != nil {
 		a.refreshTicker.Stop()
+		close(a.refreshStop)
 		a.refreshTicker = nil
+		a.refreshStop = nil
+		a.refreshArmed = false
 	}
 }
 
+// getRefreshTickerCmd waits for the next tick of the current ticker. The
+// channel is captured now, not read from the field later, so a restart
+// cannot leave the command blocked on a stopped ticker. At most one command
+// waits per ticker; further calls return nil until its tick is handled.
 func (a *App) getRefreshTickerCmd() tea.Cmd {
-	if a.refreshTicker == nil {
+	if a.refreshTicker == nil || a.refreshArmed {
 		return nil
 	}
+	a.refreshArmed = true
+	ticks, stop, gen := a.refreshTicker.C(), a.refreshStop, a.refreshGen
 	return func() tea.Msg {
-		return refreshTickMsg{timestamp: <-a.refreshTicker.C}
+		select {
+		case t := <-ticks:
+			return refreshTickMsg{timestamp: t, gen: gen}
+		case <-stop:
+			return nil
+		}
 	}
 }
 
-func (a *App) fetchWorkflowRunsCmd() tea.Msg {
-	runs, err := a.gh.GetWorkflowRuns(a.selectedWorkflow, 20)
-	return workflowRunsMsg{runs: runs, err: err}
+// handleRefreshTick fetches runs on each tick and waits for the next one.
+// Ticks from a ticker that has since been replaced are dropped.
+func (a *App) handleRefreshTick(msg refreshTickMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != a.refreshGen || a.refreshTicker == nil {
+		return a, nil
+	}
+	a.refreshArmed = false
+	if a.selectedWorkflow != "" && !a.loading {
+		a.loading = true
+		a.runsTable.SetLoading(true)
+		return a, tea.Batch(a.fetchWorkflowRuns(), a.getRefreshTickerCmd())
+	}
+	return a, a.getRefreshTickerCmd()
+}
+
+// fetchWorkflowRuns fetches the selected workflow's runs. A fetch still in
+// flight is cancelled, and this one can be with cancelRunsFetch.
+func (a *App) fetchWorkflowRuns() tea.Cmd {
+	if a.cancelFetch != nil {
+		a.cancelFetch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancelFetch = cancel
+	gh, workflow := a.gh, a.selectedWorkflow
+	return func() tea.Msg {
+		defer cancel()
+		runs, err := gh.GetWorkflowRunsContext(ctx, workflow, github.DefaultRunLimit)
+		return workflowRunsMsg{workflow: workflow, runs: runs, err: err}
+	}
+}
+
+// cancelRunsFetch aborts the runs fetch in flight, if any
+func (a *App) cancelRunsFetch() {
+	if a.cancelFetch != nil {
+		a.cancelFetch()
+		a.cancelFetch = nil
+	}
+	if a.loading {
+		a.loading = false
+		a.spinner.Stop()
+		a.runsTable.SetLoading(false)
+	}
 }
@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/goleak"
+)
+
+// fakeClock hands out fakeTickers that only fire when a test says so
+type fakeClock struct {
+	tickers []*fakeTicker
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) ticker {
+	t := &fakeTicker{interval: d, ch: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+func (c *fakeClock) last() *fakeTicker {
+	if len(c.tickers) == 0 {
+		return nil
+	}
+	return c.tickers[len(c.tickers)-1]
+}
+
+type fakeTicker struct {
+	interval time.Duration
+	ch       chan time.Time
+	stopped  bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.ch }
+func (t *fakeTicker) Stop()               { t.stopped = true }
+
+func newRefreshTestApp(t *testing.T) (*App, *fakeClock) {
+	t.Helper()
+	app := newTestApp(t)
+	clock := &fakeClock{}
+	app.clock = clock
+	app.refreshInterval = 30
+	app.autoRefreshEnabled = true
+	return app, clock
+}
+
+func TestRefreshTickerStartsWithRunsView(t *testing.T) {
+	app, clock := newRefreshTestApp(t)
+
+	app.selectWorkflow("ci.yml", &app.config.Groups[0])
+	ticker := clock.last()
+	if ticker == nil || ticker.interval != 30*time.Second {
+		t.Fatalf("expected a 30s ticker, got %+v", ticker)
+	}
+
+	app.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if !ticker.stopped {
+		t.Error("expected leaving the runs view to stop the ticker")
+	}
+}
+
+func TestManualRefreshRestartsTicker(t *testing.T) {
+	app, clock := newRefreshTestApp(t)
+	app.selectWorkflow("ci.yml", &app.config.Groups[0])
+	first := clock.last()
+	app.loading = false
+
+	app.handleRefreshKey()
+
+	if !first.stopped {
+		t.Error("expected manual refresh to stop the old ticker")
+	}
+	if second := clock.last(); second == first || second.stopped {
+		t.Error("expected manual refresh to start a fresh ticker")
+	}
+}
+
+func TestManualRefreshDuringPendingTick(t *testing.T) {
+	app, clock := newRefreshTestApp(t)
+	app.selectWorkflow("ci.yml", &app.config.Groups[0])
+	first := clock.last()
+	app.loading = false
+
+	pending := make(chan tea.Msg, 1)
+	stale := app.getRefreshTickerCmd()
+	go func() { pending <- stale() }()
+
+	app.handleRefreshKey()
+	select {
+	case msg := <-pending:
+		if msg != nil {
+			t.Fatalf("expected the old tick command to resolve empty, got %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("old tick command still blocked after manual refresh")
+	}
+
+	// The runs that the refresh fetched arrive and re-arm the new ticker
+	_, cmd := app.Update(workflowRunsMsg{workflow: app.selectedWorkflow})
+	second := clock.last()
+	if second == first {
+		t.Fatal("expected manual refresh to start a fresh ticker")
+	}
+	now := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	second.ch <- now
+	tick, ok := cmd().(refreshTickMsg)
+	if !ok || !tick.timestamp.Equal(now) {
+		t.Fatalf("expected a tick from the new ticker, got %#v", tick)
+	}
+
+	// A tick the old ticker fired just before the restart is dropped
+	_, cmd = app.Update(refreshTickMsg{timestamp: now, gen: tick.gen - 1})
+	if cmd != nil || app.loading {
+		t.Error("expected a tick from the replaced ticker to be ignored")
+	}
+}
+
+func TestRefreshTickFetchesOrDefers(t *testing.T) {
+	app, clock := newRefreshTestApp(t)
+	app.selectWorkflow("ci.yml", &app.config.Groups[0])
+
+	now := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	clock.last().ch <- now
+	msg := app.getRefreshTickerCmd()()
+	tick, ok := msg.(refreshTickMsg)
+	if !ok || !tick.timestamp.Equal(now) {
+		t.Fatalf("expected tick at %v, got %#v", now, msg)
+	}
+
+	// A fetch is still in flight, so the tick only re-arms the ticker
+	app.loading = true
+	_, cmd := app.Update(tick)
+	clock.last().ch <- now.Add(30 * time.Second)
+	if _, ok := cmd().(refreshTickMsg); !ok {
+		t.Fatal("expected a deferred tick to only wait for the next tick")
+	}
+
+	app.loading = false
+	app.Update(tick)
+	if !app.loading {
+		t.Error("expected an idle tick to start a fetch")
+	}
+}
+
+func TestQuitStopsTickerAndSavesStateOnce(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	app := newTestApp(t)
+	app.refreshInterval = 30
+	app.autoRefreshEnabled = true
+	app.selectWorkflow("ci.yml", &app.config.Groups[0])
+
+	// Run the pending tick command the way the Bubble Tea runtime would
+	pending := make(chan tea.Msg, 1)
+	tickCmd := app.getRefreshTickerCmd()
+	go func() { pending <- tickCmd() }()
+
+	_, cmd := app.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("expected q to quit")
+	}
+	if app.refreshTicker != nil {
+		t.Error("expected quitting to stop the refresh ticker")
+	}
+	select {
+	case msg := <-pending:
+		if msg != nil {
+			t.Errorf("expected the pending tick to be dropped, got %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pending tick command still blocked after quit")
+	}
+
+	data, err := os.ReadFile(app.statePath)
+	if err != nil {
+		t.Fatalf("expected state to be saved on quit: %v", err)
+	}
+	if !strings.Contains(string(data), "ci.yml") {
+		t.Errorf("expected saved state to include the selected workflow, got:\n%s", data)
+	}
+
+	// Later exit paths must not write the state again
+	if err := os.Remove(app.statePath); err != nil {
+		t.Fatal(err)
+	}
+	app.shutdown()
+	if _, err := os.Stat(app.statePath); !os.IsNotExist(err) {
+		t.Errorf("expected state to be saved only once, stat err = %v", err)
+	}
+}
+
+func TestIdleTimeoutQuitsAfterInactivity(t *testing.T) {
+	app := newTestApp(t)
+	if cmd := app.idleTickCmd(); cmd != nil {
+		t.Fatal("expected no idle check without an idle timeout")
+	}
+
+	app.idleTimeout = 10 * time.Minute
+	start := time.Now()
+	app.lastInteraction = start
+
+	_, cmd := app.Update(idleTickMsg{at: start.Add(5 * time.Minute)})
+	if cmd == nil {
+		t.Fatal("expected another idle check before the timeout")
+	}
+	if app.shutDown {
+		t.Fatal("expected no quit before the timeout")
+	}
+
+	// Input restarts the timeout
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	touched := app.lastInteraction
+	if !touched.After(start) {
+		t.Fatal("expected a key press to reset the idle timer")
+	}
+
+	app.Update(idleTickMsg{at: touched.Add(9 * time.Minute)})
+	if app.shutDown {
+		t.Fatal("expected a key press to reset the idle timer")
+	}
+
+	_, cmd = app.Update(idleTickMsg{at: touched.Add(10 * time.Minute)})
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("expected the idle timeout to quit")
+	}
+	if _, err := os.Stat(app.statePath); err != nil {
+		t.Errorf("expected state to be saved on idle quit: %v", err)
+	}
+}
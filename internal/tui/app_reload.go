@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
+)
+
+// applyPreferences applies the config preferences that the TUI reads on
+// its own. Preferences that CLI flags can override, such as the refresh
+// interval and layout, arrive through AppOptions instead.
+func (a *App) applyPreferences() {
+	a.autoOpenMatch = a.config.GetAutoOpenMatch()
+	a.openBehavior = a.config.GetOpenBehavior()
+	a.setSortMode(a.config.GetSortMode())
+	a.rememberFilters = a.config.GetRememberFilters()
+	a.idleTimeout = time.Duration(a.config.GetIdleTimeout()) * time.Minute
+	a.runsTable.SetPageSize(a.config.GetTablePageSize())
+	a.sidebar.SetGrouped(a.config.GetGroupPinned())
+	a.navList.SetHideDescriptions(a.config.GetHideDescriptions())
+
+	format := a.config.GetTimeFormat()
+	timeFormat := components.RunTimeFormat{
+		Layout:   config.TimeLayout(format),
+		Relative: format == config.TimeFormatRelative,
+		UTC:      a.config.GetUTCTimes(),
+	}
+	a.runsTable.SetTimeFormat(timeFormat)
+	a.details.SetTimeFormat(timeFormat)
+
+	var matcher func(string) bool
+	if patterns := a.config.GetBranchHighlights(); len(patterns) > 0 {
+		matcher = func(branch string) bool {
+			return config.MatchesBranch(branch, patterns)
+		}
+	}
+	a.runsTable.SetBranchMatcher(matcher)
+}
+
+// reloadConfig rereads the config files and rebuilds the lists from them,
+// keeping the current group, workflow, and cursor where they still exist.
+// When the files no longer load or validate, the current config stays.
+func (a *App) reloadConfig() (tea.Model, tea.Cmd) {
+	cfg, err := config.LoadMerged(a.configPaths)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		a.err = err
+		return a, a.toaster.Error("Config not reloaded: " + err.Error())
+	}
+	// The repository being viewed may come from --repo, not the config
+	cfg.Repository = a.config.Repository
+
+	groupPath := a.groupPath
+	if a.healthView {
+		groupPath = a.configGroupPath
+	}
+	resolved, _ := state.ResolveGroupPath(cfg, state.ExtractGroupIDs(groupPath))
+	if a.selectedGroup != nil {
+		selected := state.ExtractGroupIDs(a.config.FindGroupPath(a.selectedGroup))
+		if path, ok := state.ResolveGroupPath(cfg, selected); ok && len(path) > 0 {
+			a.selectedGroup = path[len(path)-1]
+		}
+	}
+
+	idleWasOff := a.idleTimeout <= 0
+	cursor := a.navList.Cursor()
+
+	a.config = cfg
+	a.searchIndex = nil
+	a.invalidateNavItems()
+	a.applyPreferences()
+	if a.healthView {
+		a.configGroupPath = resolved
+		a.rebuildHealthGroups()
+	} else {
+		a.groupPath = resolved
+	}
+	a.refreshNavList()
+	a.navList.SetCursor(cursor)
+	a.refreshPinnedList()
+	a.updateStatusBar()
+
+	cmds := []tea.Cmd{a.toaster.Success("Config reloaded"), a.syncDetails()}
+	if idleWasOff {
+		a.lastInteraction = time.Now()
+		cmds = append(cmds, a.idleTickCmd())
+	}
+	return a, tea.Batch(cmds...)
+}
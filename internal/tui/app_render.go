@@ -2,33 +2,38 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
 )
 
 func (a *App) renderLayout() string {
-	barHeight := 2
-	panelHeight := a.height - barHeight - 2
-
-	sidebarWidth := 0
-	if a.showSidebar {
-		sidebarWidth = max(25, a.width/5)
-	}
-	mainWidth := a.width - sidebarWidth
-	if a.showSidebar {
-		mainWidth -= 2
-	}
+	l := computeLayout(a.width, a.height, a.showSidebar)
+	sidebarWidth, mainWidth, panelHeight := l.sidebarWidth, l.mainWidth, l.panelHeight
+	// The expanded legend takes its extra lines from the panels
+	panelHeight = max(0, panelHeight-(a.helpBar.Height()-1))
 
 	var mainView string
 	if a.viewMode == ViewRuns {
-		a.runsTable.SetSize(mainWidth-2, panelHeight-2)
+		a.runsTable.SetSize(inner(mainWidth), inner(panelHeight))
 		mainView = a.wrapPanel(a.runsTable.View(), a.focusArea == FocusMain)
+	} else if a.hasNoWorkflows() {
+		mainView = a.wrapPanel(a.renderNoWorkflows(inner(mainWidth), inner(panelHeight)), a.focusArea == FocusMain)
+	} else if detailsWidth := mainWidth * 2 / 5; a.classicLayout && detailsWidth >= minDetailsWidth {
+		navWidth := mainWidth - detailsWidth
+		a.navList.SetSize(inner(navWidth), inner(panelHeight))
+		a.details.SetSize(inner(detailsWidth), inner(panelHeight))
+		mainView = lipgloss.JoinHorizontal(lipgloss.Top,
+			a.wrapPanel(a.navList.View(), a.focusArea == FocusMain),
+			a.wrapPanel(a.details.View(), false),
+		)
 	} else {
-		a.navList.SetSize(mainWidth-2, panelHeight-2)
+		a.navList.SetSize(inner(mainWidth), inner(panelHeight))
 		mainView = a.wrapPanel(a.navList.View(), a.focusArea == FocusMain)
 	}
 
 	var topRow string
 	if a.showSidebar {
-		a.sidebar.SetSize(sidebarWidth-2, panelHeight-2)
+		a.sidebar.SetSize(inner(sidebarWidth), inner(panelHeight))
 		sidebarView := a.wrapPanel(a.sidebar.View(), a.focusArea == FocusSidebar)
 		topRow = lipgloss.JoinHorizontal(lipgloss.Top, sidebarView, mainView)
 	} else {
@@ -73,8 +78,21 @@ func (a *App) updateStatusBar() {
 	a.statusBar.SetWorkflow(a.selectedWorkflow)
 	a.statusBar.SetRefreshStatus(a.autoRefreshEnabled, a.refreshInterval)
 	a.statusBar.SetLoading(a.loading)
+	a.statusBar.SetHidden(len(a.config.GetHiddenWorkflows()), a.showHidden)
+	a.statusBar.SetLocalRepository(a.mismatchedLocalRepository())
+
+	switch {
+	case a.focusArea == FocusSidebar && a.sidebar.HasFilter():
+		a.statusBar.SetFilterCounts(a.sidebar.FilterCounts())
+	case a.focusArea != FocusSidebar && a.viewMode == ViewGroups && a.navList.HasFilter():
+		a.statusBar.SetFilterCounts(a.navList.FilterCounts())
+	default:
+		a.statusBar.SetFilterCounts(0, 0)
+	}
 }
 
+// updateHelpBar refreshes the help bar, its legend, and the help overlay's
+// context section from the same bindings, so they never disagree
 func (a *App) updateHelpBar() {
 	hints := []string{"[q]uit", "[?]help", "[:]cmd", "[ctrl+f]search"}
 
@@ -82,16 +100,106 @@ func (a *App) updateHelpBar() {
 		hints = append(hints, "[tab]switch", "[1]sidebar")
 	}
 
-	if a.focusArea == FocusSidebar {
-		hints = append(hints, "[enter]select", "[p]unpin", "[w]web")
-	} else if a.viewMode == ViewGroups {
-		hints = append(hints, "[enter]select", "[/]filter")
-		if len(a.groupPath) > 0 {
-			hints = append(hints, "[h]back", "[p]pin", "[w]web")
+	context := a.contextKeys()
+	for _, binding := range context.Bindings {
+		if binding.Hint != "" {
+			hints = append(hints, "["+binding.Key+"]"+binding.Hint)
 		}
-	} else {
-		hints = append(hints, "[j/k]nav", "[w]open", "[h]back")
 	}
 
 	a.helpBar.SetHints(hints)
+	a.helpBar.SetLegend(context)
+	a.helpOverlay.SetContext(context)
+}
+
+// contextKeys returns the bindings available for the current focus and view
+func (a *App) contextKeys() components.KeySection {
+	dispatch := []components.KeyBinding{
+		{Key: "x", Description: "Dispatch workflow", Hint: "dispatch"},
+		{Key: "X", Description: "Dispatch with last inputs"},
+	}
+	if !a.canDispatch(a.currentWorkflow()) {
+		dispatch = nil
+	}
+
+	if a.focusArea == FocusSidebar {
+		bindings := []components.KeyBinding{
+			{Key: "enter", Description: "Show the workflow's runs", Hint: "select"},
+			{Key: "p", Description: "Unpin workflow", Hint: "unpin"},
+			{Key: "w", Description: "Open in browser", Hint: "web"},
+			{Key: "i", Description: "Show the latest run", Hint: "latest"},
+			{Key: "Y", Description: "Copy workflow filename"},
+			{Key: "V", Description: "View workflow source"},
+		}
+		if a.sidebar.IsGrouped() {
+			bindings[0].Description = "Show the workflow's runs, or fold a group"
+		}
+		bindings = append(bindings, dispatch...)
+		bindings = append(bindings, components.KeyBinding{Key: "l / →", Description: "Focus main panel"})
+		return components.KeySection{Title: "Pinned Workflows", Bindings: bindings}
+	}
+
+	if a.viewMode == ViewRuns {
+		bindings := []components.KeyBinding{
+			{Key: "j/k", Description: "Move between runs", Hint: "nav"},
+			{Key: "w", Description: a.openRunDescription(), Hint: "open"},
+			{Key: "o", Description: "Open run in browser"},
+			{Key: "v", Description: "View the run's log"},
+			{Key: "J", Description: "Show the run's jobs"},
+			{Key: "A", Description: "Open a run waiting for approval"},
+			{Key: "n", Description: "Show the run's check annotations"},
+			{Key: "T", Description: "Tag or untag the run"},
+			{Key: "#", Description: "Show only runs with a tag"},
+			{Key: "F", Description: "Re-run failed jobs and follow until done"},
+			{Key: "L", Description: "Copy the run's log"},
+			{Key: "S", Description: "Save the run's log to a file"},
+			{Key: "V", Description: "View workflow source"},
+			{Key: "h", Description: "Back to workflows", Hint: "back"},
+		}
+		bindings = append(bindings, dispatch...)
+		if a.runsTable.HasBranchMatcher() {
+			bindings = append(bindings, components.KeyBinding{Key: "b", Description: "Filter runs to highlighted branches", Hint: "branches"})
+		}
+		return components.KeySection{Title: "Runs", Bindings: bindings}
+	}
+
+	title := "Groups"
+	if a.healthView {
+		title = "Health"
+	}
+	bindings := []components.KeyBinding{
+		{Key: "enter", Description: "Open group or workflow", Hint: "select"},
+		{Key: "/", Description: "Filter the list", Hint: "filter"},
+		{Key: "v", Description: "Toggle grouping by workflow health", Hint: "health"},
+	}
+	if !a.healthView {
+		bindings = append(bindings, components.KeyBinding{Key: "f", Description: "Star/unstar group"})
+	}
+	bindings = append(bindings,
+		components.KeyBinding{Key: "H", Description: "Hide/unhide workflow"},
+		components.KeyBinding{Key: ".", Description: "Show or hide hidden workflows"},
+	)
+	if len(a.groupPath) == 0 {
+		bindings = append(bindings, components.KeyBinding{Key: "w", Description: "Open the Actions tab in browser"})
+	}
+	if a.hasNoWorkflows() {
+		bindings = append(bindings, components.KeyBinding{Key: "ctrl+r", Description: "Check the repository for workflows"})
+	}
+	if len(a.groupPath) > 0 {
+		bindings = append(bindings, components.KeyBinding{Key: "h", Description: "Go back", Hint: "back"})
+		bindings = append(bindings, components.KeyBinding{Key: "space", Description: "Mark workflow for p/w", Hint: "mark"})
+		if !a.healthView {
+			bindings = append(bindings, components.KeyBinding{Key: "p", Description: "Pin/unpin workflow or marked ones", Hint: "pin"})
+		}
+		bindings = append(bindings,
+			components.KeyBinding{Key: "w", Description: "Open workflow or marked ones in browser, or Actions on a group", Hint: "web"},
+			components.KeyBinding{Key: "i", Description: "Show the workflow's latest run", Hint: "latest"},
+			components.KeyBinding{Key: "Y", Description: "Copy workflow filename"},
+			components.KeyBinding{Key: "V", Description: "View workflow source"},
+		)
+		if !a.healthView {
+			bindings = append(bindings, dispatch...)
+		}
+	}
+	return components.KeySection{Title: title, Bindings: bindings}
 }
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContextKeysFollowView(t *testing.T) {
+	app := newTestApp(t)
+
+	if got := app.contextKeys().Title; got != "Groups" {
+		t.Errorf("groups view context = %q, want Groups", got)
+	}
+
+	app.viewMode = ViewRuns
+	app.updateFocus()
+	if got := app.contextKeys().Title; got != "Runs" {
+		t.Errorf("runs view context = %q, want Runs", got)
+	}
+
+	app.focusArea = FocusSidebar
+	app.updateFocus()
+	if got := app.contextKeys().Title; got != "Pinned Workflows" {
+		t.Errorf("sidebar context = %q, want Pinned Workflows", got)
+	}
+}
+
+func TestHelpOverlayMatchesHelpBar(t *testing.T) {
+	app := newTestApp(t)
+	app.width, app.height = 120, 40
+	app.viewMode = ViewRuns
+	app.updateFocus()
+	app.helpBar.SetSize(app.width)
+	app.helpOverlay.SetSize(app.width, app.height)
+	app.helpOverlay.Toggle()
+
+	bar := app.helpBar.View()
+	overlay := app.helpOverlay.View()
+	for _, binding := range app.contextKeys().Bindings {
+		if binding.Hint != "" && !strings.Contains(bar, "["+binding.Key+"]"+binding.Hint) {
+			t.Errorf("help bar is missing %q", binding.Key)
+		}
+		if !strings.Contains(overlay, binding.Description) {
+			t.Errorf("help overlay is missing %q", binding.Description)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mismatchedLocalRepository returns the current directory's repository when
+// it is not the one being viewed, and "" otherwise
+func (a *App) mismatchedLocalRepository() string {
+	if a.localRepository == "" || strings.EqualFold(a.localRepository, a.config.Repository) {
+		return ""
+	}
+	return a.localRepository
+}
+
+// confirmSwitchToLocal asks to view the current directory's repository
+// instead. The app quits so the caller can relaunch it on that repository.
+func (a *App) confirmSwitchToLocal() (tea.Model, tea.Cmd) {
+	local := a.mismatchedLocalRepository()
+	if local == "" {
+		return a, a.toaster.Info("Already viewing this directory's repository")
+	}
+	a.askConfirm("Switch repository",
+		fmt.Sprintf("View %s, the repository of this directory, instead of %s?", local, a.config.Repository),
+		func() (tea.Model, tea.Cmd) {
+			a.switchRepository = local
+			return a.quit()
+		})
+	return a, nil
+}
+
+// SwitchRepository returns the repository the user chose to view before
+// quitting, or "" when they simply quit
+func (a *App) SwitchRepository() string {
+	return a.switchRepository
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// rerunPollInterval is how often a followed re-run is checked
+const rerunPollInterval = 10 * time.Second
+
+// followedRun is a re-run followed until it finishes. Its ID is 0 when no
+// run is followed.
+type followedRun struct {
+	id       int
+	workflow string
+	// attempt is the attempt that failed. Until GitHub starts the next one,
+	// the run still reports it as finished.
+	attempt int
+}
+
+type rerunStartedMsg struct {
+	run followedRun
+	err error
+}
+
+type rerunPollMsg struct {
+	runID int
+}
+
+type followedRunMsg struct {
+	run *models.GHRun
+	err error
+}
+
+// confirmRerunAndWatch asks before re-running the failed jobs of the
+// highlighted run and following it until it finishes
+func (a *App) confirmRerunAndWatch() (tea.Model, tea.Cmd) {
+	run := a.runsTable.SelectedRun()
+	if run == nil {
+		return a, nil
+	}
+	if !run.IsFailure() {
+		return a, a.toaster.Info("Only failed runs can be re-run")
+	}
+	if a.followed.id != 0 {
+		return a, a.toaster.Warning(fmt.Sprintf("Already following run #%d", a.followed.id))
+	}
+	failed := followedRun{id: run.DatabaseID, workflow: a.selectedWorkflow, attempt: run.Attempt}
+	a.askConfirm("Re-run failed jobs",
+		fmt.Sprintf("Re-run the failed jobs of run #%d and follow it until it finishes?", run.DatabaseID),
+		func() (tea.Model, tea.Cmd) {
+			return a, a.rerunFailedJobs(failed)
+		})
+	return a, nil
+}
+
+func (a *App) rerunFailedJobs(failed followedRun) tea.Cmd {
+	gh := a.gh
+	return tea.Batch(
+		a.spinner.Start("Re-running failed jobs..."),
+		func() tea.Msg {
+			if err := gh.RerunFailedJobs(failed.id); err != nil {
+				return rerunStartedMsg{err: err}
+			}
+			// The re-run is a new attempt of the same run, never a new run
+			return rerunStartedMsg{run: failed}
+		},
+	)
+}
+
+func (a *App) handleRerunStarted(msg rerunStartedMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+	if msg.err != nil {
+		a.err = msg.err
+		return a, a.toaster.Error("Re-run failed")
+	}
+
+	a.followed = msg.run
+	return a, tea.Batch(
+		a.offerRun(msg.run.id, msg.run.workflow, fmt.Sprintf("Re-running run #%d, following it until it finishes", msg.run.id)),
+		a.rerunPollCmd(msg.run.id),
+		a.refreshRunsOf(msg.run.workflow),
+	)
+}
+
+func (a *App) rerunPollCmd(runID int) tea.Cmd {
+	return tea.Tick(a.rerunPoll, func(time.Time) tea.Msg {
+		return rerunPollMsg{runID: runID}
+	})
+}
+
+// pollFollowedRun fetches the followed run, unless following it has ended
+func (a *App) pollFollowedRun(msg rerunPollMsg) (tea.Model, tea.Cmd) {
+	if msg.runID != a.followed.id {
+		return a, nil
+	}
+	gh := a.gh
+	return a, func() tea.Msg {
+		run, err := gh.GetRunByID(msg.runID)
+		return followedRunMsg{run: run, err: err}
+	}
+}
+
+// handleFollowedRun keeps polling until the re-run finishes, then toasts
+// its result and refreshes its workflow's runs
+func (a *App) handleFollowedRun(msg followedRunMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		a.err = msg.err
+		a.followed = followedRun{}
+		return a, a.toaster.Error("Stopped following the re-run")
+	}
+	if msg.run.DatabaseID != a.followed.id {
+		return a, nil
+	}
+	// The failed attempt is still reported until the new one starts
+	if !msg.run.IsTerminal() || msg.run.Attempt <= a.followed.attempt {
+		return a, a.rerunPollCmd(msg.run.DatabaseID)
+	}
+
+	cmds := []tea.Cmd{a.refreshRunsOf(a.followed.workflow)}
+	a.followed = followedRun{}
+	if msg.run.IsSuccess() {
+		cmds = append(cmds, a.toaster.Success(fmt.Sprintf("Re-run of #%d passed", msg.run.DatabaseID)))
+	} else {
+		cmds = append(cmds, a.toaster.Error(fmt.Sprintf("Re-run of #%d finished: %s", msg.run.DatabaseID, msg.run.Conclusion)))
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// refreshRunsOf refetches the runs shown when they are workflow's
+func (a *App) refreshRunsOf(workflow string) tea.Cmd {
+	if a.viewMode != ViewRuns || a.selectedWorkflow != workflow || a.loading {
+		return nil
+	}
+	a.loading = true
+	a.runsTable.SetLoading(true)
+	return tea.Batch(a.spinner.Start("Refreshing..."), a.fetchWorkflowRuns())
+}
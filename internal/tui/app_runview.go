@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+type runViewMsg struct {
+	runID int
+	lines []string
+	err   error
+}
+
+// openRun opens the selected run the way behavior says: in the browser, or
+// with its log or jobs in the run view overlay
+func (a *App) openRun(behavior string) (tea.Model, tea.Cmd) {
+	runID := a.runsTable.SelectedRunID()
+	if runID == 0 {
+		return a, nil
+	}
+
+	gh := a.gh
+	switch behavior {
+	case config.OpenLogs:
+		a.runView.Open(runID, "Log")
+		return a, func() tea.Msg {
+			log, err := gh.GetRunLog(runID)
+			return runViewMsg{runID: runID, lines: logLines(log), err: err}
+		}
+	case config.OpenJobs:
+		a.runView.Open(runID, "Jobs")
+		t := a.theme
+		return a, func() tea.Msg {
+			jobs, err := gh.GetRunJobs(runID)
+			return runViewMsg{runID: runID, lines: jobLines(t, jobs), err: err}
+		}
+	}
+	return a, a.openRunInBrowser(runID)
+}
+
+// openRunDescription describes what w and enter do on a run
+func (a *App) openRunDescription() string {
+	switch a.openBehavior {
+	case config.OpenLogs:
+		return "View the run's log"
+	case config.OpenJobs:
+		return "Show the run's jobs"
+	}
+	return "Open run in browser"
+}
+
+func (a *App) handleRunView(msg runViewMsg) (tea.Model, tea.Cmd) {
+	a.runView.SetLines(msg.runID, msg.lines, msg.err)
+	if msg.err != nil {
+		a.err = msg.err
+	}
+	return a, nil
+}
+
+// logLines splits a run log into lines, dropping the trailing newline
+func logLines(log []byte) []string {
+	text := strings.TrimRight(string(log), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// jobLines describes each job in a line, such as "✓ build  success"
+func jobLines(t *theme.Theme, jobs []models.GHJob) []string {
+	width := 0
+	for _, job := range jobs {
+		width = max(width, len([]rune(job.Name)))
+	}
+	lines := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		icon, _ := t.StatusIcon(job.Status, job.Conclusion)
+		outcome := job.Status
+		if models.IsTerminalStatus(job.Status) {
+			outcome = job.Conclusion
+		}
+		lines = append(lines, fmt.Sprintf("%s %-*s  %s", icon, width, job.Name, outcome))
+	}
+	return lines
+}
@@ -8,7 +8,14 @@ import (
 )
 
 func (a *App) navigateToSearchResult(result *components.SearchResult) (*App, tea.Cmd) {
+	if a.healthView {
+		a.exitHealthView()
+	}
+
 	if result.Type == "group" {
+		if a.viewMode == ViewRuns {
+			a.leaveRunsView()
+		}
 		a.groupPath = a.resolveGroupPath(result.GroupPath)
 		if result.Data != nil {
 			if group, ok := result.Data.(*config.Group); ok {
@@ -34,69 +41,30 @@ func (a *App) navigateToSearchResult(result *components.SearchResult) (*App, tea
 	return a.selectWorkflow(result.WorkflowName, group)
 }
 
+// performGlobalSearch searches the groups and workflows, leaving out hidden
+// workflows unless they are shown
 func (a *App) performGlobalSearch(query string) []components.SearchResult {
-	var results []components.SearchResult
-
-	var searchGroup func(group *config.Group, path []string)
-	searchGroup = func(group *config.Group, path []string) {
-		results = append(results, components.SearchResult{
-			Type:      "group",
-			Name:      group.Name,
-			GroupPath: path,
-			Data:      group,
-		})
-
-		currentPath := append(path, group.Name)
-
-		workflowDefs := make(map[string]string)
-		for i := range group.WorkflowDefs {
-			wf := &group.WorkflowDefs[i]
-			if wf.Name != "" {
-				workflowDefs[wf.File] = wf.Name
-			}
-		}
-
-		allWorkflows := make([]string, 0)
-		allWorkflows = append(allWorkflows, group.Workflows...)
-		for i := range group.WorkflowDefs {
-			wf := &group.WorkflowDefs[i]
-			found := false
-			for _, w := range allWorkflows {
-				if w == wf.File {
-					found = true
-					break
-				}
-			}
-			if !found {
-				allWorkflows = append(allWorkflows, wf.File)
-			}
-		}
-
-		for _, wfFile := range allWorkflows {
-			displayName := wfFile
-			if name, ok := workflowDefs[wfFile]; ok {
-				displayName = name
-			}
-			results = append(results, components.SearchResult{
-				Type:         "workflow",
-				Name:         displayName,
-				Description:  wfFile,
-				GroupPath:    currentPath,
-				WorkflowName: wfFile,
-				Data:         group,
-			})
-		}
+	results := a.groupSearchIndex().Search(query)
+	if a.showHidden || len(a.config.GetHiddenWorkflows()) == 0 {
+		return results
+	}
 
-		for i := range group.Groups {
-			searchGroup(&group.Groups[i], currentPath)
+	visible := make([]components.SearchResult, 0, len(results))
+	for _, result := range results {
+		if result.Type != "workflow" || !a.config.IsHiddenWorkflow(result.WorkflowName) {
+			visible = append(visible, result)
 		}
 	}
+	return visible
+}
 
-	for i := range a.config.Groups {
-		searchGroup(&a.config.Groups[i], []string{})
+// groupSearchIndex returns the search index of the configured groups,
+// building it the first time
+func (a *App) groupSearchIndex() *components.SearchIndex {
+	if a.searchIndex == nil {
+		a.searchIndex = components.NewSearchIndex(a.config.Groups, a.order.searchOrder())
 	}
-
-	return components.FuzzySearchItems(results, query)
+	return a.searchIndex
 }
 
 func (a *App) resolveGroupPath(names []string) []*config.Group {
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden snapshot files in testdata")
+
+// assertGolden compares the ANSI-stripped view with testdata/<name>.golden.
+// Run `go test ./internal/tui -update` to accept intended changes.
+func assertGolden(t *testing.T, name, view string) {
+	t.Helper()
+
+	got := ansi.Strip(view)
+	path := filepath.Join("testdata", name+".golden")
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("missing snapshot %s (run go test ./internal/tui -update): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("snapshot %s does not match (run go test ./internal/tui -update if intended)\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
+
+func newSnapshotApp(t *testing.T) *App {
+	t.Helper()
+	cfg := &config.Config{
+		Repository: "owner/repo",
+		Groups: []config.Group{
+			{
+				ID:              "ci",
+				Name:            "CI",
+				Workflows:       []string{"build.yml", "test.yml"},
+				WorkflowDefs:    []config.Workflow{{File: "build.yml", Name: "Build"}},
+				PinnedWorkflows: []string{"build.yml"},
+				Groups: []config.Group{
+					{ID: "nightly", Name: "Nightly", Workflows: []string{"nightly.yml"}},
+				},
+			},
+			{ID: "deploy", Name: "Deploy", Workflows: []string{"deploy.yml"}},
+		},
+	}
+	return NewApp(cfg, filepath.Join(t.TempDir(), "config.yaml"), github.NewClient("owner/repo"), AppOptions{
+		StatePath:      filepath.Join(t.TempDir(), "state.yaml"),
+		NoRestoreState: true,
+	})
+}
+
+var snapshotSizes = []struct{ width, height int }{
+	{width: 100, height: 24},
+	{width: 140, height: 32},
+}
+
+func TestSnapshotGroups(t *testing.T) {
+	for _, size := range snapshotSizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			app := newSnapshotApp(t)
+			app.Update(tea.WindowSizeMsg{Width: size.width, Height: size.height})
+			assertGolden(t, fmt.Sprintf("groups_%dx%d", size.width, size.height), app.View())
+
+			app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			assertGolden(t, fmt.Sprintf("group_ci_%dx%d", size.width, size.height), app.View())
+		})
+	}
+}
+
+func TestSnapshotRuns(t *testing.T) {
+	created := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+	runs := []models.GHRun{
+		{DatabaseID: 1003, DisplayTitle: "Fix flaky test", WorkflowName: "Build", Status: models.StatusInProgress, CreatedAt: created, HeadBranch: "main"},
+		{DatabaseID: 1002, DisplayTitle: "Add a very long pull request title that needs truncating in narrow terminals", WorkflowName: "Build", Status: models.StatusCompleted, Conclusion: models.ConclusionFailure, CreatedAt: created.Add(-time.Hour), HeadBranch: "feature/long-branch-name"},
+		{DatabaseID: 1001, DisplayTitle: "Release", WorkflowName: "Build", Status: models.StatusCompleted, Conclusion: models.ConclusionSuccess, CreatedAt: created.Add(-2 * time.Hour), HeadBranch: "release/1.0"},
+	}
+
+	for _, size := range snapshotSizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			app := newSnapshotApp(t)
+			app.Update(tea.WindowSizeMsg{Width: size.width, Height: size.height})
+			app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if app.viewMode != ViewRuns {
+				t.Fatalf("expected runs view, got %v", app.viewMode)
+			}
+
+			app.Update(workflowRunsMsg{workflow: app.selectedWorkflow, runs: runs})
+			view := app.View()
+			if strings.Contains(view, "Loading") {
+				t.Fatalf("runs still loading:\n%s", view)
+			}
+			assertGolden(t, fmt.Sprintf("runs_%dx%d", size.width, size.height), view)
+		})
+	}
+}
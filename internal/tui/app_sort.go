@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/components"
+)
+
+// navOrder sorts the groups and workflows of the nav lists and search the
+// way preferences.sortMode says. Favorites and pins are listed before what
+// it sorts.
+type navOrder struct {
+	mode     string
+	collator *collate.Collator // Compares names in alpha mode
+	counts   map[string]int    // Open counts of workflows in frequency mode
+}
+
+// setSortMode orders the lists by mode. Frequency mode orders by the open
+// counts as they are now, so lists do not reorder under the cursor while
+// workflows are opened.
+func (a *App) setSortMode(mode string) {
+	a.order = navOrder{mode: mode}
+	switch mode {
+	case config.SortAlpha:
+		a.order.collator = collate.New(localeTag(), collate.IgnoreCase, collate.Numeric)
+	case config.SortFrequency:
+		a.order.counts = maps.Clone(a.openCounts)
+	}
+}
+
+// localeTag returns the language of the locale names are collated in,
+// read from the variables POSIX consults for it, in order
+func localeTag() language.Tag {
+	for _, name := range []string{"LC_ALL", "LC_COLLATE", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		// Such as en_US.UTF-8 or de_DE@euro
+		value, _, _ = strings.Cut(value, ".")
+		value, _, _ = strings.Cut(value, "@")
+		if tag, err := language.Parse(strings.ReplaceAll(value, "_", "-")); err == nil {
+			return tag
+		}
+		return language.Und
+	}
+	return language.Und
+}
+
+// groups sorts groups in place. Ties keep the config order.
+func (o *navOrder) groups(groups []*config.Group) {
+	switch o.mode {
+	case config.SortAlpha:
+		slices.SortStableFunc(groups, func(a, b *config.Group) int {
+			return o.collator.CompareString(a.Name, b.Name)
+		})
+	case config.SortFrequency:
+		slices.SortStableFunc(groups, func(a, b *config.Group) int {
+			return o.groupCount(b) - o.groupCount(a)
+		})
+	}
+}
+
+// workflows sorts the workflow files of group in place, by the names they
+// are listed under. Ties keep the config order.
+func (o *navOrder) workflows(group *config.Group, files []string) {
+	switch o.mode {
+	case config.SortAlpha:
+		slices.SortStableFunc(files, func(a, b string) int {
+			return o.collator.CompareString(workflowName(group, a), workflowName(group, b))
+		})
+	case config.SortFrequency:
+		slices.SortStableFunc(files, func(a, b string) int {
+			return o.counts[b] - o.counts[a]
+		})
+	}
+}
+
+// groupCount is how many times the workflows of group and its subgroups
+// were opened
+func (o *navOrder) groupCount(group *config.Group) int {
+	count := 0
+	for _, wf := range group.Workflows {
+		count += o.counts[wf]
+	}
+	for i := range group.WorkflowDefs {
+		if !slices.Contains(group.Workflows, group.WorkflowDefs[i].File) {
+			count += o.counts[group.WorkflowDefs[i].File]
+		}
+	}
+	for i := range group.Groups {
+		count += o.groupCount(&group.Groups[i])
+	}
+	return count
+}
+
+// searchOrder orders search results that match equally well
+func (o *navOrder) searchOrder() components.GroupOrder {
+	if o.mode == config.SortConfig {
+		return components.GroupOrder{}
+	}
+	return components.GroupOrder{Groups: o.groups, Workflows: o.workflows}
+}
+
+// workflowName is the name file is listed under in group
+func workflowName(group *config.Group, file string) string {
+	if def := group.GetWorkflowDef(file); def != nil && def.Name != "" {
+		return def.Name
+	}
+	return file
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type workflowSourceMsg struct {
+	workflow string
+	source   string
+	err      error
+}
+
+// showWorkflowSource opens the current workflow's YAML, fetching it the
+// first time it is viewed this session
+func (a *App) showWorkflowSource() (tea.Model, tea.Cmd) {
+	workflow := a.currentWorkflow()
+	if workflow == "" {
+		return a, a.toaster.Info("Select a workflow to view its source")
+	}
+
+	a.sourceView.Open(workflow)
+	if source, ok := a.workflowSources[workflow]; ok {
+		a.sourceView.SetSource(workflow, source, nil)
+		return a, nil
+	}
+	gh := a.gh
+	return a, func() tea.Msg {
+		source, err := gh.GetWorkflowSource(workflow)
+		return workflowSourceMsg{workflow: workflow, source: source, err: err}
+	}
+}
+
+func (a *App) handleWorkflowSource(msg workflowSourceMsg) (tea.Model, tea.Cmd) {
+	a.sourceView.SetSource(msg.workflow, msg.source, msg.err)
+	if msg.err != nil {
+		a.err = msg.err
+		return a, nil
+	}
+	a.workflowSources[msg.workflow] = msg.source
+	return a, nil
+}
+
+func (a *App) copyWorkflowSource(workflow, source string) tea.Cmd {
+	return func() tea.Msg {
+		return actionResultMsg{
+			success: "Copied the YAML of " + workflow,
+			failure: "Failed to copy to clipboard",
+			err:     clipboard.WriteAll(source),
+		}
+	}
+}
@@ -4,19 +4,59 @@ import (
 	"fmt"
 	"os"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+	"github.com/Cloudsky01/gh-rivet/internal/github"
 	"github.com/Cloudsky01/gh-rivet/internal/state"
 )
 
+// quit shuts down and tells Bubble Tea to exit
+func (a *App) quit() (tea.Model, tea.Cmd) {
+	a.shutdown()
+	return a, tea.Quit
+}
+
+// shutdown stops background refreshes and saves state. It runs once; later
+// calls do nothing, so every exit path can call it.
+func (a *App) shutdown() {
+	if a.shutDown {
+		return
+	}
+	a.shutDown = true
+	a.stopRefreshTicker()
+	a.cancelRunsFetch()
+	a.saveState()
+}
+
 func (a *App) saveState() {
 	s := &state.NavigationState{
 		GroupPath: state.ExtractGroupIDs(a.groupPath),
 		ListIndex: a.navList.Cursor(),
 	}
+	if len(a.dispatchInputs) > 0 {
+		s.DispatchInputs = a.dispatchInputs
+	}
+	if len(a.dispatchRefs) > 0 {
+		s.DispatchRefs = a.dispatchRefs
+	}
+	if len(a.openCounts) > 0 {
+		s.OpenCounts = a.openCounts
+	}
+	s.ShowFilenames = a.showFilenames
+	a.rememberFilter()
+	if len(a.filters) > 0 {
+		s.Filters = a.filters
+		s.FiltersRepository = a.config.Repository
+	}
 
 	if a.viewMode == ViewRuns && a.selectedWorkflow != "" {
 		s.ViewState = state.ViewWorkflowOutput
 		s.SelectedWorkflow = a.selectedWorkflow
 		s.FromPinnedView = a.selectedGroup != nil
+		if a.selectedGroup != nil {
+			s.SelectedGroupPath = state.ExtractGroupIDs(a.config.FindGroupPath(a.selectedGroup))
+		}
 	} else if a.focusArea == FocusSidebar {
 		s.ViewState = state.ViewPinnedWorkflows
 		s.PinnedListIndex = a.sidebar.Cursor()
@@ -29,6 +69,50 @@ func (a *App) saveState() {
 	}
 }
 
+// loadDispatchInputs reads previously used dispatch inputs from the state file.
+// They are kept even when navigation state is not restored.
+func loadDispatchInputs(statePath string) map[string]map[string]string {
+	if savedState, err := state.Load(statePath); err == nil && savedState.DispatchInputs != nil {
+		return savedState.DispatchInputs
+	}
+	return make(map[string]map[string]string)
+}
+
+// loadShowFilenames reads whether workflows were last listed by filename,
+// kept like the dispatch inputs
+func loadShowFilenames(statePath string) bool {
+	savedState, err := state.Load(statePath)
+	return err == nil && savedState.ShowFilenames
+}
+
+// loadFilters reads the group lists' last filters, dropping those typed for
+// another repository
+func loadFilters(statePath, repository string) map[string]string {
+	savedState, err := state.Load(statePath)
+	if err == nil && savedState.Filters != nil && savedState.FiltersRepository == repository {
+		return savedState.Filters
+	}
+	return make(map[string]string)
+}
+
+// loadDispatchRefs reads the refs workflows were last dispatched on from
+// the state file, kept like the dispatch inputs
+func loadDispatchRefs(statePath string) map[string]string {
+	if savedState, err := state.Load(statePath); err == nil && savedState.DispatchRefs != nil {
+		return savedState.DispatchRefs
+	}
+	return make(map[string]string)
+}
+
+// loadOpenCounts reads how many times each workflow's runs were opened,
+// kept like the dispatch inputs
+func loadOpenCounts(statePath string) map[string]int {
+	if savedState, err := state.Load(statePath); err == nil && savedState.OpenCounts != nil {
+		return savedState.OpenCounts
+	}
+	return make(map[string]int)
+}
+
 func (a *App) restoreState() {
 	savedState, err := state.Load(a.statePath)
 	if err != nil {
@@ -42,6 +126,7 @@ func (a *App) restoreState() {
 			a.refreshNavList()
 		}
 	}
+	a.restoreFilter()
 
 	if savedState.ListIndex > 0 {
 		a.navList.SetCursor(savedState.ListIndex)
@@ -55,9 +140,14 @@ func (a *App) restoreState() {
 	case state.ViewWorkflowOutput:
 		if savedState.SelectedWorkflow != "" {
 			a.selectedWorkflow = savedState.SelectedWorkflow
+			if savedState.FromPinnedView {
+				if path, ok := state.ResolveGroupPath(a.config, savedState.SelectedGroupPath); ok && len(path) > 0 {
+					a.selectedGroup = path[len(path)-1]
+				}
+			}
 			a.viewMode = ViewRuns
 			a.runsTable.SetVisible(true)
-			runs, err := a.gh.GetWorkflowRuns(savedState.SelectedWorkflow, 20)
+			runs, err := a.gh.GetWorkflowRuns(savedState.SelectedWorkflow, github.DefaultRunLimit)
 			if err != nil {
 				a.err = err
 			} else {
@@ -70,3 +160,51 @@ func (a *App) restoreState() {
 	a.updateFocus()
 	a.updateStatusBar()
 }
+
+// filterKey returns the key the group list's filter is remembered under:
+// the open group's ID, or "" at the root. ok is false in the health view,
+// whose groups are not the config's.
+func (a *App) filterKey() (key string, ok bool) {
+	if a.healthView {
+		return "", false
+	}
+	if len(a.groupPath) > 0 {
+		key = a.groupPath[len(a.groupPath)-1].ID
+	}
+	return key, true
+}
+
+// rememberFilter records the group list's filter when filters are
+// remembered. An empty filter forgets the group's.
+func (a *App) rememberFilter() {
+	key, ok := a.filterKey()
+	if !a.rememberFilters || !ok {
+		return
+	}
+	if filter := a.navList.FilterInput(); filter != "" {
+		a.filters[key] = filter
+	} else {
+		delete(a.filters, key)
+	}
+}
+
+// restoreFilter reapplies the remembered filter of the open group
+func (a *App) restoreFilter() {
+	key, ok := a.filterKey()
+	if !a.rememberFilters || !ok {
+		return
+	}
+	if filter := a.filters[key]; filter != "" {
+		a.navList.SetFilter(filter)
+	}
+}
+
+// enterGroupPath moves the group list to path, remembering the filter of the
+// group being left and restoring the one of the group entered
+func (a *App) enterGroupPath(path []*config.Group) {
+	a.rememberFilter()
+	a.groupPath = path
+	a.navList.ClearFilter()
+	a.refreshNavList()
+	a.restoreFilter()
+}
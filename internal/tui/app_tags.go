@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/paths"
+	"github.com/Cloudsky01/gh-rivet/internal/state"
+)
+
+// loadRunTags reads the run tags of repository from the state directory,
+// returning the tags and the file they are saved to. Tags that cannot be
+// read start out empty.
+func loadRunTags(repository string) (state.RunTags, string) {
+	p, err := paths.New()
+	if err != nil {
+		slog.Debug("run tags unavailable", "err", err)
+		return state.RunTags{}, ""
+	}
+	path := p.UserTagsFile(repository)
+	tags, err := state.LoadTags(path)
+	if err != nil {
+		slog.Debug("run tags unreadable", "path", path, "err", err)
+		return state.RunTags{}, path
+	}
+	return tags, path
+}
+
+// tagRun picks a tag for the selected run from the tags in use, or a new
+// one typed in. Picking a tag the run has already removes it.
+func (a *App) tagRun() (tea.Model, tea.Cmd) {
+	runID := a.runsTable.SelectedRunID()
+	if runID == 0 {
+		return a, nil
+	}
+	a.branchPicker.OpenList(fmt.Sprintf("Tag run #%d", runID), "tag", "tags", a.runTags.All())
+	a.onBranch = func(tag string) (tea.Model, tea.Cmd) {
+		tagged := a.runTags.Toggle(runID, tag)
+		a.runsTable.SetRunTags(a.runTags)
+		if err := a.saveRunTags(); err != nil {
+			a.err = err
+			return a, a.toaster.Error("Failed to save tags")
+		}
+		if tagged {
+			return a, a.toaster.Success(fmt.Sprintf("Tagged run #%d %s", runID, tag))
+		}
+		return a, a.toaster.Info(fmt.Sprintf("Removed tag %s from run #%d", tag, runID))
+	}
+	return a, nil
+}
+
+func (a *App) saveRunTags() error {
+	if a.tagsPath == "" {
+		return errors.New("no state directory to save tags in")
+	}
+	return a.runTags.Save(a.tagsPath)
+}
+
+// filterRunsByTag picks a tag to show only runs with it, or clears the tag
+// filter when one is set
+func (a *App) filterRunsByTag() (tea.Model, tea.Cmd) {
+	if tag := a.runsTable.TagFilter(); tag != "" {
+		a.runsTable.SetTagFilter("")
+		return a, a.toaster.Info("Showing runs with any tag")
+	}
+	tags := a.runTags.All()
+	if len(tags) == 0 {
+		return a, a.toaster.Info("No runs are tagged yet, press T to tag one")
+	}
+	a.branchPicker.OpenList("Filter runs by tag", "tag", "tags", tags)
+	a.onBranch = func(tag string) (tea.Model, tea.Cmd) {
+		a.runsTable.SetTagFilter(tag)
+		return a, a.toaster.Info("Showing runs tagged " + tag)
+	}
+	return a, nil
+}
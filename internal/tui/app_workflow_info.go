@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+type workflowInfoMsg struct {
+	workflow string
+	info     *models.WorkflowInfo
+	err      error
+}
+
+// showWorkflowInfo puts the workflow's path and triggers in the runs header,
+// fetching them the first time the workflow is opened
+func (a *App) showWorkflowInfo(workflow string) tea.Cmd {
+	if info, ok := a.workflowInfo[workflow]; ok {
+		a.runsTable.SetWorkflowInfo(info)
+		return nil
+	}
+	gh := a.gh
+	return func() tea.Msg {
+		info, err := gh.GetWorkflowInfo(workflow)
+		return workflowInfoMsg{workflow: workflow, info: info, err: err}
+	}
+}
+
+// handleWorkflowInfo caches fetched workflow info. The header is only extra
+// context, so a failed fetch is logged and the header left without it.
+func (a *App) handleWorkflowInfo(msg workflowInfoMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		slog.Debug("workflow info unavailable", "workflow", msg.workflow, "err", msg.err)
+		return a, nil
+	}
+	a.workflowInfo[msg.workflow] = msg.info
+	if msg.workflow == a.selectedWorkflow {
+		a.runsTable.SetWorkflowInfo(msg.info)
+	}
+	// The dispatch keys are only listed for workflows that can be dispatched
+	a.updateHelpBar()
+	return a, nil
+}
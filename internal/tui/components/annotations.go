@@ -0,0 +1,193 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// Annotations is an overlay listing a run's check annotations, errors first
+type Annotations struct {
+	active      bool
+	loading     bool
+	runID       int
+	annotations []models.GHAnnotation
+	err         error
+	offset      int
+	width       int
+	height      int
+	theme       *theme.Theme
+}
+
+func NewAnnotations(t *theme.Theme) Annotations {
+	return Annotations{theme: t}
+}
+
+func (a *Annotations) SetSize(width, height int) {
+	a.width = width
+	a.height = height
+}
+
+func (a *Annotations) IsActive() bool {
+	return a.active
+}
+
+// RunID returns the run whose annotations are shown
+func (a *Annotations) RunID() int {
+	return a.runID
+}
+
+// Open shows the overlay for runID, loading until SetAnnotations is called
+func (a *Annotations) Open(runID int) {
+	a.active = true
+	a.loading = true
+	a.runID = runID
+	a.annotations = nil
+	a.err = nil
+	a.offset = 0
+}
+
+// SetAnnotations fills in the overlay once the annotations of runID are
+// fetched. Results for a run that is no longer shown are ignored.
+func (a *Annotations) SetAnnotations(runID int, annotations []models.GHAnnotation, err error) {
+	if runID != a.runID {
+		return
+	}
+	a.loading = false
+	a.err = err
+	a.annotations = slices.Clone(annotations)
+	slices.SortStableFunc(a.annotations, func(x, y models.GHAnnotation) int {
+		return annotationRank(x.Level) - annotationRank(y.Level)
+	})
+}
+
+// annotationRank orders failures before warnings before notices
+func annotationRank(level string) int {
+	switch models.NormalizeStatus(level) {
+	case "failure":
+		return 0
+	case "warning":
+		return 1
+	}
+	return 2
+}
+
+func (a *Annotations) Close() {
+	a.active = false
+	a.annotations = nil
+}
+
+func (a *Annotations) Update(msg tea.Msg) {
+	if !a.active {
+		return
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q", "n":
+		a.Close()
+	case "j", "down":
+		if a.offset < len(a.annotations)-1 {
+			a.offset++
+		}
+	case "k", "up":
+		if a.offset > 0 {
+			a.offset--
+		}
+	}
+}
+
+func (a *Annotations) View() string {
+	if !a.active {
+		return ""
+	}
+
+	overlayWidth := max(50, a.width*70/100)
+	overlayHeight := max(15, a.height*70/100)
+	textWidth := overlayWidth - 12
+
+	var b strings.Builder
+	b.WriteString(a.theme.Title.Render(fmt.Sprintf("Annotations · run #%d", a.runID)))
+	b.WriteString("\n")
+	b.WriteString(a.theme.Divider(overlayWidth - 8))
+	b.WriteString("\n\n")
+
+	switch {
+	case a.loading:
+		b.WriteString(a.theme.StatusInProgress.Render(a.theme.Icons.InProgress + " Loading annotations..."))
+		b.WriteString("\n")
+	case a.err != nil:
+		b.WriteString(a.theme.TextMuted.Render(truncate("Annotations unavailable: "+a.err.Error(), textWidth)))
+		b.WriteString("\n")
+	case len(a.annotations) == 0:
+		b.WriteString(a.theme.TextMuted.Render("This run has no annotations."))
+		b.WriteString("\n")
+	default:
+		summary := models.SummarizeAnnotations(a.annotations)
+		b.WriteString(a.theme.StatusError.Render(fmt.Sprintf("%s %d errors", a.theme.Icons.Error, summary.Errors)))
+		b.WriteString("  ")
+		b.WriteString(a.theme.StatusWarning.Render(fmt.Sprintf("%s %d warnings", a.theme.Icons.ActionRequired, summary.Warnings)))
+		b.WriteString("\n\n")
+
+		// Each annotation takes a location line and a message line
+		visible := max(1, (overlayHeight-12)/2)
+		end := min(len(a.annotations), a.offset+visible)
+		for _, annotation := range a.annotations[a.offset:end] {
+			icon, style := a.theme.Icons.Neutral, a.theme.TextDim
+			switch annotationRank(annotation.Level) {
+			case 0:
+				icon, style = a.theme.Icons.Error, a.theme.StatusError
+			case 1:
+				icon, style = a.theme.Icons.ActionRequired, a.theme.StatusWarning
+			}
+
+			location := annotation.Path
+			if annotation.StartLine > 0 {
+				location = fmt.Sprintf("%s:%d", location, annotation.StartLine)
+			}
+			b.WriteString(style.Render(icon) + " ")
+			b.WriteString(a.theme.Text.Render(truncate(location, textWidth-len(annotation.JobName)-3)))
+			b.WriteString(a.theme.TextDim.Render(" · " + annotation.JobName))
+			b.WriteString("\n")
+
+			message := annotation.Message
+			if annotation.Title != "" {
+				message = annotation.Title + ": " + message
+			}
+			message = strings.Join(strings.Fields(message), " ")
+			b.WriteString(a.theme.TextDim.Render("  " + truncate(message, textWidth)))
+			b.WriteString("\n")
+		}
+		if len(a.annotations) > visible {
+			b.WriteString(a.theme.TextMuted.Render(fmt.Sprintf("\n(%d-%d of %d)", a.offset+1, end, len(a.annotations))))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(a.theme.TextMuted.Render("[j/k] scroll [esc] close"))
+
+	overlayContent := lipgloss.NewStyle().
+		Width(overlayWidth-4).
+		Height(overlayHeight-2).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		a.width,
+		a.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		a.theme.BorderActive.Render(overlayContent),
+	)
+}
@@ -0,0 +1,233 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// BranchPicker is an overlay for choosing a branch from a fuzzy-filtered
+// list. When no branch matches, the typed text is picked as is, so tags and
+// commit SHAs can still be used. OpenList picks from other lists the same
+// way.
+type BranchPicker struct {
+	active   bool
+	loading  bool
+	title    string
+	noun     string // What is picked, such as "branch"
+	nouns    string // Its plural, such as "branches"
+	empty    string // Shown when there is nothing to pick from
+	selected string
+	input    string
+	branches []string
+	matches  []string
+	err      error
+	cursor   int
+	width    int
+	height   int
+	theme    *theme.Theme
+}
+
+func NewBranchPicker(t *theme.Theme) BranchPicker {
+	return BranchPicker{theme: t}
+}
+
+func (p *BranchPicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+func (p *BranchPicker) IsActive() bool {
+	return p.active
+}
+
+// Open shows the picker, loading until SetBranches is called. selected is
+// highlighted once the branches are set.
+func (p *BranchPicker) Open(title, selected string) {
+	p.active = true
+	p.loading = true
+	p.title = title
+	p.noun, p.nouns = "branch", "branches"
+	p.empty = "The repository has no branches"
+	p.selected = selected
+	p.input = ""
+	p.branches = nil
+	p.matches = nil
+	p.err = nil
+	p.cursor = 0
+}
+
+// OpenList shows the picker over items, named by noun and its plural nouns
+// in the prompts
+func (p *BranchPicker) OpenList(title, noun, nouns string, items []string) {
+	p.Open(title, "")
+	p.noun, p.nouns = noun, nouns
+	p.empty = fmt.Sprintf("No %s yet, type one and press enter", nouns)
+	p.SetBranches(items, nil)
+}
+
+// SetBranches fills in the branches to pick from. When they could not be
+// fetched, err is shown and a ref can still be typed.
+func (p *BranchPicker) SetBranches(branches []string, err error) {
+	if !p.active {
+		return
+	}
+	p.loading = false
+	p.err = err
+	p.branches = slices.Clone(branches)
+	p.filter()
+	if i := slices.Index(p.matches, p.selected); i >= 0 {
+		p.cursor = i
+	}
+}
+
+func (p *BranchPicker) Close() {
+	p.active = false
+	p.input = ""
+	p.branches = nil
+	p.matches = nil
+}
+
+func (p *BranchPicker) filter() {
+	p.cursor = 0
+	if p.input == "" {
+		p.matches = p.branches
+		return
+	}
+	p.matches = nil
+	for _, match := range fuzzy.Find(p.input, p.branches) {
+		p.matches = append(p.matches, match.Str)
+	}
+}
+
+// Update handles input and returns the picked branch once enter is pressed
+func (p *BranchPicker) Update(msg tea.Msg) *string {
+	if !p.active {
+		return nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		p.Close()
+	case "enter":
+		picked := p.input
+		if p.cursor < len(p.matches) {
+			picked = p.matches[p.cursor]
+		}
+		if picked == "" {
+			return nil
+		}
+		p.Close()
+		return &picked
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "ctrl+n":
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case "backspace":
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+			p.filter()
+		}
+	default:
+		if key := keyMsg.String(); len(key) == 1 {
+			p.input += key
+			p.filter()
+		}
+	}
+	return nil
+}
+
+func (p *BranchPicker) View() string {
+	if !p.active {
+		return ""
+	}
+
+	overlayWidth := max(50, p.width*60/100)
+	overlayHeight := max(15, p.height*70/100)
+	textWidth := overlayWidth - 12
+
+	var b strings.Builder
+	b.WriteString(p.theme.Title.Render(p.title))
+	b.WriteString("\n")
+	b.WriteString(p.theme.Divider(overlayWidth - 4))
+	b.WriteString("\n\n")
+
+	inputText := p.input + "█"
+	if p.input == "" {
+		inputText = p.theme.TextMuted.Render("Type to filter "+p.nouns+"...") + "█"
+	}
+	b.WriteString(p.theme.FilterPrompt.Render(p.theme.Icons.Search + " "))
+	b.WriteString(p.theme.FilterInput.Render(inputText))
+	b.WriteString("\n\n")
+
+	switch {
+	case p.loading:
+		b.WriteString(p.theme.StatusInProgress.Render("  " + p.theme.Icons.InProgress + " Loading branches..."))
+	case p.err != nil:
+		b.WriteString(p.theme.TextMuted.Render(truncate("  Branches unavailable: "+p.err.Error(), textWidth)))
+		b.WriteString("\n")
+		b.WriteString(p.theme.TextMuted.Render("  Type a ref and press enter"))
+	case len(p.matches) == 0 && p.input != "":
+		b.WriteString(p.theme.TextMuted.Render(truncate(fmt.Sprintf("  No matching %s, enter uses %q", p.noun, p.input), textWidth)))
+	case len(p.matches) == 0:
+		b.WriteString(p.theme.TextMuted.Render("  " + p.empty))
+	default:
+		b.WriteString(p.theme.TextMuted.Render(fmt.Sprintf("  %d %s", len(p.matches), p.nouns)))
+		b.WriteString("\n\n")
+
+		maxResults := max(1, overlayHeight-11)
+		visibleStart := 0
+		visibleEnd := min(len(p.matches), maxResults)
+		if p.cursor >= visibleEnd {
+			visibleStart = p.cursor - maxResults + 1
+			visibleEnd = p.cursor + 1
+		}
+
+		for i := visibleStart; i < visibleEnd; i++ {
+			isSelected := i == p.cursor
+			line := p.theme.ItemPrefix(isSelected) + truncate(p.matches[i], textWidth)
+			if isSelected {
+				b.WriteString(p.theme.Selected.Render(line))
+			} else {
+				b.WriteString(p.theme.Text.Render(line))
+			}
+			b.WriteString("\n")
+		}
+
+		if len(p.matches) > maxResults {
+			b.WriteString(p.theme.TextMuted.Render(fmt.Sprintf("\n  (%d-%d of %d)", visibleStart+1, visibleEnd, len(p.matches))))
+		}
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(p.theme.TextMuted.Render("[↑/↓] navigate | [enter] select | [esc] close"))
+
+	overlayContent := lipgloss.NewStyle().
+		Width(overlayWidth-4).
+		Height(overlayHeight-2).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		p.width,
+		p.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		p.theme.BorderActive.Render(overlayContent),
+	)
+}
@@ -15,6 +15,7 @@ type Command struct {
 	Aliases     []string
 	Description string
 	Action      func() tea.Cmd
+	Repeatable  bool // Opens nothing else, so it can run with the palette kept open
 }
 
 type CmdPalette struct {
@@ -26,6 +27,11 @@ type CmdPalette struct {
 	width    int
 	height   int
 	theme    *theme.Theme
+
+	// sticky keeps the palette open after repeatable commands, and lastRun
+	// names the last one run that way
+	sticky  bool
+	lastRun string
 }
 
 func NewCmdPalette(t *theme.Theme) CmdPalette {
@@ -61,9 +67,16 @@ func (c *CmdPalette) Close() {
 	c.active = false
 	c.input = ""
 	c.cursor = 0
+	c.lastRun = ""
+}
+
+// IsSticky reports whether the palette stays open after repeatable commands
+func (c *CmdPalette) IsSticky() bool {
+	return c.sticky
 }
 
 func (c *CmdPalette) applyFilter() {
+	c.cursor = 0
 	if c.input == "" {
 		c.filtered = c.commands
 		return
@@ -74,7 +87,6 @@ func (c *CmdPalette) applyFilter() {
 	for i, match := range matches {
 		c.filtered[i] = c.commands[match.Index]
 	}
-	c.cursor = 0
 }
 
 type commandSource []Command
@@ -99,14 +111,24 @@ func (c *CmdPalette) Update(msg tea.Msg) (selectedCmd *Command, cmd tea.Cmd) {
 		case "esc":
 			c.Close()
 			return nil, nil
-		case "enter":
+		case "enter", "alt+enter":
 			if c.cursor >= 0 && c.cursor < len(c.filtered) {
 				selected := c.filtered[c.cursor]
+				// alt+enter keeps the palette open for one command
+				if selected.Repeatable && (c.sticky || msg.String() == "alt+enter") {
+					c.input = ""
+					c.applyFilter()
+					c.lastRun = selected.Name
+					return &selected, nil
+				}
 				c.Close()
 				return &selected, nil
 			}
 			c.Close()
 			return nil, nil
+		case "ctrl+s":
+			c.sticky = !c.sticky
+			return nil, nil
 		case "up", "ctrl+p":
 			if c.cursor > 0 {
 				c.cursor--
@@ -163,7 +185,8 @@ func (c *CmdPalette) View() string {
 	if len(c.filtered) == 0 {
 		b.WriteString(c.theme.TextMuted.Render("  No matching commands"))
 	} else {
-		maxVisible := overlayHeight - 5
+		// Leaves room for the footer and the last command run
+		maxVisible := overlayHeight - 7
 		visibleStart := 0
 		visibleEnd := min(len(c.filtered), maxVisible)
 
@@ -194,7 +217,17 @@ func (c *CmdPalette) View() string {
 	}
 
 	b.WriteString("\n")
+	if c.lastRun != "" {
+		b.WriteString(c.theme.StatusSuccess.Render(c.theme.Icons.Success + " Ran " + c.lastRun))
+		b.WriteString("\n")
+	}
+	stay := "off"
+	if c.sticky {
+		stay = "on"
+	}
 	b.WriteString(c.theme.TextMuted.Render("[tab] complete [enter] execute [esc] cancel"))
+	b.WriteString("\n")
+	b.WriteString(c.theme.TextMuted.Render("[alt+enter] execute, stay open [ctrl+s] always stay open: " + stay))
 
 	overlayContent := lipgloss.NewStyle().
 		Width(overlayWidth-4).
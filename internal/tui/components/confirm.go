@@ -0,0 +1,95 @@
+package components
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// Confirm is a yes/no overlay shown before destructive actions
+type Confirm struct {
+	active  bool
+	title   string
+	message string
+	width   int
+	height  int
+	theme   *theme.Theme
+}
+
+func NewConfirm(t *theme.Theme) Confirm {
+	return Confirm{theme: t}
+}
+
+func (c *Confirm) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+func (c *Confirm) IsActive() bool {
+	return c.active
+}
+
+// Open shows the dialog with a title and the question to answer
+func (c *Confirm) Open(title, message string) {
+	c.active = true
+	c.title = title
+	c.message = message
+}
+
+func (c *Confirm) Close() {
+	c.active = false
+}
+
+// Update returns true when the user confirms. Any answer closes the dialog.
+func (c *Confirm) Update(msg tea.Msg) bool {
+	if !c.active {
+		return false
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+
+	switch keyMsg.String() {
+	case "y", "Y", "enter":
+		c.Close()
+		return true
+	case "n", "N", "esc", "q":
+		c.Close()
+	}
+	return false
+}
+
+func (c *Confirm) View() string {
+	if !c.active {
+		return ""
+	}
+
+	overlayWidth := max(40, min(60, c.width*50/100))
+
+	var b strings.Builder
+	b.WriteString(c.theme.Title.Render(c.title))
+	b.WriteString("\n")
+	b.WriteString(c.theme.Divider(overlayWidth - 8))
+	b.WriteString("\n\n")
+	b.WriteString(c.theme.Text.Render(c.message))
+	b.WriteString("\n\n")
+	b.WriteString(c.theme.TextMuted.Render("[y] confirm [n/esc] cancel"))
+
+	overlayContent := lipgloss.NewStyle().
+		Width(overlayWidth-4).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		c.width,
+		c.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		c.theme.BorderActive.Render(overlayContent),
+	)
+}
@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -20,6 +21,7 @@ type Details struct {
 	loading      bool
 	err          error
 	theme        *theme.Theme
+	timeFormat   RunTimeFormat
 }
 
 // NewDetails creates a new details component
@@ -39,6 +41,11 @@ func (d *Details) SetRuns(runs []models.GHRun) {
 	d.runs = runs
 }
 
+// SetTimeFormat sets how the recent runs' times render
+func (d *Details) SetTimeFormat(f RunTimeFormat) {
+	d.timeFormat = f
+}
+
 // SetSize sets dimensions
 func (d *Details) SetSize(width, height int) {
 	d.width = width
@@ -116,6 +123,7 @@ func (d *Details) View() string {
 			b.WriteString("\n\n")
 
 			// Show up to 5 most recent runs
+			now := time.Now()
 			displayCount := min(5, len(d.runs))
 			for i := 0; i < displayCount; i++ {
 				run := d.runs[i]
@@ -124,10 +132,19 @@ func (d *Details) View() string {
 				statusIcon, statusStyle := d.theme.StatusIcon(run.Status, run.Conclusion)
 
 				statusText := statusStyle.Render(statusIcon)
+				runID := fmt.Sprintf("#%d", run.DatabaseID)
+				if badge := run.AttemptBadge(); badge != "" {
+					runID += " " + badge
+				}
 				runInfo := d.theme.Text.Render(
-					fmt.Sprintf(" #%d %s", run.DatabaseID, run.HeadBranch))
-
-				b.WriteString(fmt.Sprintf("%s%s\n", statusText, runInfo))
+					fmt.Sprintf(" %s %s", runID, run.HeadBranch))
+				line := statusText + runInfo
+				// The time goes last and only when the line has room for it
+				if created := " " + d.timeFormat.Format(run.CreatedAt, now); lipgloss.Width(line+created) <= d.width-2 {
+					line += d.theme.TextMuted.Render(created)
+				}
+
+				b.WriteString(line + "\n")
 			}
 
 			if len(d.runs) > displayCount {
@@ -139,7 +156,7 @@ func (d *Details) View() string {
 			b.WriteString("\n")
 
 			// Hint to view full table
-			hintText := d.theme.TextMuted.Render("[r] View all runs")
+			hintText := d.theme.TextMuted.Render("[enter] View all runs")
 			b.WriteString(hintText)
 		} else {
 			noRunsText := d.theme.TextMuted.Render("No runs found")
@@ -155,17 +172,14 @@ func (d *Details) View() string {
 	}
 
 	// Help hint
-	var hints string
 	if d.workflowName != "" {
-		hints = "[w] open in browser [esc] clear"
-	} else {
-		hints = "[d] focus"
+		b.WriteString("\n")
+		b.WriteString(d.theme.TextMuted.Render("[w] open in browser"))
 	}
-	b.WriteString("\n")
-	b.WriteString(d.theme.TextMuted.Render(hints))
 
 	return lipgloss.NewStyle().
 		Width(d.width).
 		Height(d.height).
+		MaxHeight(d.height).
 		Render(b.String())
 }
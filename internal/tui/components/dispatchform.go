@@ -0,0 +1,293 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+// DispatchRequest is returned by the dispatch form when the user confirms
+type DispatchRequest struct {
+	Workflow string
+	Ref      string // "" runs on the default branch
+	Inputs   map[string]string
+}
+
+// DispatchForm is an overlay that collects the ref to run on and the
+// workflow_dispatch inputs. In review mode the values are shown read-only
+// and enter dispatches immediately, which is used to re-run with the
+// previous inputs.
+type DispatchForm struct {
+	active   bool
+	review   bool
+	workflow string
+	ref      string
+	refs     []string // branches offered with ←/→, default branch first
+	inputs   []models.WorkflowInput
+	values   []string
+	cursor   int // 0 is the ref, then one row per input
+	errMsg   string
+	width    int
+	height   int
+	theme    *theme.Theme
+}
+
+func NewDispatchForm(t *theme.Theme) DispatchForm {
+	return DispatchForm{theme: t}
+}
+
+func (f *DispatchForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+func (f *DispatchForm) IsActive() bool {
+	return f.active
+}
+
+// Open shows the form for workflow, running on ref with refs offered as
+// alternatives. Values missing from values fall back to each input's default
+// (or first option for choices).
+func (f *DispatchForm) Open(workflow, ref string, refs []string, inputs []models.WorkflowInput, values map[string]string, review bool) {
+	f.active = true
+	f.review = review
+	f.workflow = workflow
+	f.ref = ref
+	f.refs = refs
+	f.inputs = inputs
+	f.cursor = 0
+	f.errMsg = ""
+	f.values = make([]string, len(inputs))
+	for i, input := range inputs {
+		if v, ok := values[input.Name]; ok {
+			f.values[i] = v
+			continue
+		}
+		f.values[i] = input.Default
+		if f.values[i] == "" {
+			if opts := inputOptions(input); len(opts) > 0 {
+				f.values[i] = opts[0]
+			}
+		}
+	}
+}
+
+func (f *DispatchForm) Close() {
+	f.active = false
+	f.refs = nil
+	f.inputs = nil
+	f.values = nil
+}
+
+// field returns the value of a form row, the values ←/→ cycle through, and
+// whether the value can also be typed. The ref can be typed or picked.
+func (f *DispatchForm) field(row int) (*string, []string, bool) {
+	if row == 0 {
+		return &f.ref, f.refs, true
+	}
+	options := inputOptions(f.inputs[row-1])
+	return &f.values[row-1], options, len(options) == 0
+}
+
+// inputOptions returns the fixed values an input can take, if any
+func inputOptions(input models.WorkflowInput) []string {
+	switch input.Type {
+	case "choice":
+		return input.Options
+	case "boolean":
+		return []string{"true", "false"}
+	}
+	return nil
+}
+
+func (f *DispatchForm) Update(msg tea.Msg) *DispatchRequest {
+	if !f.active {
+		return nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		f.Close()
+		return nil
+	case "enter":
+		return f.submit()
+	}
+
+	if f.review {
+		if keyMsg.String() == "e" {
+			f.review = false
+		}
+		return nil
+	}
+
+	rows := len(f.inputs) + 1
+	value, options, typed := f.field(f.cursor)
+
+	switch keyMsg.String() {
+	case "tab", "down", "ctrl+n":
+		f.cursor = (f.cursor + 1) % rows
+	case "shift+tab", "up", "ctrl+p":
+		f.cursor = (f.cursor - 1 + rows) % rows
+	case "left", "right":
+		if len(options) > 0 {
+			step := 1
+			if keyMsg.String() == "left" {
+				step = len(options) - 1
+			}
+			idx := max(slices.Index(options, *value), 0)
+			*value = options[(idx+step)%len(options)]
+		}
+	case "backspace":
+		if typed && len(*value) > 0 {
+			runes := []rune(*value)
+			*value = string(runes[:len(runes)-1])
+		}
+	default:
+		if typed && (keyMsg.Type == tea.KeyRunes || keyMsg.Type == tea.KeySpace) {
+			*value += string(keyMsg.Runes)
+		}
+	}
+	f.errMsg = ""
+	return nil
+}
+
+// submit validates required inputs and returns the request to dispatch
+func (f *DispatchForm) submit() *DispatchRequest {
+	inputs := make(map[string]string, len(f.inputs))
+	for i, input := range f.inputs {
+		value := strings.TrimSpace(f.values[i])
+		if value == "" {
+			if input.Required {
+				f.errMsg = fmt.Sprintf("%s is required", input.Name)
+				f.cursor = i + 1
+				f.review = false
+				return nil
+			}
+			continue
+		}
+		inputs[input.Name] = value
+	}
+
+	req := &DispatchRequest{Workflow: f.workflow, Ref: strings.TrimSpace(f.ref), Inputs: inputs}
+	f.Close()
+	return req
+}
+
+func (f *DispatchForm) View() string {
+	if !f.active {
+		return ""
+	}
+
+	overlayWidth := max(50, f.width*60/100)
+	overlayHeight := max(12, min(f.height-4, (len(f.inputs)+1)*3+10))
+
+	var b strings.Builder
+
+	title := "Dispatch " + f.workflow
+	if f.review {
+		title = "Dispatch " + f.workflow + " with previous inputs"
+	}
+	b.WriteString(f.theme.Title.Render(title))
+	b.WriteString("\n")
+	b.WriteString(f.theme.Divider(overlayWidth - 8))
+	b.WriteString("\n\n")
+
+	refSelected := f.cursor == 0 && !f.review
+	label := f.theme.Text.Render(f.theme.ItemPrefix(refSelected) + "ref")
+	if refSelected {
+		label = f.theme.Selected.Render(f.theme.ItemPrefix(refSelected) + "ref")
+	}
+	b.WriteString(label)
+	b.WriteString(f.theme.TextDim.Render(" - Branch or tag to run on"))
+	b.WriteString("\n  ")
+	switch {
+	case refSelected:
+		b.WriteString(f.theme.FilterInput.Render(f.ref + "█"))
+		if len(f.refs) > 0 {
+			b.WriteString(f.theme.TextMuted.Render("  ←/→ branches"))
+		}
+	case f.ref == "":
+		b.WriteString(f.theme.TextMuted.Render("(default branch)"))
+	default:
+		b.WriteString(f.theme.Text.Render(f.ref))
+	}
+	b.WriteString("\n")
+
+	if len(f.inputs) == 0 {
+		b.WriteString(f.theme.TextDim.Render("This workflow has no inputs."))
+		b.WriteString("\n")
+	}
+
+	for i, input := range f.inputs {
+		selected := i+1 == f.cursor && !f.review
+
+		label := input.Name
+		if input.Required {
+			label += " *"
+		}
+		prefix := f.theme.ItemPrefix(selected)
+		if selected {
+			b.WriteString(f.theme.Selected.Render(prefix + label))
+		} else {
+			b.WriteString(f.theme.Text.Render(prefix + label))
+		}
+		if input.Description != "" {
+			b.WriteString(f.theme.TextDim.Render(" - " + input.Description))
+		}
+		b.WriteString("\n  ")
+
+		value := f.values[i]
+		switch {
+		case len(inputOptions(input)) > 0 && selected:
+			b.WriteString(f.theme.FilterInput.Render("◂ " + value + " ▸"))
+		case selected:
+			b.WriteString(f.theme.FilterInput.Render(value + "█"))
+		case value == "":
+			b.WriteString(f.theme.TextMuted.Render("(empty)"))
+		default:
+			b.WriteString(f.theme.Text.Render(value))
+		}
+		b.WriteString("\n")
+	}
+
+	if f.errMsg != "" {
+		b.WriteString("\n")
+		b.WriteString(f.theme.StatusError.Render(f.errMsg))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if f.review {
+		b.WriteString(f.theme.TextMuted.Render("[enter] dispatch [e] edit [esc] cancel"))
+	} else {
+		b.WriteString(f.theme.TextMuted.Render("[tab] next [←/→] pick [enter] dispatch [esc] cancel"))
+	}
+
+	overlayContent := lipgloss.NewStyle().
+		Width(overlayWidth-4).
+		Height(overlayHeight-2).
+		Padding(1, 2).
+		Render(b.String())
+
+	overlayBox := f.theme.BorderActive.
+		Render(overlayContent)
+
+	return lipgloss.Place(
+		f.width,
+		f.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		overlayBox,
+	)
+}
@@ -1,10 +1,12 @@
 package components
 
 import (
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 
 	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
 )
@@ -12,6 +14,7 @@ import (
 type KeyBinding struct {
 	Key         string
 	Description string
+	Hint        string // Short label for the help bar; empty keeps it out of the bar
 }
 
 type KeySection struct {
@@ -20,12 +23,15 @@ type KeySection struct {
 }
 
 type HelpOverlay struct {
-	active   bool
-	sections []KeySection
-	scroll   int
-	width    int
-	height   int
-	theme    *theme.Theme
+	active       bool
+	context      *KeySection
+	sections     []KeySection
+	scroll       int
+	filterActive bool
+	filterInput  string
+	width        int
+	height       int
+	theme        *theme.Theme
 }
 
 func NewHelpOverlay(t *theme.Theme) HelpOverlay {
@@ -44,14 +50,98 @@ func (h *HelpOverlay) IsActive() bool {
 	return h.active
 }
 
+// SetContext sets the bindings available in the current view. They are shown
+// first and highlighted, ahead of the full reference.
+func (h *HelpOverlay) SetContext(section KeySection) {
+	h.context = &section
+}
+
 func (h *HelpOverlay) Toggle() {
-	h.active = !h.active
-	h.scroll = 0
+	if h.active {
+		h.Close()
+		return
+	}
+	h.active = true
 }
 
 func (h *HelpOverlay) Close() {
 	h.active = false
 	h.scroll = 0
+	h.clearFilter()
+}
+
+func (h *HelpOverlay) clearFilter() {
+	h.filterActive = false
+	h.filterInput = ""
+	h.scroll = 0
+}
+
+// handleFilterKey edits the filter while it is being typed
+func (h *HelpOverlay) handleFilterKey(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		h.clearFilter()
+	case tea.KeyEnter:
+		h.filterActive = false
+	case tea.KeyBackspace:
+		if len(h.filterInput) > 0 {
+			runes := []rune(h.filterInput)
+			h.filterInput = string(runes[:len(runes)-1])
+		}
+		h.scroll = 0
+	case tea.KeyUp, tea.KeyCtrlP:
+		if h.scroll > 0 {
+			h.scroll--
+		}
+	case tea.KeyDown, tea.KeyCtrlN:
+		h.scroll++
+	case tea.KeyRunes, tea.KeySpace:
+		h.filterInput += string(msg.Runes)
+		h.scroll = 0
+	}
+}
+
+// visibleSections returns the sections narrowed to bindings whose key or
+// description fuzzy-matches the filter. Sections without a match are dropped.
+func (h *HelpOverlay) visibleSections() []KeySection {
+	all := h.sections
+	if h.context != nil && len(h.context.Bindings) > 0 {
+		all = append([]KeySection{*h.context}, h.sections...)
+	}
+	if h.filterInput == "" {
+		return all
+	}
+
+	var sections []KeySection
+	for _, section := range all {
+		matches := fuzzy.FindFrom(h.filterInput, keyBindingSource(section.Bindings))
+		if len(matches) == 0 {
+			continue
+		}
+		indexes := make([]int, len(matches))
+		for i, match := range matches {
+			indexes[i] = match.Index
+		}
+		slices.Sort(indexes)
+
+		filtered := KeySection{Title: section.Title}
+		for _, i := range indexes {
+			filtered.Bindings = append(filtered.Bindings, section.Bindings[i])
+		}
+		sections = append(sections, filtered)
+	}
+	return sections
+}
+
+// keyBindingSource implements fuzzy.Source for KeyBindings
+type keyBindingSource []KeyBinding
+
+func (s keyBindingSource) String(i int) string {
+	return s[i].Key + " " + s[i].Description
+}
+
+func (s keyBindingSource) Len() int {
+	return len(s)
 }
 
 func (h *HelpOverlay) Update(msg tea.Msg) tea.Cmd {
@@ -61,8 +151,22 @@ func (h *HelpOverlay) Update(msg tea.Msg) tea.Cmd {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if h.filterActive {
+			h.handleFilterKey(msg)
+			return nil
+		}
 		switch msg.String() {
-		case "esc", "q", "?":
+		case "/":
+			h.filterActive = true
+			h.filterInput = ""
+			h.scroll = 0
+		case "esc":
+			if h.filterInput != "" {
+				h.clearFilter()
+			} else {
+				h.Close()
+			}
+		case "q", "?":
 			h.Close()
 		case "j", "down":
 			h.scroll++
@@ -94,8 +198,16 @@ func (h *HelpOverlay) View() string {
 
 	// Build all content lines
 	var lines []string
-	for _, section := range h.sections {
-		lines = append(lines, sectionStyle.Render(section.Title))
+	sections := h.visibleSections()
+	if len(sections) == 0 {
+		lines = append(lines, h.theme.TextDim.Render("No matching shortcuts"))
+	}
+	for i, section := range sections {
+		if i == 0 && h.context != nil && section.Title == h.context.Title {
+			lines = append(lines, h.theme.TitleActive.Render(" "+section.Title+" "))
+		} else {
+			lines = append(lines, sectionStyle.Render(section.Title))
+		}
 		lines = append(lines, "")
 		for _, binding := range section.Bindings {
 			keyWidth := 20
@@ -108,6 +220,10 @@ func (h *HelpOverlay) View() string {
 
 	// Calculate visible area (account for title, footer, padding, and borders)
 	maxVisible := overlayHeight - 8
+	showFilter := h.filterActive || h.filterInput != ""
+	if showFilter {
+		maxVisible -= 2
+	}
 	if maxVisible < 5 {
 		maxVisible = 5
 	}
@@ -132,13 +248,22 @@ func (h *HelpOverlay) View() string {
 	b.WriteString(lipgloss.PlaceHorizontal(overlayWidth-4, lipgloss.Center, title))
 	b.WriteString("\n\n")
 
-	// Visible lines
-	for i := visibleStart; i < visibleEnd; i++ {
-		b.WriteString(lines[i])
-		if i < visibleEnd-1 {
-			b.WriteString("\n")
+	if showFilter {
+		filter := h.theme.FilterPrompt.Render(h.theme.Icons.Filter+" ") + h.theme.FilterInput.Render(h.filterInput)
+		if h.filterActive {
+			filter += h.theme.FilterInput.Render("█")
 		}
+		b.WriteString(filter)
+		b.WriteString("\n\n")
+	}
+
+	// Visible lines, with a scrollbar when they do not all fit
+	visibleLines := strings.Join(lines[visibleStart:visibleEnd], "\n")
+	if len(lines) > maxVisible {
+		bar := scrollbar(h.theme, visibleEnd-visibleStart, len(lines), visibleStart, visibleEnd-visibleStart)
+		visibleLines = withScrollbar(visibleLines, overlayWidth-8, visibleEnd-visibleStart, 0, bar)
 	}
+	b.WriteString(visibleLines)
 
 	// Footer with scroll indicator and close instruction
 	b.WriteString("\n\n")
@@ -148,7 +273,7 @@ func (h *HelpOverlay) View() string {
 		b.WriteString(scrollInfo)
 		b.WriteString("\n")
 	}
-	closeInfo := h.theme.TextMuted.Render("Press ? or esc to close")
+	closeInfo := h.theme.TextMuted.Render("Press / to filter, ? or esc to close")
 	b.WriteString(lipgloss.PlaceHorizontal(overlayWidth-4, lipgloss.Left, closeInfo))
 
 	// Render content with proper styling
@@ -185,8 +310,10 @@ func DefaultKeySections() []KeySection {
 			Bindings: []KeyBinding{
 				{Key: "q / Ctrl+c", Description: "Quit"},
 				{Key: "?", Description: "Toggle help"},
+				{Key: "K", Description: "Toggle the key legend"},
 				{Key: ":", Description: "Command palette"},
 				{Key: "Ctrl+f", Description: "Global search"},
+				{Key: "Ctrl+g", Description: "Jump to group"},
 				{Key: "Tab", Description: "Cycle panels forward"},
 				{Key: "Shift+Tab", Description: "Cycle panels backward"},
 				{Key: "1", Description: "Toggle sidebar"},
@@ -215,9 +342,31 @@ func DefaultKeySections() []KeySection {
 			Title: "Actions",
 			Bindings: []KeyBinding{
 				{Key: "p", Description: "Pin/unpin workflow"},
+				{Key: "space", Description: "Mark workflow; p and w then act on all marked"},
+				{Key: "f", Description: "Star/unstar group"},
 				{Key: "w", Description: "Open in browser"},
+				{Key: "i", Description: "Show the workflow's latest run"},
+				{Key: "Y", Description: "Copy workflow filename"},
+				{Key: "A", Description: "Open a run waiting for approval"},
+				{Key: "enter", Description: "Open run as openBehavior says: browser, log or jobs"},
+				{Key: "o", Description: "Open run in browser"},
+				{Key: "J", Description: "Show the run's jobs"},
+				{Key: "n", Description: "Show the run's check annotations"},
+				{Key: "T", Description: "Tag or untag a run"},
+				{Key: "#", Description: "Show only runs with a tag"},
+				{Key: "F", Description: "Re-run a failed run's failed jobs and follow it"},
+				{Key: "b", Description: "Filter runs to highlighted branches"},
+				{Key: "v", Description: "Toggle health grouping, or view a run's log"},
+				{Key: "x", Description: "Dispatch workflow"},
+				{Key: "X", Description: "Dispatch with last inputs"},
 				{Key: "Ctrl+r", Description: "Refresh data"},
+				{Key: "Ctrl+o", Description: "Open the run a dispatch or re-run just started"},
 				{Key: "Ctrl+t", Description: "Toggle auto-refresh"},
+				{Key: "R", Description: "Reload config files"},
+				{Key: "ctrl+l", Description: "View the current directory's repository"},
+				{Key: "t", Description: "Toggle workflow names and filenames"},
+				{Key: "D", Description: "Show or hide workflow descriptions"},
+				{Key: "V", Description: "View the workflow's YAML"},
 			},
 		},
 		{
@@ -227,6 +376,7 @@ func DefaultKeySections() []KeySection {
 				{Key: "↑/↓ or Ctrl+p/n", Description: "Navigate while typing"},
 				{Key: "Enter", Description: "Confirm and exit filter"},
 				{Key: "Esc", Description: "Clear filter"},
+				{Key: "failing / passing / running", Description: "Show workflows by latest run"},
 				{Key: "n / N", Description: "Next/Previous match (after filter)"},
 			},
 		},
@@ -235,6 +385,8 @@ func DefaultKeySections() []KeySection {
 			Bindings: []KeyBinding{
 				{Key: "Tab", Description: "Autocomplete command"},
 				{Key: "Enter", Description: "Execute command"},
+				{Key: "Alt+enter", Description: "Execute command and keep the palette open"},
+				{Key: "Ctrl+s", Description: "Keep the palette open after every command"},
 				{Key: "Esc", Description: "Close palette"},
 			},
 		},
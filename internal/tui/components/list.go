@@ -18,7 +18,10 @@ type ListItem struct {
 	Title       string
 	Description string
 	Icon        string
-	Data        interface{} // Arbitrary data attached to the item
+	// AltTitle replaces Title, which then becomes the description, while the
+	// list shows alternate titles. Empty for items that have none.
+	AltTitle string
+	Data     interface{} // Arbitrary data attached to the item
 }
 
 // FilterValue implements fuzzy.Source
@@ -26,18 +29,36 @@ func (i ListItem) FilterValue() string {
 	return i.Title
 }
 
+// FilterPredicate is a reserved filter keyword that selects items by a
+// condition instead of fuzzy matching their titles
+type FilterPredicate struct {
+	Keyword     string
+	Description string // what the predicate shows, e.g. "failing workflows"
+	Match       func(ListItem) bool
+}
+
 // List is a filterable, navigable list component
 type List struct {
 	items         []ListItem
 	filteredItems []ListItem
-	cursor        int
-	filterInput   string
-	filterActive  bool
-	width         int
-	height        int
-	title         string
-	focused       bool
-	theme         *theme.Theme
+	// filteredIndices maps each filtered item to its index in items, nil
+	// while every item is shown
+	filteredIndices []int
+	// marked holds the indices in items of the items marked for a batch
+	// action
+	marked       map[int]bool
+	cursor       int
+	filterInput  string
+	filterActive bool
+	predicates   []FilterPredicate
+	width        int
+	height       int
+	title        string
+	focused      bool
+	altTitles    bool
+	// hideDescriptions lists items on a single line each
+	hideDescriptions bool
+	theme            *theme.Theme
 }
 
 // NewList creates a new list component
@@ -51,8 +72,20 @@ func NewList(t *theme.Theme, title string) List {
 	}
 }
 
-// SetItems sets the list items
+// SetItems sets the list items. Marked items stay marked if an item with
+// the same ID is still listed.
 func (l *List) SetItems(items []ListItem) {
+	markedIDs := make(map[string]bool, len(l.marked))
+	for i := range l.marked {
+		markedIDs[l.items[i].ID] = true
+	}
+	l.marked = nil
+	for i, item := range items {
+		if markedIDs[item.ID] {
+			l.markIndex(i)
+		}
+	}
+
 	l.items = items
 	l.applyFilter()
 	// Reset cursor if out of bounds
@@ -77,6 +110,92 @@ func (l *List) SetSize(width, height int) {
 	l.height = height
 }
 
+// SetAltTitles shows each item's AltTitle, where it has one, as its title.
+// Filtering matches the titles shown.
+func (l *List) SetAltTitles(alt bool) {
+	l.altTitles = alt
+	if l.filterInput != "" {
+		l.applyFilter()
+	}
+}
+
+// SetHideDescriptions shows items without their description line, fitting
+// twice as many in the same height
+func (l *List) SetHideDescriptions(hide bool) {
+	l.hideDescriptions = hide
+}
+
+// DescriptionsHidden reports whether items are shown without descriptions
+func (l *List) DescriptionsHidden() bool {
+	return l.hideDescriptions
+}
+
+// labels returns the title and description item is shown with
+func (l *List) labels(item ListItem) (title, description string) {
+	if l.altTitles && item.AltTitle != "" {
+		return item.AltTitle, item.Title
+	}
+	return item.Title, item.Description
+}
+
+// markIndex marks the item at index i of items
+func (l *List) markIndex(i int) {
+	if l.marked == nil {
+		l.marked = make(map[int]bool)
+	}
+	l.marked[i] = true
+}
+
+// sourceIndex returns the index in items of the filtered item at pos
+func (l *List) sourceIndex(pos int) int {
+	if l.filteredIndices == nil {
+		return pos
+	}
+	return l.filteredIndices[pos]
+}
+
+// ToggleMark marks or unmarks the selected item and moves the cursor down,
+// so consecutive items can be marked by repeating the key
+func (l *List) ToggleMark() {
+	if l.cursor < 0 || l.cursor >= len(l.filteredItems) {
+		return
+	}
+	i := l.sourceIndex(l.cursor)
+	if l.marked[i] {
+		delete(l.marked, i)
+	} else {
+		l.markIndex(i)
+	}
+	l.moveDown()
+}
+
+// IsMarked reports whether the filtered item at pos is marked
+func (l *List) IsMarked(pos int) bool {
+	return pos >= 0 && pos < len(l.filteredItems) && l.marked[l.sourceIndex(pos)]
+}
+
+// MarkedItems returns the marked items in list order, including ones the
+// filter hides
+func (l *List) MarkedItems() []ListItem {
+	var marked []ListItem
+	for i, item := range l.items {
+		if l.marked[i] {
+			marked = append(marked, item)
+		}
+	}
+	return marked
+}
+
+// MarkedCount returns how many items are marked
+func (l *List) MarkedCount() int {
+	return len(l.marked)
+}
+
+// ClearMarks unmarks every item
+func (l *List) ClearMarks() {
+	l.marked = nil
+}
+
 // SetFocused sets the focus state
 func (l *List) SetFocused(focused bool) {
 	l.focused = focused
@@ -122,11 +241,36 @@ func (l *List) HasFilter() bool {
 	return l.filterInput != ""
 }
 
+// FilterCounts returns how many items the filter shows and how many there
+// are in total
+func (l *List) FilterCounts() (shown, total int) {
+	return len(l.filteredItems), len(l.items)
+}
+
 // FilterInput returns the current filter text
 func (l *List) FilterInput() string {
 	return l.filterInput
 }
 
+// SetFilterPredicates sets the keywords that filter by predicate. A filter
+// that is exactly one of the keywords, ignoring case, applies its predicate;
+// anything else is fuzzy matched against item titles.
+func (l *List) SetFilterPredicates(predicates []FilterPredicate) {
+	l.predicates = predicates
+	l.applyFilter()
+}
+
+// ActivePredicate returns the predicate the current filter selects, if any
+func (l *List) ActivePredicate() *FilterPredicate {
+	keyword := strings.TrimSpace(l.filterInput)
+	for i := range l.predicates {
+		if strings.EqualFold(keyword, l.predicates[i].Keyword) {
+			return &l.predicates[i]
+		}
+	}
+	return nil
+}
+
 // ClearFilter clears the filter
 func (l *List) ClearFilter() {
 	l.filterInput = ""
@@ -134,6 +278,13 @@ func (l *List) ClearFilter() {
 	l.applyFilter()
 }
 
+// SetFilter applies filter as if it had been typed and confirmed
+func (l *List) SetFilter(filter string) {
+	l.filterInput = filter
+	l.filterActive = false
+	l.refilter()
+}
+
 // StartFilter begins filter input mode
 func (l *List) StartFilter() {
 	l.filterActive = true
@@ -144,26 +295,52 @@ func (l *List) StartFilter() {
 func (l *List) applyFilter() {
 	if l.filterInput == "" {
 		l.filteredItems = l.items
+		l.filteredIndices = nil
+		return
+	}
+
+	if predicate := l.ActivePredicate(); predicate != nil {
+		l.filteredItems = make([]ListItem, 0, len(l.items))
+		l.filteredIndices = make([]int, 0, len(l.items))
+		for i, item := range l.items {
+			if predicate.Match(item) {
+				l.filteredItems = append(l.filteredItems, item)
+				l.filteredIndices = append(l.filteredIndices, i)
+			}
+		}
 		return
 	}
 
 	// Use fuzzy matching
-	matches := fuzzy.FindFrom(l.filterInput, listItemSource(l.items))
+	matches := fuzzy.FindFrom(l.filterInput, listItemSource{list: l})
 	l.filteredItems = make([]ListItem, len(matches))
+	l.filteredIndices = make([]int, len(matches))
 	for i, match := range matches {
 		l.filteredItems[i] = l.items[match.Index]
+		l.filteredIndices[i] = match.Index
 	}
 }
 
-// listItemSource implements fuzzy.Source for ListItems
-type listItemSource []ListItem
+// refilter reapplies the filter after its input changed. The cursor moves
+// to the first match, since its old index may point at an unrelated item.
+func (l *List) refilter() {
+	l.applyFilter()
+	l.cursor = 0
+}
+
+// listItemSource implements fuzzy.Source for a list's items, matching the
+// titles they are shown with
+type listItemSource struct {
+	list *List
+}
 
 func (s listItemSource) String(i int) string {
-	return s[i].Title
+	title, _ := s.list.labels(s.list.items[i])
+	return title
 }
 
 func (s listItemSource) Len() int {
-	return len(s)
+	return len(s.list.items)
 }
 
 // Update handles input messages
@@ -184,8 +361,7 @@ func (l *List) handleKey(msg tea.KeyMsg) tea.Cmd {
 		case "esc":
 			l.filterActive = false
 			l.filterInput = ""
-			l.applyFilter()
-			l.cursor = 0
+			l.refilter()
 			return nil
 		case "ctrl+n", "down":
 			l.moveDown()
@@ -196,20 +372,14 @@ func (l *List) handleKey(msg tea.KeyMsg) tea.Cmd {
 		case "backspace":
 			if len(l.filterInput) > 0 {
 				l.filterInput = l.filterInput[:len(l.filterInput)-1]
-				l.applyFilter()
-				if l.cursor >= len(l.filteredItems) {
-					l.cursor = max(0, len(l.filteredItems)-1)
-				}
+				l.refilter()
 			}
 			return nil
 		default:
 			key := msg.String()
 			if len(key) == 1 {
 				l.filterInput += key
-				l.applyFilter()
-				if l.cursor >= len(l.filteredItems) {
-					l.cursor = max(0, len(l.filteredItems)-1)
-				}
+				l.refilter()
 			}
 			return nil
 		}
@@ -286,12 +456,21 @@ func (l *List) View() string {
 	b.WriteString("\n")
 
 	// Render filter input if active
+	predicate := l.ActivePredicate()
 	if l.filterActive {
 		cursor := "█"
 		filterLine := l.theme.FilterPrompt.Render(l.theme.Icons.Filter+" ") +
 			l.theme.FilterInput.Render(l.filterInput+cursor)
+		if predicate != nil {
+			filterLine += l.theme.StatusWarning.Render("  showing " + predicate.Description)
+		}
 		b.WriteString(filterLine)
 		b.WriteString("\n")
+	} else if predicate != nil {
+		filterInfo := l.theme.StatusWarning.Render(
+			fmt.Sprintf("%s Showing %s [esc] clear", l.theme.Icons.Filter, predicate.Description))
+		b.WriteString(filterInfo)
+		b.WriteString("\n")
 	} else if l.filterInput != "" {
 		filterInfo := l.theme.TextMuted.Render(
 			fmt.Sprintf("%s Filtered: %q [esc] clear", l.theme.Icons.Search, l.filterInput))
@@ -300,8 +479,14 @@ func (l *List) View() string {
 	}
 
 	// Calculate visible window
-	visibleCount := max(1, availableHeight/2) // 2 lines per item (title + desc)
+	linesPerItem := 2 // title + desc
+	if l.hideDescriptions {
+		linesPerItem = 1
+	}
+	visibleCount := max(1, availableHeight/linesPerItem)
 	visibleStart, visibleEnd := l.calculateVisibleWindow(visibleCount)
+	var bar []string
+	barTop := 0
 
 	// Render items
 	if len(l.filteredItems) == 0 {
@@ -319,25 +504,30 @@ func (l *List) View() string {
 			b.WriteString(scrollInfo)
 			b.WriteString("\n")
 		}
+		barTop = strings.Count(b.String(), "\n")
 
 		for i := visibleStart; i < visibleEnd; i++ {
 			item := l.filteredItems[i]
 			isSelected := i == l.cursor && l.focused
 
-			// Prefix
+			// Prefix, with the mark in place of the blank for marked items
 			prefix := l.theme.ItemPrefix(isSelected)
+			if l.IsMarked(i) {
+				prefix = l.theme.Icons.Marked + " "
+				if isSelected {
+					prefix = l.theme.Icons.Selected + l.theme.Icons.Marked
+				}
+			}
 
 			// Title with icon
-			titleText := item.Title
+			titleText, description := l.labels(item)
 			if item.Icon != "" {
 				titleText = item.Icon + " " + titleText
 			}
 
 			// Truncate if needed
 			maxWidth := l.width - 6
-			if len(titleText) > maxWidth {
-				titleText = titleText[:maxWidth-3] + "..."
-			}
+			titleText = truncate(titleText, maxWidth)
 
 			// Style based on selection
 			var titleLine string
@@ -350,16 +540,18 @@ func (l *List) View() string {
 			b.WriteString("\n")
 
 			// Description
-			if item.Description != "" {
-				desc := item.Description
-				if len(desc) > maxWidth-2 {
-					desc = desc[:maxWidth-5] + "..."
-				}
+			if description != "" && !l.hideDescriptions {
+				desc := truncate(description, maxWidth-2)
 				descLine := l.theme.TextDim.Render("    " + desc)
 				b.WriteString(descLine)
 				b.WriteString("\n")
 			}
 		}
+
+		if len(l.filteredItems) > visibleCount {
+			barHeight := strings.Count(b.String(), "\n") - barTop
+			bar = scrollbar(l.theme, barHeight, len(l.filteredItems), visibleStart, visibleEnd-visibleStart)
+		}
 	}
 
 	// Pad remaining height
@@ -372,6 +564,9 @@ func (l *List) View() string {
 	var hints string
 	if l.filterActive {
 		hints = "[↑/↓] navigate [enter] done [esc] clear"
+	} else if len(l.marked) > 0 {
+		b.WriteString(l.theme.StatusWarning.Render(fmt.Sprintf("%s %d marked ", l.theme.Icons.Marked, len(l.marked))))
+		hints = "[space] mark [esc] unmark"
 	} else if l.filterInput != "" {
 		hints = "[n/N] next/prev [esc] clear"
 	} else {
@@ -379,6 +574,9 @@ func (l *List) View() string {
 	}
 	b.WriteString(l.theme.TextMuted.Render(hints))
 
+	if bar != nil {
+		return withScrollbar(b.String(), l.width, l.height, barTop, bar)
+	}
 	return lipgloss.NewStyle().
 		Width(l.width).
 		Height(l.height).
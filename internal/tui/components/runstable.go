@@ -2,8 +2,10 @@ package components
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -22,6 +24,15 @@ const (
 	colCreated    = "created"
 )
 
+// longQueue is how long a run may wait to start before its wait is
+// highlighted, such as when no self-hosted runner picks it up
+const longQueue = 10 * time.Minute
+
+// runsTableChrome is the number of lines around the table rows: the title,
+// status and blank lines and the hints, plus the table's borders, header
+// and page footer
+const runsTableChrome = 10
+
 // RunsTable displays workflow runs in a table
 type RunsTable struct {
 	table        table.Model
@@ -34,15 +45,37 @@ type RunsTable struct {
 	loading      bool
 	err          error
 	theme        *theme.Theme
-	pageSize     int
+	pageSize     int // 0 fits the page to the height
+
+	// branchMatcher reports whether a branch should be highlighted
+	branchMatcher func(branch string) bool
+	branchFilter  bool
+
+	// annotations holds the annotation counts of runs whose annotations
+	// have been fetched
+	annotations map[int]models.AnnotationSummary
+
+	// tags holds the labels the user gave runs, by run ID, and tagFilter
+	// the tag runs must have to be shown, "" for every run
+	tags      map[int][]string
+	tagFilter string
+
+	// info describes the workflow file, shown in the header once fetched
+	info *models.WorkflowInfo
+
+	// timeFormat renders the Created column
+	timeFormat RunTimeFormat
+
+	// now is the time queued runs' waits are measured to, replaced in tests
+	now func() time.Time
 }
 
 // NewRunsTable creates a new runs table component
 func NewRunsTable(t *theme.Theme) RunsTable {
 	return RunsTable{
-		theme:    t,
-		visible:  false,
-		pageSize: 15,
+		theme:   t,
+		visible: false,
+		now:     time.Now,
 	}
 }
 
@@ -70,6 +103,21 @@ func (r *RunsTable) SetSize(width, height int) {
 	r.rebuildTable()
 }
 
+// SetPageSize fixes the number of runs per page. Zero fits the page to the
+// table's height.
+func (r *RunsTable) SetPageSize(size int) {
+	r.pageSize = size
+	r.rebuildTable()
+}
+
+// PageSize returns the number of runs per page at the current size
+func (r *RunsTable) PageSize() int {
+	if r.pageSize > 0 {
+		return r.pageSize
+	}
+	return max(1, r.height-runsTableChrome)
+}
+
 // SetFocused sets focus state
 func (r *RunsTable) SetFocused(focused bool) {
 	r.focused = focused
@@ -108,6 +156,66 @@ func (r *RunsTable) SetError(err error) {
 	r.err = err
 }
 
+// SetBranchMatcher sets the function used to highlight and filter runs by branch
+func (r *RunsTable) SetBranchMatcher(fn func(branch string) bool) {
+	r.branchMatcher = fn
+	r.rebuildTable()
+}
+
+// SetTimeFormat sets how the Created column renders run times
+func (r *RunsTable) SetTimeFormat(f RunTimeFormat) {
+	r.timeFormat = f
+	r.rebuildTable()
+}
+
+// SetAnnotationSummary shows a run's error and warning annotation counts
+// next to its title
+func (r *RunsTable) SetAnnotationSummary(runID int, summary models.AnnotationSummary) {
+	if r.annotations == nil {
+		r.annotations = make(map[int]models.AnnotationSummary)
+	}
+	r.annotations[runID] = summary
+	r.rebuildTable()
+}
+
+// SetRunTags shows the labels the user gave runs, keyed by run ID
+func (r *RunsTable) SetRunTags(tags map[int][]string) {
+	r.tags = tags
+	r.rebuildTable()
+}
+
+// SetTagFilter shows only runs tagged tag, or every run when tag is ""
+func (r *RunsTable) SetTagFilter(tag string) {
+	r.tagFilter = tag
+	r.table = r.table.WithHighlightedRow(0)
+	r.rebuildTable()
+}
+
+// TagFilter returns the tag runs are filtered to, "" when they are not
+func (r *RunsTable) TagFilter() string {
+	return r.tagFilter
+}
+
+// HasBranchMatcher returns whether branch highlighting is configured
+func (r *RunsTable) HasBranchMatcher() bool {
+	return r.branchMatcher != nil
+}
+
+// ToggleBranchFilter toggles showing only runs on highlighted branches
+func (r *RunsTable) ToggleBranchFilter() {
+	if r.branchMatcher == nil {
+		return
+	}
+	r.branchFilter = !r.branchFilter
+	r.table = r.table.WithHighlightedRow(0)
+	r.rebuildTable()
+}
+
+// IsBranchFiltered returns whether the highlighted-branch filter is active
+func (r *RunsTable) IsBranchFiltered() bool {
+	return r.branchFilter
+}
+
 // SelectedRunID returns the ID of the selected run
 func (r *RunsTable) SelectedRunID() int {
 	row := r.table.HighlightedRow()
@@ -124,16 +232,172 @@ func (r *RunsTable) SelectedRunID() int {
 	return 0
 }
 
+// SelectedRun returns the highlighted run, or nil when there is none
+func (r *RunsTable) SelectedRun() *models.GHRun {
+	id := r.SelectedRunID()
+	for i := range r.runs {
+		if r.runs[i].DatabaseID == id {
+			return &r.runs[i]
+		}
+	}
+	return nil
+}
+
+// SelectRun highlights the visible run with runID, reporting whether there
+// is one
+func (r *RunsTable) SelectRun(runID int) bool {
+	for i, run := range r.VisibleRuns() {
+		if run.DatabaseID == runID {
+			r.table = r.table.WithHighlightedRow(i)
+			return true
+		}
+	}
+	return false
+}
+
 // Runs returns the current runs
 func (r *RunsTable) Runs() []models.GHRun {
 	return r.runs
 }
 
+// VisibleRuns returns the runs shown after applying the branch and tag
+// filters
+func (r *RunsTable) VisibleRuns() []models.GHRun {
+	byBranch := r.branchFilter && r.branchMatcher != nil
+	if !byBranch && r.tagFilter == "" {
+		return r.runs
+	}
+	visible := make([]models.GHRun, 0, len(r.runs))
+	for _, run := range r.runs {
+		if byBranch && !r.branchMatcher(run.HeadBranch) {
+			continue
+		}
+		if r.tagFilter != "" && !slices.Contains(r.tags[run.DatabaseID], r.tagFilter) {
+			continue
+		}
+		visible = append(visible, run)
+	}
+	return visible
+}
+
+func (r *RunsTable) isHighlightedBranch(branch string) bool {
+	return r.branchMatcher != nil && r.branchMatcher(branch)
+}
+
+// AttentionRunID returns the run to approve: the highlighted run if it needs
+// attention, otherwise the newest visible run that does. It returns 0 when
+// no run is waiting on approval.
+func (r *RunsTable) AttentionRunID() int {
+	runs := r.VisibleRuns()
+	if idx := r.table.GetHighlightedRowIndex(); idx >= 0 && idx < len(runs) && runs[idx].NeedsAttention() {
+		return runs[idx].DatabaseID
+	}
+	for _, run := range runs {
+		if run.NeedsAttention() {
+			return run.DatabaseID
+		}
+	}
+	return 0
+}
+
+// attentionCount returns how many visible runs are waiting on approval
+func (r *RunsTable) attentionCount() int {
+	count := 0
+	for _, run := range r.VisibleRuns() {
+		if run.NeedsAttention() {
+			count++
+		}
+	}
+	return count
+}
+
+// annotationCounts formats a run's fetched annotation counts, such as
+// "✗2 !1", or returns "" when there are none
+func (r *RunsTable) annotationCounts(runID int) string {
+	summary := r.annotations[runID]
+	var parts []string
+	if summary.Errors > 0 {
+		parts = append(parts, fmt.Sprintf("%s%d", r.theme.Icons.Error, summary.Errors))
+	}
+	if summary.Warnings > 0 {
+		parts = append(parts, fmt.Sprintf("%s%d", r.theme.Icons.ActionRequired, summary.Warnings))
+	}
+	return strings.Join(parts, " ")
+}
+
+// SetWorkflowInfo shows a workflow's path and triggers in the header while
+// its runs are shown
+func (r *RunsTable) SetWorkflowInfo(info *models.WorkflowInfo) {
+	r.info = info
+}
+
+// workflowInfo returns the info of the shown workflow, or nil
+func (r *RunsTable) workflowInfo() *models.WorkflowInfo {
+	if r.info == nil || r.info.File != r.workflowName {
+		return nil
+	}
+	return r.info
+}
+
 // WorkflowName returns the current workflow name
 func (r *RunsTable) WorkflowName() string {
 	return r.workflowName
 }
 
+// runsTableColumns sizes the columns to fit width, with Created fitting
+// times createdWidth wide, returning them and the title column's width. The title takes the slack; when it would drop below
+// a readable width, Created and then Conclusion are hidden and Branch
+// narrowed, so the table never wraps inside its panel.
+func runsTableColumns(width, createdWidth int) ([]table.Column, int) {
+	const minTitleWidth = 20
+
+	type column struct {
+		key, title string
+		width      int
+	}
+	columns := []column{
+		{colID, "ID", 10},
+		{colTitle, "Title", 0},
+		{colStatus, "Status", 12},
+		{colConclusion, "Conclusion", 18}, // fits "! action_required"
+		{colBranch, "Branch", 20},
+		{colCreated, "Created", max(lipgloss.Width("Created"), createdWidth)},
+	}
+	remove := func(key string) {
+		columns = slices.DeleteFunc(columns, func(c column) bool { return c.key == key })
+	}
+	shrinkSteps := []func(){
+		func() { remove(colCreated) },
+		func() { remove(colConclusion) },
+		func() { columns[len(columns)-1].width = 12 }, // Branch is last by now
+	}
+
+	titleWidth := 0
+	for {
+		// Each column is followed by a border, plus the leading one
+		used := len(columns) + 1
+		for _, c := range columns {
+			used += c.width
+		}
+		titleWidth = width - used
+		if titleWidth >= minTitleWidth || len(shrinkSteps) == 0 {
+			break
+		}
+		shrinkSteps[0]()
+		shrinkSteps = shrinkSteps[1:]
+	}
+	titleWidth = max(10, titleWidth)
+
+	result := make([]table.Column, len(columns))
+	for i, c := range columns {
+		if c.key == colTitle {
+			c.width = titleWidth
+		}
+		result[i] = table.NewColumn(c.key, c.title, c.width)
+	}
+	return result, titleWidth
+}
+
 func (r *RunsTable) rebuildTable() {
 	if r.width == 0 || r.height == 0 || len(r.runs) == 0 {
 		return
@@ -145,39 +409,64 @@ func (r *RunsTable) rebuildTable() {
 		currentIdx = 0
 	}
 
-	// Calculate column widths dynamically
-	idWidth := 10
-	statusWidth := 12
-	conclusionWidth := 12
-	branchWidth := 20
-	createdWidth := 19
-	titleWidth := max(20, r.width-idWidth-statusWidth-conclusionWidth-branchWidth-createdWidth-10)
+	columns, titleWidth := runsTableColumns(r.width, r.timeFormat.width())
 
-	columns := []table.Column{
-		table.NewColumn(colID, "ID", idWidth),
-		table.NewColumn(colTitle, "Title", titleWidth),
-		table.NewColumn(colStatus, "Status", statusWidth),
-		table.NewColumn(colConclusion, "Conclusion", conclusionWidth),
-		table.NewColumn(colBranch, "Branch", branchWidth),
-		table.NewColumn(colCreated, "Created", createdWidth),
-	}
+	branchStyle := lipgloss.NewStyle().
+		Foreground(r.theme.Colors.Accent).
+		Bold(true)
 
-	rows := make([]table.Row, len(r.runs))
-	for i, run := range r.runs {
-		createdStr := run.CreatedAt.Format("2006-01-02 15:04:05")
+	now := r.now()
+	runs := r.VisibleRuns()
+	rows := make([]table.Row, len(runs))
+	for i, run := range runs {
+		createdStr := r.timeFormat.Format(run.CreatedAt, now)
 
 		// Truncate title if needed
 		title := run.DisplayTitle
-		if len(title) > titleWidth-2 {
-			title = title[:titleWidth-5] + "..."
+		if badge := run.AttemptBadge(); badge != "" {
+			title = badge + " " + title
+		}
+		if tags := r.tags[run.DatabaseID]; len(tags) > 0 {
+			title = "[" + strings.Join(tags, "] [") + "] " + title
+		}
+		if counts := r.annotationCounts(run.DatabaseID); counts != "" {
+			title = counts + " " + title
+		}
+		title = truncate(title, titleWidth-2)
+
+		var branch any = run.HeadBranch
+		if r.isHighlightedBranch(run.HeadBranch) {
+			branch = table.NewStyledCell(run.HeadBranch, branchStyle)
+		}
+
+		icon, statusStyle := r.theme.StatusIcon(run.Status, run.Conclusion)
+		status, conclusionText := run.Status, run.Conclusion
+		if run.NeedsAttention() {
+			// Mark runs held for approval so they stand out from plain pending
+			if run.IsTerminal() {
+				conclusionText = icon + " " + conclusionText
+			} else {
+				status = icon + " " + status
+			}
+		}
+		// Show how long a pending run has waited, refreshed with the runs
+		if wait := run.QueuedFor(now); wait > 0 {
+			status += " " + formatElapsed(wait)
+			if wait >= longQueue {
+				statusStyle = r.theme.StatusWarning
+			}
+		}
+		var conclusion any = conclusionText
+		if run.IsTerminal() {
+			conclusion = table.NewStyledCell(conclusionText, statusStyle)
 		}
 
 		rows[i] = table.NewRow(table.RowData{
 			colID:         strconv.Itoa(run.DatabaseID),
 			colTitle:      title,
-			colStatus:     run.Status,
-			colConclusion: run.Conclusion,
-			colBranch:     run.HeadBranch,
+			colStatus:     table.NewStyledCell(status, statusStyle),
+			colConclusion: conclusion,
+			colBranch:     branch,
 			colCreated:    createdStr,
 		})
 	}
@@ -194,7 +483,7 @@ func (r *RunsTable) rebuildTable() {
 
 	r.table = table.New(columns).
 		WithRows(rows).
-		WithPageSize(r.pageSize).
+		WithPageSize(r.PageSize()).
 		Focused(r.focused).
 		BorderRounded().
 		WithBaseStyle(lipgloss.NewStyle().
@@ -222,7 +511,7 @@ func (r *RunsTable) Update(msg tea.Msg) tea.Cmd {
 			r.table = r.table.WithHighlightedRow(0)
 			return nil
 		case "G":
-			r.table = r.table.WithHighlightedRow(len(r.runs) - 1)
+			r.table = r.table.WithHighlightedRow(len(r.VisibleRuns()) - 1)
 			return nil
 		}
 	}
@@ -243,11 +532,27 @@ func (r *RunsTable) View() string {
 	}
 	title := fmt.Sprintf("📋 Runs: %s", r.workflowName)
 	b.WriteString(titleStyle.Render(title))
+	info := r.workflowInfo()
+	if info != nil {
+		room := r.width - lipgloss.Width(titleStyle.Render(title)) - 2
+		b.WriteString(r.theme.TextDim.Render("  " + truncate(info.Path, room)))
+	}
 	b.WriteString("\n")
 
 	// Status info
-	statusInfo := r.theme.TextMuted.Render(fmt.Sprintf("Total: %d runs", len(r.runs)))
+	statusText := fmt.Sprintf("Total: %d runs", len(r.runs))
+	if filters := r.filterNames(); len(filters) > 0 {
+		statusText = fmt.Sprintf("Showing %d of %d runs (%s)", len(r.VisibleRuns()), len(r.runs), strings.Join(filters, ", "))
+	}
+	statusInfo := r.theme.TextMuted.Render(statusText)
 	b.WriteString(statusInfo)
+	if info != nil && len(info.Triggers) > 0 {
+		room := r.width - lipgloss.Width(statusInfo) - 2
+		b.WriteString(r.theme.TextMuted.Render("  " + truncate("on: "+strings.Join(info.Triggers, ", "), room)))
+	}
+	if n := r.attentionCount(); n > 0 {
+		b.WriteString(r.theme.StatusWarning.Render(fmt.Sprintf("  %s %d awaiting approval [A]", r.theme.Icons.ActionRequired, n)))
+	}
 	b.WriteString("\n\n")
 
 	// Content
@@ -257,6 +562,8 @@ func (r *RunsTable) View() string {
 		b.WriteString(r.theme.StatusError.Render(fmt.Sprintf("Error: %v", r.err)))
 	} else if len(r.runs) == 0 {
 		b.WriteString(r.theme.TextMuted.Render("No workflow runs found"))
+	} else if len(r.VisibleRuns()) == 0 {
+		b.WriteString(r.theme.TextMuted.Render("No runs " + strings.Join(r.filterNames(), " and ")))
 	} else {
 		b.WriteString(r.table.View())
 	}
@@ -264,7 +571,11 @@ func (r *RunsTable) View() string {
 	b.WriteString("\n")
 
 	// Help hints
-	hints := r.theme.TextMuted.Render("[j/k] nav [w] open in browser [esc] close")
+	hintText := "[j/k] nav [w] open in browser [esc] close"
+	if r.branchMatcher != nil {
+		hintText = "[j/k] nav [b] branches [w] open in browser [esc] close"
+	}
+	hints := r.theme.TextMuted.Render(hintText)
 	b.WriteString(hints)
 
 	return lipgloss.NewStyle().
@@ -272,3 +583,30 @@ func (r *RunsTable) View() string {
 		Height(r.height).
 		Render(b.String())
 }
+
+// filterNames describes the active filters, such as "on highlighted
+// branches" and "tagged flaky"
+func (r *RunsTable) filterNames() []string {
+	var names []string
+	if r.branchFilter {
+		names = append(names, "on highlighted branches")
+	}
+	if r.tagFilter != "" {
+		names = append(names, "tagged "+r.tagFilter)
+	}
+	return names
+}
+
+// formatElapsed renders d in its largest whole unit, such as 45s, 12m, 3h
+// or 2d, to fit a narrow column
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
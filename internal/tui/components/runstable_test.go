@@ -0,0 +1,196 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
+
+func newTestRunsTable(runCount int) *RunsTable {
+	runs := make([]models.GHRun, runCount)
+	for i := range runs {
+		runs[i] = models.GHRun{DatabaseID: i + 1, DisplayTitle: "Run", Status: "completed", Conclusion: "success", HeadBranch: "main"}
+	}
+	r := NewRunsTablePtr(theme.Default())
+	r.SetRuns(runs, "ci.yml")
+	return r
+}
+
+func TestRunsTablePageFitsHeight(t *testing.T) {
+	r := newTestRunsTable(50)
+
+	for _, height := range []int{20, 30, 45} {
+		r.SetSize(100, height)
+		view := r.View()
+		if got := lipgloss.Height(view); got != height {
+			t.Errorf("height %d: view is %d lines tall", height, got)
+		}
+		// A fitted page leaves no blank rows below the table
+		lines := strings.Split(view, "\n")
+		if hints := strings.TrimSpace(lines[len(lines)-1]); !strings.HasPrefix(hints, "[j/k]") {
+			t.Errorf("height %d: expected hints on the last line, got %q", height, hints)
+		}
+	}
+}
+
+func TestRunsTablePageSizeOverride(t *testing.T) {
+	r := newTestRunsTable(50)
+	r.SetSize(100, 40)
+	r.SetPageSize(5)
+
+	if got := r.PageSize(); got != 5 {
+		t.Fatalf("PageSize() = %d, want 5", got)
+	}
+	if view := r.View(); !strings.Contains(view, "1/10") {
+		t.Errorf("expected 10 pages of 5 runs, got:\n%s", view)
+	}
+}
+
+func TestRunsTableKeepsHighlightVisibleOnResize(t *testing.T) {
+	r := newTestRunsTable(50)
+	r.SetSize(100, 40)
+	r.SetFocused(true)
+
+	r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if got := r.SelectedRunID(); got != 50 {
+		t.Fatalf("SelectedRunID() = %d, want 50", got)
+	}
+
+	r.SetSize(100, 20)
+	if got := r.SelectedRunID(); got != 50 {
+		t.Fatalf("SelectedRunID() after resize = %d, want 50", got)
+	}
+	if view := r.View(); !strings.Contains(view, " 50 ") {
+		t.Errorf("expected the highlighted run on the visible page, got:\n%s", view)
+	}
+
+	// Paging still moves a whole page at the new size
+	r.SetSize(100, 30)
+	r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	r.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got, want := r.SelectedRunID(), r.PageSize()+1; got != want {
+		t.Errorf("SelectedRunID() after next page = %d, want %d", got, want)
+	}
+}
+
+func TestRunsTableAttentionRuns(t *testing.T) {
+	r := NewRunsTablePtr(theme.Default())
+	r.SetRuns([]models.GHRun{
+		{DatabaseID: 4, Status: "in_progress"},
+		{DatabaseID: 3, Status: "waiting"},
+		{DatabaseID: 2, Status: "queued"},
+		{DatabaseID: 1, Status: "completed", Conclusion: "action_required"},
+	}, "deploy.yml")
+	r.SetSize(100, 30)
+
+	if got := r.AttentionRunID(); got != 3 {
+		t.Errorf("AttentionRunID() = %d, want the newest waiting run 3", got)
+	}
+	r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if got := r.AttentionRunID(); got != 1 {
+		t.Errorf("AttentionRunID() = %d, want the highlighted run 1", got)
+	}
+
+	view := r.View()
+	for _, want := range []string{"2 awaiting approval", "⏸ waiting", "! action_required"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+
+	r.SetRuns([]models.GHRun{{DatabaseID: 5, Status: "queued"}}, "deploy.yml")
+	if got := r.AttentionRunID(); got != 0 {
+		t.Errorf("AttentionRunID() = %d, want 0 with no runs waiting", got)
+	}
+}
+
+func TestRunsTableQueuedWait(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	r := NewRunsTablePtr(theme.Default())
+	r.now = func() time.Time { return now }
+	r.SetSize(120, 30)
+	r.SetRuns([]models.GHRun{
+		{DatabaseID: 3, Status: "queued", CreatedAt: now.Add(-90 * time.Second)},
+		{DatabaseID: 2, Status: "waiting", CreatedAt: now.Add(-3 * time.Hour)},
+		{DatabaseID: 1, Status: "completed", Conclusion: "success", CreatedAt: now.Add(-time.Hour)},
+	}, "ci.yml")
+
+	view := r.View()
+	for _, want := range []string{"queued 1m", "waiting 3h"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "completed 1h") {
+		t.Error("expected no wait on a finished run")
+	}
+}
+
+func TestRunsTableAttemptBadge(t *testing.T) {
+	r := NewRunsTablePtr(theme.Default())
+	r.SetSize(120, 30)
+	r.SetRuns([]models.GHRun{
+		{DatabaseID: 2, DisplayTitle: "Flaky", Status: "completed", Conclusion: "success", Attempt: 2},
+		{DatabaseID: 1, DisplayTitle: "Stable", Status: "completed", Conclusion: "success", Attempt: 1},
+	}, "ci.yml")
+
+	view := r.View()
+	if !strings.Contains(view, "(attempt 2) Flaky") {
+		t.Errorf("expected the re-run to show its attempt:\n%s", view)
+	}
+	if strings.Contains(view, "(attempt 1)") {
+		t.Error("expected no badge on a first attempt")
+	}
+}
+
+func TestRunTimeFormat(t *testing.T) {
+	created := time.Date(2025, 3, 14, 9, 30, 15, 0, time.UTC)
+	now := created.Add(3 * time.Hour)
+
+	tests := []struct {
+		format RunTimeFormat
+		want   string
+	}{
+		{RunTimeFormat{UTC: true}, "2025-03-14 09:30:15"},
+		{RunTimeFormat{Layout: "2006-01-02T15:04:05Z07:00", UTC: true}, "2025-03-14T09:30:15Z"},
+		{RunTimeFormat{Layout: "Jan 2 15:04", UTC: true}, "Mar 14 09:30"},
+		{RunTimeFormat{Relative: true}, "3h ago"},
+		{RunTimeFormat{Layout: "15:04 MST"}, created.Local().Format("15:04 MST")},
+	}
+	for _, tt := range tests {
+		got := tt.format.Format(created, now)
+		if got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.format, got, tt.want)
+		}
+		if layout := tt.format.Layout; layout != "" && tt.format.UTC {
+			if parsed, err := time.Parse(layout, got); err != nil || parsed.Format(layout) != got {
+				t.Errorf("%+v: %q does not parse back: %v", tt.format, got, err)
+			}
+		}
+	}
+}
+
+func TestRunsTableTimeFormat(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	r := NewRunsTablePtr(theme.Default())
+	r.now = func() time.Time { return now }
+	r.SetSize(140, 30)
+	r.SetRuns([]models.GHRun{
+		{DatabaseID: 1, Status: "completed", Conclusion: "success", CreatedAt: now.Add(-25 * time.Hour)},
+	}, "ci.yml")
+
+	r.SetTimeFormat(RunTimeFormat{Relative: true})
+	if view := r.View(); !strings.Contains(view, "1d ago") {
+		t.Errorf("expected a relative time:\n%s", view)
+	}
+	r.SetTimeFormat(RunTimeFormat{Layout: "2006-01-02T15:04:05Z07:00", UTC: true})
+	if view := r.View(); !strings.Contains(view, "2025-03-13T11:00:00Z") {
+		t.Errorf("expected the full ISO time in a widened column:\n%s", view)
+	}
+}
@@ -0,0 +1,167 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// RunView is an overlay showing a run's log or its jobs as lines of text,
+// for reading them without leaving the terminal
+type RunView struct {
+	active  bool
+	loading bool
+	runID   int
+	title   string
+	lines   []string
+	err     error
+	offset  int
+	width   int
+	height  int
+	theme   *theme.Theme
+}
+
+func NewRunView(t *theme.Theme) RunView {
+	return RunView{theme: t}
+}
+
+func (r *RunView) SetSize(width, height int) {
+	r.width = width
+	r.height = height
+}
+
+func (r *RunView) IsActive() bool {
+	return r.active
+}
+
+// RunID returns the run whose log or jobs are shown
+func (r *RunView) RunID() int {
+	return r.runID
+}
+
+// Open shows the overlay for runID under title, loading until SetLines is
+// called
+func (r *RunView) Open(runID int, title string) {
+	r.active = true
+	r.loading = true
+	r.runID = runID
+	r.title = title
+	r.lines = nil
+	r.err = nil
+	r.offset = 0
+}
+
+// SetLines fills in the overlay once the content of runID is fetched.
+// Results for a run that is no longer shown are ignored.
+func (r *RunView) SetLines(runID int, lines []string, err error) {
+	if runID != r.runID {
+		return
+	}
+	r.loading = false
+	r.err = err
+	r.lines = lines
+}
+
+func (r *RunView) Close() {
+	r.active = false
+	r.lines = nil
+}
+
+// visibleLines is how many lines fit the overlay
+func (r *RunView) visibleLines() int {
+	return max(1, max(15, r.height*80/100)-8)
+}
+
+// Update handles scrolling and closing. It returns true when w is pressed
+// to open the run in the browser.
+func (r *RunView) Update(msg tea.Msg) bool {
+	if !r.active {
+		return false
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+
+	lastOffset := max(0, len(r.lines)-r.visibleLines())
+	switch keyMsg.String() {
+	case "esc", "q":
+		r.Close()
+	case "w":
+		return true
+	case "j", "down":
+		r.offset = min(r.offset+1, lastOffset)
+	case "k", "up":
+		r.offset = max(r.offset-1, 0)
+	case "ctrl+d", "pgdown":
+		r.offset = min(r.offset+r.visibleLines()/2, lastOffset)
+	case "ctrl+u", "pgup":
+		r.offset = max(r.offset-r.visibleLines()/2, 0)
+	case "g", "home":
+		r.offset = 0
+	case "G", "end":
+		r.offset = lastOffset
+	}
+	return false
+}
+
+func (r *RunView) View() string {
+	if !r.active {
+		return ""
+	}
+
+	overlayWidth := max(50, r.width*80/100)
+	overlayHeight := max(15, r.height*80/100)
+	textWidth := overlayWidth - 10
+
+	var b strings.Builder
+	b.WriteString(r.theme.Title.Render(fmt.Sprintf("%s · Run #%d", r.title, r.runID)))
+	b.WriteString("\n")
+	b.WriteString(r.theme.Divider(overlayWidth - 8))
+	b.WriteString("\n\n")
+
+	switch {
+	case r.loading:
+		b.WriteString(r.theme.StatusInProgress.Render(r.theme.Icons.InProgress + " Loading " + strings.ToLower(r.title) + "..."))
+		b.WriteString("\n")
+	case r.err != nil:
+		b.WriteString(r.theme.TextMuted.Render(truncate(r.title+" unavailable: "+r.err.Error(), textWidth)))
+		b.WriteString("\n")
+	case len(r.lines) == 0:
+		b.WriteString(r.theme.TextMuted.Render("Nothing to show yet."))
+		b.WriteString("\n")
+	default:
+		end := min(len(r.lines), r.offset+r.visibleLines())
+		for i := r.offset; i < end; i++ {
+			line := strings.ReplaceAll(r.lines[i], "\t", "  ")
+			b.WriteString(r.theme.Text.Render(truncate(line, textWidth)))
+			b.WriteString("\n")
+		}
+		if len(r.lines) > r.visibleLines() {
+			b.WriteString(r.theme.TextMuted.Render(fmt.Sprintf("(%d-%d of %d lines)", r.offset+1, end, len(r.lines))))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(r.theme.TextMuted.Render("[j/k] scroll [g/G] top/bottom [w] open in browser [esc] close"))
+
+	overlayContent := lipgloss.NewStyle().
+		Width(overlayWidth-4).
+		Height(overlayHeight-2).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		r.width,
+		r.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		r.theme.BorderActive.Render(overlayContent),
+	)
+}
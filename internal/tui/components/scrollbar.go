@@ -0,0 +1,52 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// scrollbar returns a one-column scrollbar, height lines tall, for a window
+// showing visible of total entries from start. The thumb's size and place on
+// the track are in proportion to the window.
+func scrollbar(t *theme.Theme, height, total, start, visible int) []string {
+	if height <= 0 {
+		return nil
+	}
+	thumbSize := min(height, max(1, height*visible/max(1, total)))
+	thumbTop := 0
+	if total > visible {
+		thumbTop = (height - thumbSize) * start / (total - visible)
+	}
+
+	bar := make([]string, height)
+	for i := range bar {
+		if i >= thumbTop && i < thumbTop+thumbSize {
+			bar[i] = t.Text.Render("┃")
+		} else {
+			bar[i] = t.TextMuted.Render("│")
+		}
+	}
+	return bar
+}
+
+// withScrollbar renders content width wide and at least height lines tall,
+// with bar in the last column starting at line top
+func withScrollbar(content string, width, height, top int, bar []string) string {
+	rendered := lipgloss.NewStyle().
+		Width(width - 1).
+		Height(height).
+		Render(content)
+
+	lines := strings.Split(rendered, "\n")
+	for i := range lines {
+		glyph := " "
+		if j := i - top; j >= 0 && j < len(bar) {
+			glyph = bar[j]
+		}
+		lines[i] += glyph
+	}
+	return strings.Join(lines, "\n")
+}
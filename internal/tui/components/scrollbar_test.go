@@ -0,0 +1,62 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+func TestScrollbar(t *testing.T) {
+	thumb := func(bar []string) (top, size int) {
+		top = -1
+		for i, glyph := range bar {
+			if strings.Contains(glyph, "┃") {
+				if top < 0 {
+					top = i
+				}
+				size++
+			}
+		}
+		return top, size
+	}
+
+	bar := scrollbar(theme.Default(), 10, 40, 0, 10)
+	if top, size := thumb(bar); top != 0 || size != 2 {
+		t.Errorf("expected a 2-line thumb at the top, got %d lines at %d", size, top)
+	}
+	bar = scrollbar(theme.Default(), 10, 40, 30, 10)
+	if top, size := thumb(bar); top != 8 || size != 2 {
+		t.Errorf("expected a 2-line thumb at the bottom, got %d lines at %d", size, top)
+	}
+}
+
+func TestListScrollbar(t *testing.T) {
+	l := NewList(theme.Default(), "Workflows")
+	l.SetSize(40, 12)
+	var items []ListItem
+	for i := range 3 {
+		items = append(items, ListItem{ID: fmt.Sprint(i), Title: fmt.Sprintf("workflow %d", i)})
+	}
+	l.SetItems(items)
+	if strings.Contains(l.View(), "┃") {
+		t.Fatal("expected no scrollbar when every item fits")
+	}
+
+	for i := 3; i < 30; i++ {
+		items = append(items, ListItem{ID: fmt.Sprint(i), Title: fmt.Sprintf("workflow %d", i)})
+	}
+	l.SetItems(items)
+	view := l.View()
+	if !strings.Contains(view, "┃") || !strings.Contains(view, "of 30)") {
+		t.Fatalf("expected a scrollbar next to the text indicator, got:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if width := lipgloss.Width(line); width != 40 {
+			t.Fatalf("expected every line to keep the list width, got %d in %q", width, line)
+		}
+	}
+}
@@ -2,12 +2,14 @@ package components
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sahilm/fuzzy"
 
+	"github.com/Cloudsky01/gh-rivet/internal/config"
 	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
 )
 
@@ -34,16 +36,31 @@ type Search struct {
 	height     int
 	theme      *theme.Theme
 	searchFunc SearchFunc
+
+	title       string
+	placeholder string
+	hint        string
 }
 
 // NewSearch creates a new search component
 func NewSearch(t *theme.Theme) Search {
 	return Search{
-		theme:   t,
-		results: []SearchResult{},
+		theme:       t,
+		results:     []SearchResult{},
+		title:       "Global Search",
+		placeholder: "Type to search groups and workflows...",
+		hint:        "Start typing to search through all groups and workflows",
 	}
 }
 
+// SetLabels replaces the title, the empty input placeholder, and the hint
+// shown before anything is typed
+func (s *Search) SetLabels(title, placeholder, hint string) {
+	s.title = title
+	s.placeholder = placeholder
+	s.hint = hint
+}
+
 // SetSearchFunc sets the function used to perform searches
 func (s *Search) SetSearchFunc(fn SearchFunc) {
 	s.searchFunc = fn
@@ -85,12 +102,12 @@ func (s *Search) SelectedResult() *SearchResult {
 }
 
 func (s *Search) doSearch() {
+	s.cursor = 0
 	if s.searchFunc == nil || s.input == "" {
 		s.results = nil
 		return
 	}
 	s.results = s.searchFunc(s.input)
-	s.cursor = 0
 }
 
 // Update handles input
@@ -154,7 +171,7 @@ func (s *Search) View() string {
 	var b strings.Builder
 
 	// Title
-	title := s.theme.Title.Render("Global Search")
+	title := s.theme.Title.Render(s.title)
 	b.WriteString(title)
 	b.WriteString("\n")
 	b.WriteString(s.theme.Divider(overlayWidth - 4))
@@ -164,7 +181,7 @@ func (s *Search) View() string {
 	cursor := "█"
 	inputText := s.input + cursor
 	if s.input == "" {
-		inputText = s.theme.TextMuted.Render("Type to search groups and workflows...") + cursor
+		inputText = s.theme.TextMuted.Render(s.placeholder) + cursor
 	}
 
 	searchIcon := s.theme.FilterPrompt.Render(s.theme.Icons.Search + " ")
@@ -174,7 +191,7 @@ func (s *Search) View() string {
 
 	// Results
 	if s.input == "" {
-		hintText := s.theme.TextMuted.Render("  Start typing to search through all groups and workflows")
+		hintText := s.theme.TextMuted.Render("  " + s.hint)
 		b.WriteString(hintText)
 	} else if len(s.results) == 0 {
 		noResultsText := s.theme.TextMuted.Render("  No results found")
@@ -212,11 +229,7 @@ func (s *Search) View() string {
 			}
 
 			// Truncate name if needed
-			name := result.Name
-			maxNameWidth := overlayWidth - 15
-			if len(name) > maxNameWidth {
-				name = name[:maxNameWidth-3] + "..."
-			}
+			name := truncate(result.Name, overlayWidth-15)
 
 			var nameLine string
 			if isSelected {
@@ -227,15 +240,14 @@ func (s *Search) View() string {
 			b.WriteString(nameLine)
 			b.WriteString("\n")
 
-			// Show path
+			// Show path, or the resolved path for group path results
 			pathText := formatPath(result.GroupPath)
 			if result.Type == "workflow" && result.Description != result.Name {
 				pathText = pathText + " / " + result.Description
+			} else if result.Type == "group" && result.Description != "" {
+				pathText = "→ " + result.Description
 			}
-			maxPathWidth := overlayWidth - 10
-			if len(pathText) > maxPathWidth {
-				pathText = pathText[:maxPathWidth-3] + "..."
-			}
+			pathText = truncate(pathText, overlayWidth-10)
 
 			pathLine := s.theme.TextDim.Render(fmt.Sprintf("     %s", pathText))
 			b.WriteString(pathLine)
@@ -282,12 +294,206 @@ func formatPath(path []string) string {
 	return strings.Join(path, " > ")
 }
 
+// SearchGroups flattens groups and fuzzy-matches query against them. It is
+// the single entry point for global search, so ranking applies everywhere.
+func SearchGroups(groups []config.Group, query string) []SearchResult {
+	return NewSearchIndex(groups, GroupOrder{}).Search(query)
+}
+
+// SearchIndex holds groups flattened once, so searching on every keystroke
+// only runs the fuzzy match. Rebuild it when the groups change.
+type SearchIndex struct {
+	items searchPass
+	paths searchPass
+}
+
+// searchPass fuzzy-matches queries against a fixed list of items. The
+// results buffer is reused, so results are only valid until the next search.
+type searchPass struct {
+	items []SearchResult
+	key   func(*SearchResult) string
+
+	// A query extending the previous one can only match what it matched,
+	// so typing narrows the candidates instead of scanning every item
+	query      string
+	candidates []int
+	results    []SearchResult
+}
+
+// NewSearchIndex flattens groups in order for Search and SearchPaths.
+// Results that match equally well are listed in that order.
+func NewSearchIndex(groups []config.Group, order GroupOrder) *SearchIndex {
+	items := flattenGroups(groups, order)
+	var paths []SearchResult
+	for _, item := range items {
+		if item.Type != "group" {
+			continue
+		}
+		item.Description = formatPath(append(slices.Clip(item.GroupPath), item.Name))
+		paths = append(paths, item)
+	}
+	return &SearchIndex{
+		items: searchPass{items: items, key: func(r *SearchResult) string { return r.Name }},
+		paths: searchPass{items: paths, key: func(r *SearchResult) string { return r.Description }},
+	}
+}
+
+// Search fuzzy-matches query against every group and workflow name
+func (idx *SearchIndex) Search(query string) []SearchResult {
+	return idx.items.search(query)
+}
+
+// SearchPaths fuzzy-matches query against the full path of every group,
+// skipping workflows. Each result's Description is that path.
+func (idx *SearchIndex) SearchPaths(query string) []SearchResult {
+	return idx.paths.search(query)
+}
+
+func (p *searchPass) search(query string) []SearchResult {
+	if query == "" {
+		p.query = ""
+		return p.items
+	}
+
+	candidates := p.candidates
+	if p.query == "" || !strings.HasPrefix(query, p.query) {
+		candidates = nil
+	}
+	matches := fuzzy.FindFrom(query, candidateSource{pass: p, candidates: candidates})
+
+	p.results = p.results[:0]
+	next := make([]int, 0, len(matches))
+	for _, match := range matches {
+		i := match.Index
+		if candidates != nil {
+			i = candidates[i]
+		}
+		p.results = append(p.results, p.items[i])
+		next = append(next, i)
+	}
+	// Candidates stay in item order, so ties rank as in a full search
+	slices.Sort(next)
+	p.query = query
+	p.candidates = next
+	return p.results
+}
+
+// candidateSource matches the candidates of a pass, or all of its items when
+// candidates is nil
+type candidateSource struct {
+	pass       *searchPass
+	candidates []int
+}
+
+func (s candidateSource) String(i int) string {
+	if s.candidates != nil {
+		i = s.candidates[i]
+	}
+	return s.pass.key(&s.pass.items[i])
+}
+
+func (s candidateSource) Len() int {
+	if s.candidates != nil {
+		return len(s.candidates)
+	}
+	return len(s.pass.items)
+}
+
+// GroupOrder sorts, in place, sibling groups and the workflow files of a
+// group. A nil func keeps the config order.
+type GroupOrder struct {
+	Groups    func(groups []*config.Group)
+	Workflows func(group *config.Group, files []string)
+}
+
+// FlattenGroups lists every group and workflow in groups, depth first. Each
+// workflow appears once per group, whether it is listed in workflows,
+// workflowDefs or both, and uses its workflowDefs name when one is set.
+func FlattenGroups(groups []config.Group) []SearchResult {
+	return flattenGroups(groups, GroupOrder{})
+}
+
+// flattenGroups is FlattenGroups listing siblings in order
+func flattenGroups(groups []config.Group, order GroupOrder) []SearchResult {
+	var results []SearchResult
+	sorted := func(groups []config.Group) []*config.Group {
+		ptrs := make([]*config.Group, len(groups))
+		for i := range groups {
+			ptrs[i] = &groups[i]
+		}
+		if order.Groups != nil {
+			order.Groups(ptrs)
+		}
+		return ptrs
+	}
+
+	var walk func(group *config.Group, path []string)
+	walk = func(group *config.Group, path []string) {
+		results = append(results, SearchResult{
+			Type:      "group",
+			Name:      group.Name,
+			GroupPath: path,
+			Data:      group,
+		})
+
+		// Copy so sibling groups never share a backing array
+		currentPath := append(slices.Clip(path), group.Name)
+
+		names := make(map[string]string)
+		var files []string
+		addFile := func(file string) {
+			if _, seen := names[file]; !seen {
+				names[file] = file
+				files = append(files, file)
+			}
+		}
+		for _, file := range group.Workflows {
+			addFile(file)
+		}
+		for i := range group.WorkflowDefs {
+			def := &group.WorkflowDefs[i]
+			addFile(def.File)
+			if def.Name != "" {
+				names[def.File] = def.Name
+			}
+		}
+
+		if order.Workflows != nil {
+			order.Workflows(group, files)
+		}
+		for _, file := range files {
+			results = append(results, SearchResult{
+				Type:         "workflow",
+				Name:         names[file],
+				Description:  file,
+				GroupPath:    currentPath,
+				WorkflowName: file,
+				Data:         group,
+			})
+		}
+
+		for _, sub := range sorted(group.Groups) {
+			walk(sub, currentPath)
+		}
+	}
+
+	for _, group := range sorted(groups) {
+		walk(group, []string{})
+	}
+	return results
+}
+
+// SearchGroupPaths fuzzy-matches query against the full path of every group
+// in groups, skipping workflows. Each result's Description is that path.
+func SearchGroupPaths(groups []config.Group, query string) []SearchResult {
+	return NewSearchIndex(groups, GroupOrder{}).SearchPaths(query)
+}
+
 // FuzzySearchItems performs fuzzy search on a list of SearchResults
 func FuzzySearchItems(items []SearchResult, query string) []SearchResult {
 	if query == "" {
 		return items
 	}
-
 	matches := fuzzy.FindFrom(query, searchResultSource(items))
 	results := make([]SearchResult, len(matches))
 	for i, match := range matches {
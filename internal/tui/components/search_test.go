@@ -0,0 +1,189 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Cloudsky01/gh-rivet/internal/config"
+)
+
+func TestFlattenGroupsNested(t *testing.T) {
+	groups := []config.Group{
+		{
+			ID:   "ci",
+			Name: "CI",
+			Groups: []config.Group{
+				{ID: "lint", Name: "Lint", Workflows: []string{"lint.yml"}},
+				{ID: "test", Name: "Test", Workflows: []string{"test.yml"}},
+			},
+		},
+		{ID: "deploy", Name: "Deploy", Workflows: []string{"deploy.yml"}},
+	}
+
+	results := FlattenGroups(groups)
+
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+	want := []string{"CI", "Lint", "lint.yml", "Test", "test.yml", "Deploy", "deploy.yml"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("FlattenGroups() names = %v, want %v", names, want)
+	}
+
+	paths := map[string][]string{}
+	for _, r := range results {
+		if r.Type == "workflow" {
+			paths[r.WorkflowName] = r.GroupPath
+		}
+	}
+	if got := paths["lint.yml"]; !slices.Equal(got, []string{"CI", "Lint"}) {
+		t.Errorf("lint.yml path = %v", got)
+	}
+	if got := paths["test.yml"]; !slices.Equal(got, []string{"CI", "Test"}) {
+		t.Errorf("test.yml path = %v, sibling paths must not share storage", got)
+	}
+	if got := paths["deploy.yml"]; !slices.Equal(got, []string{"Deploy"}) {
+		t.Errorf("deploy.yml path = %v", got)
+	}
+}
+
+func TestFlattenGroupsDeduplicates(t *testing.T) {
+	groups := []config.Group{
+		{
+			ID:           "ci",
+			Name:         "CI",
+			Workflows:    []string{"build.yml", "build.yml", "test.yml"},
+			WorkflowDefs: []config.Workflow{{File: "build.yml", Name: "Build"}, {File: "release.yml"}},
+		},
+	}
+
+	var workflows []SearchResult
+	for _, r := range FlattenGroups(groups) {
+		if r.Type == "workflow" {
+			workflows = append(workflows, r)
+		}
+	}
+
+	if len(workflows) != 3 {
+		t.Fatalf("expected 3 workflows, got %d: %+v", len(workflows), workflows)
+	}
+	if workflows[0].Name != "Build" || workflows[0].WorkflowName != "build.yml" {
+		t.Errorf("expected build.yml named Build, got %+v", workflows[0])
+	}
+	if workflows[2].Name != "release.yml" {
+		t.Errorf("expected unnamed def to use its file name, got %+v", workflows[2])
+	}
+}
+
+func TestSearchGroups(t *testing.T) {
+	groups := []config.Group{
+		{ID: "ci", Name: "CI", Workflows: []string{"build.yml", "deploy.yml"}},
+	}
+
+	results := SearchGroups(groups, "depl")
+	if len(results) != 1 || results[0].WorkflowName != "deploy.yml" {
+		t.Fatalf("SearchGroups() = %+v, want deploy.yml", results)
+	}
+	if got := SearchGroups(groups, ""); len(got) != 3 {
+		t.Errorf("empty query should return everything, got %d results", len(got))
+	}
+}
+
+func TestSearchGroupPaths(t *testing.T) {
+	groups := []config.Group{
+		{
+			ID: "services", Name: "Services", Workflows: []string{"services.yml"},
+			Groups: []config.Group{{ID: "auth", Name: "Auth"}},
+		},
+		{ID: "deploy", Name: "Deploy", Workflows: []string{"auth-deploy.yml"}},
+	}
+
+	results := SearchGroupPaths(groups, "servauth")
+	if len(results) != 1 || results[0].Name != "Auth" {
+		t.Fatalf("SearchGroupPaths() = %+v, want the Auth group matched by its path", results)
+	}
+	if results[0].Description != "Services > Auth" {
+		t.Errorf("Description = %q, want the full path", results[0].Description)
+	}
+	if got := SearchGroupPaths(groups, ""); len(got) != 3 {
+		t.Errorf("empty query should return every group and no workflows, got %d results", len(got))
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	groups := []config.Group{
+		{ID: "ci", Name: "CI", Workflows: []string{"build.yml", "lint.yml"}},
+		{ID: "deploy", Name: "Deploy", Workflows: []string{"deploy.yml"}},
+	}
+	idx := NewSearchIndex(groups, GroupOrder{})
+
+	// Searches narrow the previous matches and reuse one buffer, so each
+	// must match a fresh search
+	for _, query := range []string{"b", "bu", "build", "depl", "d", "yml", "zzz", "zzzy"} {
+		got := idx.Search(query)
+		want := SearchGroups(groups, query)
+		if len(got) != len(want) {
+			t.Fatalf("Search(%q) = %d results, want %d", query, len(got), len(want))
+		}
+		for i := range want {
+			if got[i].Name != want[i].Name {
+				t.Errorf("Search(%q)[%d] = %s, want %s", query, i, got[i].Name, want[i].Name)
+			}
+		}
+	}
+	if got := idx.Search(""); len(got) != 5 {
+		t.Errorf("expected an empty query to list everything, got %d", len(got))
+	}
+}
+
+// largeGroups returns 50 groups of 20 subgroups with 10 workflows each
+func largeGroups() []config.Group {
+	groups := make([]config.Group, 50)
+	for i := range groups {
+		groups[i] = config.Group{ID: fmt.Sprintf("g%d", i), Name: fmt.Sprintf("Service %d", i)}
+		for j := range 20 {
+			sub := config.Group{ID: fmt.Sprintf("g%d-%d", i, j), Name: fmt.Sprintf("Stage %d", j)}
+			for k := range 10 {
+				sub.Workflows = append(sub.Workflows, fmt.Sprintf("deploy-%d-%d-%d.yml", i, j, k))
+			}
+			groups[i].Groups = append(groups[i].Groups, sub)
+		}
+	}
+	return groups
+}
+
+// typeQuery searches for query one keystroke at a time, as the search
+// overlay does while typing
+func typeQuery(search func(string) []SearchResult, query string) {
+	for i := range query {
+		search(query[:i+1])
+	}
+}
+
+func BenchmarkSearchGroups(b *testing.B) {
+	groups := largeGroups()
+	b.ResetTimer()
+	for range b.N {
+		typeQuery(func(query string) []SearchResult { return SearchGroups(groups, query) }, "dep12")
+	}
+}
+
+func BenchmarkSearchIndex(b *testing.B) {
+	idx := NewSearchIndex(largeGroups(), GroupOrder{})
+	b.ResetTimer()
+	for range b.N {
+		idx.Search("")
+		typeQuery(idx.Search, "dep12")
+	}
+}
+
+func BenchmarkSearchIndexPaths(b *testing.B) {
+	idx := NewSearchIndex(largeGroups(), GroupOrder{})
+	b.ResetTimer()
+	for range b.N {
+		idx.SearchPaths("")
+		typeQuery(idx.SearchPaths, "serv12stag")
+	}
+}
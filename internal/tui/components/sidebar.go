@@ -1,7 +1,9 @@
 package components
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,16 +16,31 @@ import (
 // PinnedItem represents a pinned workflow in the sidebar
 type PinnedItem struct {
 	WorkflowName string
+	DisplayName  string // Shown instead of WorkflowName when set
 	GroupName    string
 	GroupID      string
 	Data         interface{} // Reference to the group for actions
 }
 
+// sidebarRow is one selectable line of the sidebar: a pinned item, or in
+// grouped mode also a group header
+type sidebarRow struct {
+	header  bool
+	groupID string
+	group   string
+	count   int // items under a header
+	item    int // index into filteredItems, for item rows
+}
+
 // Sidebar is the pinned workflows sidebar component
 type Sidebar struct {
 	items         []PinnedItem
 	filteredItems []PinnedItem
-	cursor        int
+	rows          []sidebarRow
+	cursor        int // index into rows
+	grouped       bool
+	collapsed     map[string]bool // group IDs whose items are folded away
+	altTitles     bool
 	filterInput   string
 	filterActive  bool
 	width         int
@@ -38,6 +55,7 @@ func NewSidebar(t *theme.Theme) Sidebar {
 	return Sidebar{
 		items:         []PinnedItem{},
 		filteredItems: []PinnedItem{},
+		collapsed:     make(map[string]bool),
 		visible:       true,
 		theme:         t,
 	}
@@ -47,9 +65,99 @@ func NewSidebar(t *theme.Theme) Sidebar {
 func (s *Sidebar) SetItems(items []PinnedItem) {
 	s.items = items
 	s.applyFilter()
-	if s.cursor >= len(s.filteredItems) {
-		s.cursor = max(0, len(s.filteredItems)-1)
+	if s.cursor >= len(s.rows) {
+		s.cursor = max(0, len(s.rows)-1)
+	}
+}
+
+// SetGrouped lists the pinned items under a collapsible header per group,
+// sorted by group name, instead of as one flat list
+func (s *Sidebar) SetGrouped(grouped bool) {
+	if s.grouped == grouped {
+		return
+	}
+	s.grouped = grouped
+	s.buildRows()
+	s.cursor = s.firstItemRow()
+}
+
+// SetAltTitles shows each pin's workflow file instead of its display name.
+// Filtering matches the names shown.
+func (s *Sidebar) SetAltTitles(alt bool) {
+	s.altTitles = alt
+	if s.filterInput != "" {
+		s.applyFilter()
+	}
+}
+
+// label returns the name item is shown with
+func (s *Sidebar) label(item *PinnedItem) string {
+	if s.altTitles || item.DisplayName == "" {
+		return item.WorkflowName
+	}
+	return item.DisplayName
+}
+
+// IsGrouped reports whether items are listed under their groups
+func (s *Sidebar) IsGrouped() bool {
+	return s.grouped
+}
+
+// ToggleGroup collapses or expands the group whose header is under the
+// cursor. Returns false when the cursor is not on a header.
+func (s *Sidebar) ToggleGroup() bool {
+	if s.cursor >= len(s.rows) || !s.rows[s.cursor].header {
+		return false
+	}
+	id := s.rows[s.cursor].groupID
+	s.collapsed[id] = !s.collapsed[id]
+	s.buildRows()
+	return true
+}
+
+// buildRows lays out filteredItems as rows. While filtering, every group is
+// expanded so all matches show.
+func (s *Sidebar) buildRows() {
+	s.rows = s.rows[:0]
+	if !s.grouped {
+		for i := range s.filteredItems {
+			s.rows = append(s.rows, sidebarRow{item: i})
+		}
+		return
+	}
+
+	order := make([]int, len(s.filteredItems))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(x, y int) int {
+		a, b := s.filteredItems[x], s.filteredItems[y]
+		return cmp.Or(strings.Compare(a.GroupName, b.GroupName), strings.Compare(a.GroupID, b.GroupID))
+	})
+
+	header := -1
+	for _, i := range order {
+		item := s.filteredItems[i]
+		if header < 0 || s.rows[header].groupID != item.GroupID {
+			header = len(s.rows)
+			s.rows = append(s.rows, sidebarRow{header: true, groupID: item.GroupID, group: item.GroupName})
+		}
+		s.rows[header].count++
+		if s.filterInput == "" && s.collapsed[item.GroupID] {
+			continue
+		}
+		s.rows = append(s.rows, sidebarRow{groupID: item.GroupID, group: item.GroupName, item: i})
+	}
+}
+
+// firstItemRow returns the row of the first pinned item, skipping headers
+func (s *Sidebar) firstItemRow() int {
+	for i, row := range s.rows {
+		if !row.header {
+			return i
+		}
 	}
+	return 0
 }
 
 // Items returns all items
@@ -57,6 +165,11 @@ func (s *Sidebar) Items() []PinnedItem {
 	return s.items
 }
 
+// FilteredItems returns the currently visible (filtered) items
+func (s *Sidebar) FilteredItems() []PinnedItem {
+	return s.filteredItems
+}
+
 // SetSize sets the dimensions
 func (s *Sidebar) SetSize(width, height int) {
 	s.width = width
@@ -93,10 +206,10 @@ func (s *Sidebar) Cursor() int {
 	return s.cursor
 }
 
-// SelectedItem returns the selected pinned item
+// SelectedItem returns the selected pinned item, or nil on a group header
 func (s *Sidebar) SelectedItem() *PinnedItem {
-	if s.cursor >= 0 && s.cursor < len(s.filteredItems) {
-		return &s.filteredItems[s.cursor]
+	if s.cursor >= 0 && s.cursor < len(s.rows) && !s.rows[s.cursor].header {
+		return &s.filteredItems[s.rows[s.cursor].item]
 	}
 	return nil
 }
@@ -111,6 +224,12 @@ func (s *Sidebar) HasFilter() bool {
 	return s.filterInput != ""
 }
 
+// FilterCounts returns how many items the filter shows and how many there
+// are in total
+func (s *Sidebar) FilterCounts() (shown, total int) {
+	return len(s.filteredItems), len(s.items)
+}
+
 // ClearFilter clears the filter
 func (s *Sidebar) ClearFilter() {
 	s.filterInput = ""
@@ -125,26 +244,37 @@ func (s *Sidebar) StartFilter() {
 }
 
 func (s *Sidebar) applyFilter() {
+	defer s.buildRows()
 	if s.filterInput == "" {
 		s.filteredItems = s.items
 		return
 	}
 
-	matches := fuzzy.FindFrom(s.filterInput, pinnedItemSource(s.items))
+	matches := fuzzy.FindFrom(s.filterInput, pinnedItemSource{sidebar: s})
 	s.filteredItems = make([]PinnedItem, len(matches))
 	for i, match := range matches {
 		s.filteredItems[i] = s.items[match.Index]
 	}
 }
 
-type pinnedItemSource []PinnedItem
+// refilter reapplies the filter after its input changed. The cursor moves
+// to the first match, since its old index may point at an unrelated item.
+func (s *Sidebar) refilter() {
+	s.applyFilter()
+	s.cursor = s.firstItemRow()
+}
+
+// pinnedItemSource matches a sidebar's pins by the names they are shown with
+type pinnedItemSource struct {
+	sidebar *Sidebar
+}
 
 func (p pinnedItemSource) String(i int) string {
-	return p[i].WorkflowName
+	return p.sidebar.label(&p.sidebar.items[i])
 }
 
 func (p pinnedItemSource) Len() int {
-	return len(p)
+	return len(p.sidebar.items)
 }
 
 // Update handles input
@@ -165,11 +295,10 @@ func (s *Sidebar) handleKey(msg tea.KeyMsg) tea.Cmd {
 		case "esc":
 			s.filterActive = false
 			s.filterInput = ""
-			s.applyFilter()
-			s.cursor = 0
+			s.refilter()
 			return nil
 		case "ctrl+n", "down":
-			if s.cursor < len(s.filteredItems)-1 {
+			if s.cursor < len(s.rows)-1 {
 				s.cursor++
 			}
 			return nil
@@ -181,20 +310,14 @@ func (s *Sidebar) handleKey(msg tea.KeyMsg) tea.Cmd {
 		case "backspace":
 			if len(s.filterInput) > 0 {
 				s.filterInput = s.filterInput[:len(s.filterInput)-1]
-				s.applyFilter()
-				if s.cursor >= len(s.filteredItems) {
-					s.cursor = max(0, len(s.filteredItems)-1)
-				}
+				s.refilter()
 			}
 			return nil
 		default:
 			key := msg.String()
 			if len(key) == 1 {
 				s.filterInput += key
-				s.applyFilter()
-				if s.cursor >= len(s.filteredItems) {
-					s.cursor = max(0, len(s.filteredItems)-1)
-				}
+				s.refilter()
 			}
 			return nil
 		}
@@ -205,7 +328,7 @@ func (s *Sidebar) handleKey(msg tea.KeyMsg) tea.Cmd {
 		s.StartFilter()
 		return nil
 	case "j", "down":
-		if s.cursor < len(s.filteredItems)-1 {
+		if s.cursor < len(s.rows)-1 {
 			s.cursor++
 		}
 		return nil
@@ -218,7 +341,7 @@ func (s *Sidebar) handleKey(msg tea.KeyMsg) tea.Cmd {
 		s.cursor = 0
 		return nil
 	case "G":
-		s.cursor = max(0, len(s.filteredItems)-1)
+		s.cursor = max(0, len(s.rows)-1)
 		return nil
 	case "esc":
 		if s.filterInput != "" {
@@ -226,7 +349,7 @@ func (s *Sidebar) handleKey(msg tea.KeyMsg) tea.Cmd {
 		}
 		return nil
 	case "n":
-		if s.filterInput != "" && s.cursor < len(s.filteredItems)-1 {
+		if s.filterInput != "" && s.cursor < len(s.rows)-1 {
 			s.cursor++
 		}
 		return nil
@@ -280,7 +403,12 @@ func (s *Sidebar) View() string {
 	footerHeight := 1
 	availableHeight := s.height - headerHeight - footerHeight
 	itemHeight := 3 // workflow name + group name + spacing
+	if s.grouped {
+		itemHeight = 1 // the group name is in the header
+	}
 	visibleCount := max(1, availableHeight/itemHeight)
+	var bar []string
+	barTop := 0
 
 	if len(s.filteredItems) == 0 {
 		emptyMsg := "No pinned workflows"
@@ -293,25 +421,29 @@ func (s *Sidebar) View() string {
 		visibleStart, visibleEnd := s.calculateVisibleWindow(visibleCount)
 
 		// Scroll indicator
-		if len(s.filteredItems) > visibleCount {
+		if len(s.rows) > visibleCount {
 			scrollInfo := s.theme.TextMuted.Render(
-				fmt.Sprintf("  (%d-%d of %d)", visibleStart+1, visibleEnd, len(s.filteredItems)))
+				fmt.Sprintf("  (%d-%d of %d)", visibleStart+1, visibleEnd, len(s.rows)))
 			b.WriteString(scrollInfo)
 			b.WriteString("\n")
 		}
+		barTop = strings.Count(b.String(), "\n")
 
 		for i := visibleStart; i < visibleEnd; i++ {
-			item := s.filteredItems[i]
+			row := s.rows[i]
 			isSelected := i == s.cursor && s.focused
-
-			// Workflow name
 			prefix := s.theme.ItemPrefix(isSelected)
-			workflowName := item.WorkflowName
 			maxWidth := s.width - 6
-			if len(workflowName) > maxWidth {
-				workflowName = workflowName[:maxWidth-3] + "..."
+
+			if s.grouped {
+				s.renderGroupedRow(&b, row, prefix, maxWidth, isSelected)
+				continue
 			}
 
+			// Workflow name
+			item := s.filteredItems[row.item]
+			workflowName := truncate(s.label(&item), maxWidth)
+
 			var workflowLine string
 			if isSelected {
 				workflowLine = s.theme.Selected.Render(prefix + workflowName)
@@ -322,10 +454,7 @@ func (s *Sidebar) View() string {
 			b.WriteString("\n")
 
 			// Group name
-			groupName := item.GroupName
-			if len(groupName) > maxWidth-2 {
-				groupName = groupName[:maxWidth-5] + "..."
-			}
+			groupName := truncate(item.GroupName, maxWidth-2)
 			groupLine := s.theme.TextDim.Render("    " + groupName)
 			b.WriteString(groupLine)
 			b.WriteString("\n")
@@ -334,6 +463,11 @@ func (s *Sidebar) View() string {
 				b.WriteString("\n")
 			}
 		}
+
+		if len(s.rows) > visibleCount {
+			barHeight := strings.Count(b.String(), "\n") - barTop
+			bar = scrollbar(s.theme, barHeight, len(s.rows), visibleStart, visibleEnd-visibleStart)
+		}
 	}
 
 	// Pad remaining
@@ -352,14 +486,41 @@ func (s *Sidebar) View() string {
 	}
 	b.WriteString(s.theme.TextMuted.Render(hints))
 
+	if bar != nil {
+		return withScrollbar(b.String(), s.width, s.height, barTop, bar)
+	}
 	return lipgloss.NewStyle().
 		Width(s.width).
 		Height(s.height).
 		Render(b.String())
 }
 
+// renderGroupedRow renders a group header with its pin count, or a pinned
+// workflow indented under its header
+func (s *Sidebar) renderGroupedRow(b *strings.Builder, row sidebarRow, prefix string, maxWidth int, isSelected bool) {
+	style := s.theme.Text
+	if isSelected {
+		style = s.theme.Selected
+	}
+
+	if row.header {
+		arrow := "▾"
+		if s.collapsed[row.groupID] && s.filterInput == "" {
+			arrow = "▸"
+		}
+		label := truncate(fmt.Sprintf("%s %s (%d)", arrow, row.group, row.count), maxWidth)
+		if !isSelected {
+			style = s.theme.TextDim
+		}
+		b.WriteString(style.Render(prefix + label))
+	} else {
+		b.WriteString(style.Render(prefix + "  " + truncate(s.label(&s.filteredItems[row.item]), maxWidth-2)))
+	}
+	b.WriteString("\n")
+}
+
 func (s *Sidebar) calculateVisibleWindow(maxVisible int) (start, end int) {
-	total := len(s.filteredItems)
+	total := len(s.rows)
 	if total <= maxVisible {
 		return 0, total
 	}
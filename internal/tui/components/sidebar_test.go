@@ -0,0 +1,59 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+func TestSidebarGrouped(t *testing.T) {
+	s := NewSidebar(theme.Default())
+	s.SetSize(40, 20)
+	s.SetFocused(true)
+	s.SetItems([]PinnedItem{
+		{WorkflowName: "deploy.yml", GroupName: "Deploy", GroupID: "deploy"},
+		{WorkflowName: "build.yml", GroupName: "CI", GroupID: "ci"},
+		{WorkflowName: "lint.yml", GroupName: "CI", GroupID: "ci"},
+	})
+	s.SetGrouped(true)
+
+	selected := func() string {
+		if item := s.SelectedItem(); item != nil {
+			return item.WorkflowName
+		}
+		return ""
+	}
+	press := func(key string) {
+		s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	view := s.View()
+	if ci, deploy := strings.Index(view, "▾ CI (2)"), strings.Index(view, "▾ Deploy (1)"); ci < 0 || deploy < ci {
+		t.Fatalf("expected groups sorted by name, got:\n%s", view)
+	}
+	if got := selected(); got != "build.yml" {
+		t.Errorf("expected the cursor on the first pin, got %q", got)
+	}
+
+	press("k")
+	if s.SelectedItem() != nil || !s.ToggleGroup() {
+		t.Fatal("expected the cursor on a header that folds")
+	}
+	if view := s.View(); !strings.Contains(view, "▸ CI (2)") || strings.Contains(view, "lint.yml") {
+		t.Errorf("expected CI folded, got:\n%s", view)
+	}
+	press("j")
+	if got := selected(); got != "" {
+		t.Errorf("expected the Deploy header after the folded group, got %q", got)
+	}
+
+	// Filtering shows matches in folded groups too
+	press("/")
+	press("l")
+	if got := selected(); got != "lint.yml" {
+		t.Errorf("expected the match in the folded group selected, got %q", got)
+	}
+}
@@ -0,0 +1,194 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// yamlKey matches the key at the start of a YAML mapping line, after its
+// indentation and an optional list dash
+var yamlKey = regexp.MustCompile(`^(\s*(?:- )?)([^\s#:][^#:]*?:)(\s|$)`)
+
+// SourceView is an overlay showing a workflow file's YAML, with keys and
+// comments highlighted
+type SourceView struct {
+	active   bool
+	loading  bool
+	workflow string
+	lines    []string
+	err      error
+	offset   int
+	width    int
+	height   int
+	theme    *theme.Theme
+}
+
+func NewSourceView(t *theme.Theme) SourceView {
+	return SourceView{theme: t}
+}
+
+func (s *SourceView) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+func (s *SourceView) IsActive() bool {
+	return s.active
+}
+
+// Workflow returns the workflow whose source is shown
+func (s *SourceView) Workflow() string {
+	return s.workflow
+}
+
+// Source returns the shown source, empty while it is loading
+func (s *SourceView) Source() string {
+	return strings.Join(s.lines, "\n")
+}
+
+// Open shows the overlay for workflow, loading until SetSource is called
+func (s *SourceView) Open(workflow string) {
+	s.active = true
+	s.loading = true
+	s.workflow = workflow
+	s.lines = nil
+	s.err = nil
+	s.offset = 0
+}
+
+// SetSource fills in the overlay once the source of workflow is fetched.
+// Results for a workflow that is no longer shown are ignored.
+func (s *SourceView) SetSource(workflow, source string, err error) {
+	if workflow != s.workflow {
+		return
+	}
+	s.loading = false
+	s.err = err
+	s.lines = strings.Split(strings.TrimRight(source, "\n"), "\n")
+	if source == "" {
+		s.lines = nil
+	}
+}
+
+func (s *SourceView) Close() {
+	s.active = false
+	s.lines = nil
+}
+
+// visibleLines is how many source lines fit the overlay
+func (s *SourceView) visibleLines() int {
+	return max(1, max(15, s.height*80/100)-8)
+}
+
+// Update handles scrolling and closing. It returns true when y is pressed
+// to copy the source.
+func (s *SourceView) Update(msg tea.Msg) bool {
+	if !s.active {
+		return false
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+
+	lastOffset := max(0, len(s.lines)-s.visibleLines())
+	switch keyMsg.String() {
+	case "esc", "q", "V":
+		s.Close()
+	case "y":
+		return !s.loading && s.err == nil && len(s.lines) > 0
+	case "j", "down":
+		s.offset = min(s.offset+1, lastOffset)
+	case "k", "up":
+		s.offset = max(s.offset-1, 0)
+	case "ctrl+d", "pgdown":
+		s.offset = min(s.offset+s.visibleLines()/2, lastOffset)
+	case "ctrl+u", "pgup":
+		s.offset = max(s.offset-s.visibleLines()/2, 0)
+	case "g", "home":
+		s.offset = 0
+	case "G", "end":
+		s.offset = lastOffset
+	}
+	return false
+}
+
+// highlight styles a YAML line: comments are muted and mapping keys stand
+// out from their values
+func (s *SourceView) highlight(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return s.theme.TextMuted.Render(line)
+	}
+	m := yamlKey.FindStringSubmatchIndex(line)
+	if m == nil {
+		return s.theme.Text.Render(line)
+	}
+	return s.theme.Text.Render(line[:m[4]]) +
+		s.theme.Title.Render(line[m[4]:m[5]]) +
+		s.theme.Text.Render(line[m[5]:])
+}
+
+func (s *SourceView) View() string {
+	if !s.active {
+		return ""
+	}
+
+	overlayWidth := max(50, s.width*80/100)
+	overlayHeight := max(15, s.height*80/100)
+	numberWidth := len(fmt.Sprint(len(s.lines)))
+	textWidth := overlayWidth - 10 - numberWidth
+
+	var b strings.Builder
+	b.WriteString(s.theme.Title.Render("Source · " + s.workflow))
+	b.WriteString("\n")
+	b.WriteString(s.theme.Divider(overlayWidth - 8))
+	b.WriteString("\n\n")
+
+	switch {
+	case s.loading:
+		b.WriteString(s.theme.StatusInProgress.Render(s.theme.Icons.InProgress + " Loading workflow source..."))
+		b.WriteString("\n")
+	case s.err != nil:
+		b.WriteString(s.theme.TextMuted.Render(truncate("Source unavailable: "+s.err.Error(), textWidth)))
+		b.WriteString("\n")
+	case len(s.lines) == 0:
+		b.WriteString(s.theme.TextMuted.Render("The workflow file is empty."))
+		b.WriteString("\n")
+	default:
+		end := min(len(s.lines), s.offset+s.visibleLines())
+		for i := s.offset; i < end; i++ {
+			line := strings.ReplaceAll(s.lines[i], "\t", "  ")
+			b.WriteString(s.theme.TextMuted.Render(fmt.Sprintf("%*d ", numberWidth, i+1)))
+			b.WriteString(s.highlight(truncate(line, textWidth)))
+			b.WriteString("\n")
+		}
+		if len(s.lines) > s.visibleLines() {
+			b.WriteString(s.theme.TextMuted.Render(fmt.Sprintf("(%d-%d of %d lines)", s.offset+1, end, len(s.lines))))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.theme.TextMuted.Render("[j/k] scroll [g/G] top/bottom [y] copy [esc] close"))
+
+	overlayContent := lipgloss.NewStyle().
+		Width(overlayWidth-4).
+		Height(overlayHeight-2).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		s.width,
+		s.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		s.theme.BorderActive.Render(overlayContent),
+	)
+}
@@ -18,6 +18,11 @@ type StatusBar struct {
 	autoRefresh     bool
 	refreshInterval int
 	loading         bool
+	filterShown     int
+	filterTotal     int
+	hidden          int
+	showHidden      bool
+	localRepository string
 	theme           *theme.Theme
 }
 
@@ -59,6 +64,26 @@ func (s *StatusBar) SetLoading(loading bool) {
 	s.loading = loading
 }
 
+// SetFilterCounts shows how many of the focused panel's items a filter
+// leaves visible. A total of 0 hides the count.
+func (s *StatusBar) SetFilterCounts(shown, total int) {
+	s.filterShown = shown
+	s.filterTotal = total
+}
+
+// SetHidden shows how many workflows are hidden and whether they are
+// currently listed anyway. A count of 0 hides it.
+func (s *StatusBar) SetHidden(count int, shown bool) {
+	s.hidden = count
+	s.showHidden = shown
+}
+
+// SetLocalRepository warns that the repository shown is not the current
+// directory's, which is repo. An empty repo hides the warning.
+func (s *StatusBar) SetLocalRepository(repo string) {
+	s.localRepository = repo
+}
+
 // View renders the status bar
 func (s *StatusBar) View() string {
 	// Build breadcrumb
@@ -78,6 +103,24 @@ func (s *StatusBar) View() string {
 	// Build right side status
 	var statusParts []string
 
+	if s.localRepository != "" {
+		statusParts = append(statusParts,
+			s.theme.StatusWarning.Render(fmt.Sprintf("%s local repo is %s [ctrl+l]", s.theme.Icons.ActionRequired, s.localRepository)))
+	}
+
+	if s.filterTotal > 0 {
+		statusParts = append(statusParts,
+			s.theme.TextMuted.Render(fmt.Sprintf("showing %d of %d", s.filterShown, s.filterTotal)))
+	}
+
+	if s.hidden > 0 {
+		hidden := fmt.Sprintf("%d hidden", s.hidden)
+		if s.showHidden {
+			hidden += " (shown)"
+		}
+		statusParts = append(statusParts, s.theme.TextMuted.Render(hidden))
+	}
+
 	if s.loading {
 		statusParts = append(statusParts,
 			s.theme.StatusInProgress.Render(s.theme.Icons.InProgress+" Loading"))
@@ -120,11 +163,17 @@ func (s *StatusBar) View() string {
 		Render(content)
 }
 
-// HelpBar displays context-sensitive keybindings
+// legendLines is how many lines the expanded help bar takes at most
+const legendLines = 3
+
+// HelpBar displays context-sensitive keybindings: one line of hints, or
+// when expanded, a short legend of the context's bindings with descriptions
 type HelpBar struct {
-	width int
-	hints []string
-	theme *theme.Theme
+	width    int
+	hints    []string
+	legend   KeySection
+	expanded bool
+	theme    *theme.Theme
 }
 
 // NewHelpBar creates a new help bar
@@ -144,9 +193,60 @@ func (h *HelpBar) SetHints(hints []string) {
 	h.hints = hints
 }
 
+// SetLegend sets the bindings the expanded help bar describes
+func (h *HelpBar) SetLegend(section KeySection) {
+	h.legend = section
+}
+
+// ToggleExpanded switches between the hints and the legend
+func (h *HelpBar) ToggleExpanded() {
+	h.expanded = !h.expanded
+}
+
+func (h *HelpBar) IsExpanded() bool {
+	return h.expanded
+}
+
+// Height returns how many lines View renders
+func (h *HelpBar) Height() int {
+	if !h.expanded {
+		return 1
+	}
+	return len(h.legendLines())
+}
+
+// legendLines packs the legend's bindings into as many lines as fit,
+// pointing at the full help when some are left out
+func (h *HelpBar) legendLines() []string {
+	const more = "· [?] all keys"
+	width := max(20, h.width-2)
+
+	lines := []string{h.legend.Title + ":"}
+	for _, binding := range h.legend.Bindings {
+		entry := "[" + binding.Key + "] " + binding.Description
+		last := len(lines) - 1
+		if lipgloss.Width(lines[last])+1+lipgloss.Width(entry) <= width {
+			lines[last] += " " + entry
+			continue
+		}
+		if len(lines) == legendLines {
+			if lipgloss.Width(lines[last])+1+len(more) > width {
+				lines[last] = truncate(lines[last], width-len(more)-1)
+			}
+			lines[last] += " " + more
+			return lines
+		}
+		lines = append(lines, "  "+entry)
+	}
+	return lines
+}
+
 // View renders the help bar
 func (h *HelpBar) View() string {
 	content := strings.Join(h.hints, " ")
+	if h.expanded {
+		content = strings.Join(h.legendLines(), "\n")
+	}
 	return h.theme.HelpBar.
 		Width(h.width).
 		Render(content)
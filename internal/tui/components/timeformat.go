@@ -0,0 +1,49 @@
+package components
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultTimeLayout is the layout of run times when none is configured
+const defaultTimeLayout = "2006-01-02 15:04:05"
+
+// RunTimeFormat describes how run times are rendered. The zero value renders
+// them with the default layout in the local time zone.
+type RunTimeFormat struct {
+	Layout   string // Go time layout, "" for the default
+	Relative bool   // Render each time as its age, such as 3h ago, instead
+	UTC      bool   // Render times in UTC instead of the local time zone
+}
+
+// Format renders t, measuring relative times to now
+func (f RunTimeFormat) Format(t, now time.Time) string {
+	if f.Relative {
+		age := now.Sub(t)
+		if age < 0 {
+			age = 0 // Clock skew between GitHub and this machine
+		}
+		return formatElapsed(age) + " ago"
+	}
+	layout := f.Layout
+	if layout == "" {
+		layout = defaultTimeLayout
+	}
+	if f.UTC {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	return t.Format(layout)
+}
+
+// width returns the widest time the format renders, to size a column. The
+// sample has the longest month and weekday names and two-digit fields.
+func (f RunTimeFormat) width() int {
+	if f.Relative {
+		return lipgloss.Width("999d ago")
+	}
+	sample := time.Date(2006, time.September, 27, 23, 59, 59, 999999999, time.Local)
+	return lipgloss.Width(f.Format(sample, sample))
+}
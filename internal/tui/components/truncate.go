@@ -0,0 +1,19 @@
+package components
+
+// truncate shortens s to at most width characters, replacing the tail with
+// "..." when it is cut. Widths too narrow for the ellipsis cut without it, and
+// a non-positive width yields an empty string, so callers can pass widths
+// derived from tiny terminals without bounds checks.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/git"
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// remotePicker asks which git remote to detect the repository from when
+// several point at GitHub
+type remotePicker struct {
+	remotes []git.Remote
+	cursor  int
+	picked  string
+	width   int
+	height  int
+	theme   *theme.Theme
+}
+
+func newRemotePicker(remotes []git.Remote, preselect string) *remotePicker {
+	p := &remotePicker{remotes: remotes, theme: theme.Default()}
+	for i, r := range remotes {
+		if r.Name == preselect {
+			p.cursor = i
+		}
+	}
+	return p
+}
+
+// PickRemote lets the user pick one of remotes in a small full-screen list,
+// starting on preselect. It returns the name of the picked remote, or an
+// empty string when the picker is cancelled.
+func PickRemote(remotes []git.Remote, preselect string) (string, error) {
+	picker := newRemotePicker(remotes, preselect)
+	if _, err := tea.NewProgram(picker, tea.WithAltScreen()).Run(); err != nil {
+		return "", err
+	}
+	return picker.picked, nil
+}
+
+func (p *remotePicker) Init() tea.Cmd {
+	return nil
+}
+
+func (p *remotePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c", "q":
+			return p, tea.Quit
+		case "up", "k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "down", "j":
+			if p.cursor < len(p.remotes)-1 {
+				p.cursor++
+			}
+		case "enter":
+			if len(p.remotes) > 0 {
+				p.picked = p.remotes[p.cursor].Name
+			}
+			return p, tea.Quit
+		}
+	}
+	return p, nil
+}
+
+func (p *remotePicker) View() string {
+	width := max(50, p.width*60/100)
+
+	var b strings.Builder
+	b.WriteString(p.theme.Title.Render("Multiple GitHub remotes found"))
+	b.WriteString("\n")
+	b.WriteString(p.theme.Divider(width - 4))
+	b.WriteString("\n\n")
+	b.WriteString(p.theme.Text.Render("Pick the remote to view the repository of."))
+	b.WriteString("\n\n")
+
+	for i, r := range p.remotes {
+		isSelected := i == p.cursor
+		line := p.theme.ItemPrefix(isSelected) + fmt.Sprintf("%s (%s)", r.Name, r.FullName())
+		if isSelected {
+			b.WriteString(p.theme.Selected.Render(line))
+		} else {
+			b.WriteString(p.theme.Text.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(p.theme.TextMuted.Render("[enter] use remote | [esc] use " + git.DefaultRemote + " | --remote skips this"))
+
+	content := lipgloss.NewStyle().
+		Width(width-4).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		p.width,
+		p.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		p.theme.BorderActive.Render(content),
+	)
+}
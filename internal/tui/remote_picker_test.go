@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cloudsky01/gh-rivet/internal/git"
+)
+
+func TestRemotePicker(t *testing.T) {
+	remotes := []git.Remote{
+		{Name: "fork", Host: "github.com", Repository: "me/Hello-World"},
+		{Name: "origin", Host: "github.com", Repository: "octocat/Hello-World"},
+		{Name: "enterprise", Host: "ghe.example.com", Repository: "octocat/Hello-World"},
+	}
+	p := newRemotePicker(remotes, git.DefaultRemote)
+	p.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	if p.cursor != 1 {
+		t.Errorf("cursor = %d, want origin preselected", p.cursor)
+	}
+	if !strings.Contains(p.View(), "ghe.example.com/octocat/Hello-World") {
+		t.Error("expected remotes to be listed with their repository")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected enter to end the picker")
+	}
+	if p.picked != "enterprise" {
+		t.Errorf("picked = %q, want enterprise", p.picked)
+	}
+
+	p = newRemotePicker(remotes, git.DefaultRemote)
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.picked != "" {
+		t.Errorf("expected esc to pick nothing, got %q", p.picked)
+	}
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/internal/tui/theme"
+)
+
+// RepositoryValidator checks a typed repository and returns its normalized
+// form, or why it cannot be viewed
+type RepositoryValidator func(input string) (string, error)
+
+type repositoryValidatedMsg struct {
+	input string
+	repo  string
+	err   error
+}
+
+// repositoryPrompt asks for the repository to view when none is configured.
+// Each entry is validated before the prompt exits with it.
+type repositoryPrompt struct {
+	input      string
+	validating bool
+	err        error
+	repo       string
+	validate   RepositoryValidator
+	width      int
+	height     int
+	theme      *theme.Theme
+}
+
+func newRepositoryPrompt(suggestion string, validate RepositoryValidator) *repositoryPrompt {
+	return &repositoryPrompt{
+		input:    suggestion,
+		validate: validate,
+		theme:    theme.Default(),
+	}
+}
+
+// PromptRepository asks for a repository in a small full-screen prompt,
+// starting from suggestion. It returns an empty string when the prompt is
+// cancelled.
+func PromptRepository(suggestion string, validate RepositoryValidator) (string, error) {
+	prompt := newRepositoryPrompt(suggestion, validate)
+	if _, err := tea.NewProgram(prompt, tea.WithAltScreen()).Run(); err != nil {
+		return "", err
+	}
+	return prompt.repo, nil
+}
+
+func (p *repositoryPrompt) Init() tea.Cmd {
+	return nil
+}
+
+func (p *repositoryPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+
+	case repositoryValidatedMsg:
+		// The input may have changed while it was being validated
+		if msg.input != strings.TrimSpace(p.input) {
+			return p, nil
+		}
+		p.validating = false
+		p.err = msg.err
+		if msg.err == nil {
+			p.repo = msg.repo
+			return p, tea.Quit
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			return p, tea.Quit
+		case "enter":
+			input := strings.TrimSpace(p.input)
+			if input == "" || p.validating {
+				return p, nil
+			}
+			p.validating = true
+			p.err = nil
+			validate := p.validate
+			return p, func() tea.Msg {
+				repo, err := validate(input)
+				return repositoryValidatedMsg{input: input, repo: repo, err: err}
+			}
+		case "backspace":
+			if len(p.input) > 0 {
+				_, size := utf8.DecodeLastRuneInString(p.input)
+				p.input = p.input[:len(p.input)-size]
+				p.validating = false
+				p.err = nil
+			}
+		default:
+			if msg.Type == tea.KeyRunes {
+				p.input += string(msg.Runes)
+				p.validating = false
+				p.err = nil
+			}
+		}
+	}
+	return p, nil
+}
+
+func (p *repositoryPrompt) View() string {
+	width := max(50, p.width*60/100)
+
+	var b strings.Builder
+	b.WriteString(p.theme.Title.Render("No repository configured"))
+	b.WriteString("\n")
+	b.WriteString(p.theme.Divider(width - 4))
+	b.WriteString("\n\n")
+	b.WriteString(p.theme.Text.Render("Enter the repository to view. It is saved to your config."))
+	b.WriteString("\n\n")
+
+	inputText := p.input + "█"
+	if p.input == "" {
+		inputText = p.theme.TextMuted.Render("owner/repo or [HOST/]OWNER/REPO") + "█"
+	}
+	b.WriteString(p.theme.FilterPrompt.Render("> "))
+	b.WriteString(p.theme.FilterInput.Render(inputText))
+	b.WriteString("\n\n")
+
+	switch {
+	case p.validating:
+		b.WriteString(p.theme.StatusInProgress.Render(p.theme.Icons.InProgress + " Checking repository..."))
+	case p.err != nil:
+		b.WriteString(p.theme.StatusError.Render(p.theme.Icons.Error + " " + p.err.Error()))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(p.theme.TextMuted.Render("[enter] use repository | [esc] cancel"))
+
+	content := lipgloss.NewStyle().
+		Width(width-4).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(
+		p.width,
+		p.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		p.theme.BorderActive.Render(content),
+	)
+}
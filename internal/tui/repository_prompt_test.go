@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRepositoryPrompt(t *testing.T) {
+	validate := func(input string) (string, error) {
+		if input != "owner/repo" {
+			return "", errors.New("repository " + input + " not found")
+		}
+		return input, nil
+	}
+	p := newRepositoryPrompt("owner/rep", validate)
+	p.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	// Runs the validation the way the program would and feeds back its result
+	submit := func() tea.Cmd {
+		t.Helper()
+		_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			t.Fatal("expected enter to validate the input")
+		}
+		_, cmd = p.Update(cmd())
+		return cmd
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if cmd := submit(); cmd != nil {
+		t.Fatal("expected an unknown repository to keep the prompt open")
+	}
+	if !strings.Contains(p.View(), "owner/re not found") {
+		t.Errorf("expected the validation error to be shown")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("po")})
+	if cmd := submit(); cmd == nil {
+		t.Fatal("expected a valid repository to end the prompt")
+	}
+	if p.repo != "owner/repo" {
+		t.Errorf("expected owner/repo to be picked, got %q", p.repo)
+	}
+}
+
+func TestRepositoryPromptIgnoresStaleValidation(t *testing.T) {
+	p := newRepositoryPrompt("", func(input string) (string, error) {
+		return input, nil
+	})
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a/b")})
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if _, quit := p.Update(cmd()); quit != nil || p.repo != "" {
+		t.Error("expected the result for an edited input to be ignored")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.repo != "" {
+		t.Error("expected esc to cancel without a repository")
+	}
+}
+
+func TestRepositoryPromptBackspaceRemovesRune(t *testing.T) {
+	p := newRepositoryPrompt("owner/répo", nil)
+
+	for range 3 {
+		p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	if p.input != "owner/r" {
+		t.Errorf("input = %q, want owner/r", p.input)
+	}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionVersion is bumped if the recording format changes
+const sessionVersion = 1
+
+// Session is a recording of the key presses and terminal resizes of a TUI
+// session. Replaying it reproduces layout and navigation bugs without the
+// reporter's terminal.
+type Session struct {
+	Version int            `json:"version"`
+	Events  []SessionEvent `json:"events"`
+}
+
+// SessionEvent is one recorded input: a key press or, when Key is nil, a
+// terminal resize
+type SessionEvent struct {
+	Key    *SessionKey `json:"key,omitempty"`
+	Width  int         `json:"width,omitempty"`
+	Height int         `json:"height,omitempty"`
+}
+
+// SessionKey mirrors tea.Key in a stable JSON form
+type SessionKey struct {
+	Type  tea.KeyType `json:"type"`
+	Runes string      `json:"runes,omitempty"`
+	Alt   bool        `json:"alt,omitempty"`
+	Paste bool        `json:"paste,omitempty"`
+}
+
+func NewSession() *Session {
+	return &Session{Version: sessionVersion}
+}
+
+// LoadSession reads a session recorded with --record
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	if s.Version != sessionVersion {
+		return nil, fmt.Errorf("unsupported session version %d", s.Version)
+	}
+	return &s, nil
+}
+
+func (s *Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// record appends msg if it is a key press or resize
+func (s *Session) record(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		s.Events = append(s.Events, SessionEvent{Key: &SessionKey{
+			Type:  msg.Type,
+			Runes: string(msg.Runes),
+			Alt:   msg.Alt,
+			Paste: msg.Paste,
+		}})
+	case tea.WindowSizeMsg:
+		s.Events = append(s.Events, SessionEvent{Width: msg.Width, Height: msg.Height})
+	}
+}
+
+// Msg returns the tea message the event was recorded from
+func (e SessionEvent) Msg() tea.Msg {
+	if e.Key == nil {
+		return tea.WindowSizeMsg{Width: e.Width, Height: e.Height}
+	}
+	return tea.KeyMsg{
+		Type:  e.Key.Type,
+		Runes: []rune(e.Key.Runes),
+		Alt:   e.Key.Alt,
+		Paste: e.Key.Paste,
+	}
+}
+
+// Replay feeds the session's events through app.Update in order and returns
+// the final frame. Commands returned by Update are not run, so replay makes no
+// gh calls and is deterministic.
+func Replay(app *App, s *Session) string {
+	for _, event := range s.Events {
+		app.Update(event.Msg())
+	}
+	return app.View()
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	s := NewSession()
+	s.record(tea.WindowSizeMsg{Width: 120, Height: 40})
+	s.record(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	s.record(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	s.record(workflowRunsMsg{})
+
+	if len(s.Events) != 3 {
+		t.Fatalf("expected only keys and resizes to be recorded, got %d events", len(s.Events))
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadSession(path)
+	if err != nil {
+		t.Fatalf("LoadSession() error = %v", err)
+	}
+
+	want := []tea.Msg{
+		tea.WindowSizeMsg{Width: 120, Height: 40},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")},
+		tea.KeyMsg{Type: tea.KeyEnter, Alt: true},
+	}
+	for i, event := range loaded.Events {
+		got := event.Msg()
+		if key, ok := got.(tea.KeyMsg); ok {
+			if key.String() != want[i].(tea.KeyMsg).String() {
+				t.Errorf("event %d = %q, want %q", i, key.String(), want[i].(tea.KeyMsg).String())
+			}
+		} else if got != want[i] {
+			t.Errorf("event %d = %#v, want %#v", i, got, want[i])
+		}
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	app := newTestApp(t)
+	app.session = NewSession()
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(tea.WindowSizeMsg{Width: 30, Height: 8})
+
+	replayed := Replay(newTestApp(t), app.session)
+	if replayed != app.View() {
+		t.Fatalf("replayed frame differs from the recorded one:\n%s\n---\n%s", replayed, app.View())
+	}
+	if !strings.Contains(replayed, "Terminal too small") {
+		t.Fatalf("expected final resize to be replayed, got:\n%s", replayed)
+	}
+}
@@ -2,7 +2,11 @@
 // Inspired by k9s, this provides a consistent visual language across all components.
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cloudsky01/gh-rivet/pkg/models"
+)
 
 // Colors defines the color palette for the application
 type Colors struct {
@@ -64,21 +68,29 @@ type Theme struct {
 
 // IconSet defines the icons used throughout the app
 type IconSet struct {
-	Folder      string
-	FolderOpen  string
-	Workflow    string
-	Pin         string
-	Success     string
-	Error       string
-	InProgress  string
-	Pending     string
-	Search      string
-	Filter      string
-	Refresh     string
-	RefreshAuto string
-	Back        string
-	Selected    string
-	Unselected  string
+	Folder         string
+	FolderOpen     string
+	Workflow       string
+	Pin            string
+	Favorite       string
+	Hidden         string
+	Success        string
+	Error          string
+	Cancelled      string
+	Skipped        string
+	Neutral        string
+	ActionRequired string
+	Waiting        string
+	InProgress     string
+	Pending        string
+	Search         string
+	Filter         string
+	Refresh        string
+	RefreshAuto    string
+	Back           string
+	Selected       string
+	Unselected     string
+	Marked         string
 }
 
 // DefaultColors returns the default color palette (dark theme)
@@ -113,21 +125,29 @@ func DefaultColors() Colors {
 // DefaultIcons returns the default icon set
 func DefaultIcons() IconSet {
 	return IconSet{
-		Folder:      "📁",
-		FolderOpen:  "📂",
-		Workflow:    "⚙️ ",
-		Pin:         "📌",
-		Success:     "✓",
-		Error:       "✗",
-		InProgress:  "⟳",
-		Pending:     "○",
-		Search:      "🔍",
-		Filter:      "⏵",
-		Refresh:     "↻",
-		RefreshAuto: "⟳",
-		Back:        "←",
-		Selected:    "▸",
-		Unselected:  " ",
+		Folder:         "📁",
+		FolderOpen:     "📂",
+		Workflow:       "⚙️ ",
+		Pin:            "📌",
+		Favorite:       "⭐",
+		Hidden:         "🙈",
+		Success:        "✓",
+		Error:          "✗",
+		Cancelled:      "⊘",
+		Skipped:        "⊝",
+		Neutral:        "−",
+		ActionRequired: "!",
+		Waiting:        "⏸",
+		InProgress:     "⟳",
+		Pending:        "○",
+		Search:         "🔍",
+		Filter:         "⏵",
+		Refresh:        "↻",
+		RefreshAuto:    "⟳",
+		Back:           "←",
+		Selected:       "▸",
+		Unselected:     " ",
+		Marked:         "◆",
 	}
 }
 
@@ -225,21 +245,40 @@ func Default() *Theme {
 
 // StatusIcon returns the appropriate icon for a workflow status
 func (t *Theme) StatusIcon(status, conclusion string) (string, lipgloss.Style) {
-	switch status {
-	case "completed":
-		if conclusion == "success" {
-			return t.Icons.Success, t.StatusSuccess
-		}
-		return t.Icons.Error, t.StatusError
-	case "in_progress":
+	switch {
+	case models.IsTerminalStatus(status):
+		return t.ConclusionIcon(conclusion)
+	case models.IsRunningStatus(status):
 		return t.Icons.InProgress, t.StatusInProgress
-	case "queued", "waiting", "pending":
-		return t.Icons.Pending, t.TextDim
+	case status == models.StatusWaiting:
+		// Held for approval, e.g. by an environment protection rule
+		return t.Icons.Waiting, t.StatusWarning
 	default:
 		return t.Icons.Pending, t.TextDim
 	}
 }
 
+// ConclusionIcon returns the icon and style for a completed run's conclusion.
+// Only genuine failures are red; skipped and cancelled runs are dimmed and
+// runs waiting on a person are highlighted as warnings.
+func (t *Theme) ConclusionIcon(conclusion string) (string, lipgloss.Style) {
+	switch {
+	case conclusion == models.ConclusionSuccess:
+		return t.Icons.Success, t.StatusSuccess
+	case models.IsFailureConclusion(conclusion):
+		return t.Icons.Error, t.StatusError
+	case conclusion == models.ConclusionActionRequired:
+		return t.Icons.ActionRequired, t.StatusWarning
+	case conclusion == models.ConclusionCancelled:
+		return t.Icons.Cancelled, t.TextDim
+	case conclusion == models.ConclusionSkipped:
+		return t.Icons.Skipped, t.TextDim
+	default:
+		// neutral, stale, or anything GitHub adds later
+		return t.Icons.Neutral, t.TextDim
+	}
+}
+
 // ItemPrefix returns the cursor prefix for an item
 func (t *Theme) ItemPrefix(selected bool) string {
 	if selected {
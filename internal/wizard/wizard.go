@@ -366,7 +366,8 @@ func (w *Wizard) promptRepository() error {
 						if strings.TrimSpace(s) == "" {
 							return fmt.Errorf("repository is required")
 						}
-						return git.ValidateRepositoryFormat(strings.TrimSpace(s))
+						_, err := github.NormalizeRepo(s)
+						return err
 					}).
 					Value(&repo),
 			),
@@ -377,7 +378,10 @@ func (w *Wizard) promptRepository() error {
 		}
 	}
 
-	repo = strings.TrimSpace(repo)
+	repo, err := github.NormalizeRepo(repo)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Validating repository..."))
 
@@ -461,6 +465,24 @@ func AskConfirm(title, description string, result *bool) error {
 	).Run()
 }
 
+// AskSelect asks the user to pick one of options, storing its value in result.
+// The option matching result's initial value is preselected.
+func AskSelect(title, description string, options []huh.Option[string], result *string) error {
+	if !isTTY() {
+		return fmt.Errorf("cannot ask for a selection in non-interactive mode")
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(title).
+				Description(description).
+				Options(options...).
+				Value(result),
+		),
+	).Run()
+}
+
 func GetInfoStyle() lipgloss.Style {
 	return lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
 }
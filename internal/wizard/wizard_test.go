@@ -1,6 +1,8 @@
 package wizard
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -99,3 +101,22 @@ func TestIsTTY(t *testing.T) {
 	result := isTTY()
 	t.Logf("isTTY returned: %v", result)
 }
+
+func TestWorkflowNames(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"build.yml":  "name: Build and test\non: push\n",
+		"lint.yml":   "on: push\n",
+		"broken.yml": "name: [unclosed\n",
+	}
+	for file, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := WorkflowNames(dir, []string{"build.yml", "lint.yml", "broken.yml", "missing.yml"})
+	if len(names) != 1 || names["build.yml"] != "Build and test" {
+		t.Errorf("WorkflowNames() = %v, want only build.yml named", names)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 func DiscoverWorkflows(workflowDir string) ([]string, error) {
@@ -27,3 +29,26 @@ func DiscoverWorkflows(workflowDir string) ([]string, error) {
 	sort.Strings(workflows)
 	return workflows, nil
 }
+
+// WorkflowNames reads the name: field of each workflow file in workflowDir,
+// keyed by file. Files that cannot be read or parsed, or have no name, are
+// left out so callers fall back to the file name.
+func WorkflowNames(workflowDir string, files []string) map[string]string {
+	names := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(workflowDir, file))
+		if err != nil {
+			continue
+		}
+		var workflow struct {
+			Name string `yaml:"name"`
+		}
+		if err := yaml.Unmarshal(data, &workflow); err != nil {
+			continue
+		}
+		if name := strings.TrimSpace(workflow.Name); name != "" {
+			names[file] = name
+		}
+	}
+	return names
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // GHRun represents a GitHub workflow run
 type GHRun struct {
@@ -11,6 +14,10 @@ type GHRun struct {
 	Conclusion   string    `json:"conclusion"`
 	CreatedAt    time.Time `json:"createdAt"`
 	HeadBranch   string    `json:"headBranch"`
+	Attempt      int       `json:"attempt"` // 1 for the first run, higher for re-runs
+	// Fetched only when asked for through github.RunListOptions
+	Event     string    `json:"event"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 // GHRunDetail contains the jobs for a workflow run
@@ -20,9 +27,52 @@ type GHRunDetail struct {
 
 // GHJob represents a single job in a workflow run
 type GHJob struct {
+	DatabaseID   int    `json:"databaseId"`
 	Name         string `json:"name"`
 	Status       string `json:"status"`
 	Conclusion   string `json:"conclusion"`
+	URL          string `json:"url"` // The job's page, empty for older gh versions
 	WorkflowName string
 	RunID        int
 }
+
+// GHAnnotation is a check annotation a job left on a run, such as a lint
+// error or a failing test
+type GHAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	Level     string `json:"annotation_level"` // notice, warning, or failure
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	JobName   string `json:"-"`
+}
+
+// AnnotationSummary counts a run's error and warning annotations
+type AnnotationSummary struct {
+	Errors   int
+	Warnings int
+}
+
+// WorkflowInfo describes a workflow file: where it lives and the events
+// listed under its on: key, in file order
+type WorkflowInfo struct {
+	File     string
+	Path     string
+	Name     string
+	Triggers []string
+}
+
+// CanDispatch reports whether the workflow can be run manually
+func (w WorkflowInfo) CanDispatch() bool {
+	return slices.Contains(w.Triggers, "workflow_dispatch")
+}
+
+// WorkflowInput describes one workflow_dispatch input of a workflow
+type WorkflowInput struct {
+	Name        string   `yaml:"-"`
+	Description string   `yaml:"description"`
+	Required    bool     `yaml:"required"`
+	Default     string   `yaml:"default"`
+	Type        string   `yaml:"type"` // string, boolean, choice, number, or environment
+	Options     []string `yaml:"options"`
+}
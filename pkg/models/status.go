@@ -0,0 +1,234 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Workflow run and job statuses reported by GitHub Actions
+const (
+	StatusRequested  = "requested"
+	StatusQueued     = "queued"
+	StatusWaiting    = "waiting"
+	StatusPending    = "pending"
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+)
+
+// Conclusions of a completed workflow run or job
+const (
+	ConclusionSuccess        = "success"
+	ConclusionFailure        = "failure"
+	ConclusionCancelled      = "cancelled"
+	ConclusionSkipped        = "skipped"
+	ConclusionNeutral        = "neutral"
+	ConclusionTimedOut       = "timed_out"
+	ConclusionActionRequired = "action_required"
+	ConclusionStartupFailure = "startup_failure"
+	ConclusionStale          = "stale"
+)
+
+// Conclusions lists every conclusion above, for validating user input
+var Conclusions = []string{
+	ConclusionSuccess,
+	ConclusionFailure,
+	ConclusionCancelled,
+	ConclusionSkipped,
+	ConclusionNeutral,
+	ConclusionTimedOut,
+	ConclusionActionRequired,
+	ConclusionStartupFailure,
+	ConclusionStale,
+}
+
+// NormalizeStatus lowercases and trims a status or conclusion so it can be
+// compared against the constants above
+func NormalizeStatus(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// IsTerminalStatus reports whether status means the run or job has finished
+func IsTerminalStatus(status string) bool {
+	return status == StatusCompleted
+}
+
+// IsRunningStatus reports whether status means the run or job is executing
+func IsRunningStatus(status string) bool {
+	return status == StatusInProgress
+}
+
+// IsPendingStatus reports whether status means the run or job has not started
+func IsPendingStatus(status string) bool {
+	switch status {
+	case StatusRequested, StatusQueued, StatusWaiting, StatusPending:
+		return true
+	}
+	return false
+}
+
+// NeedsAttention reports whether a run or job is blocked on a person, such
+// as a reviewer approving a protected environment or a first-time
+// contributor's run
+func NeedsAttention(status, conclusion string) bool {
+	if status == StatusWaiting {
+		return true
+	}
+	return IsTerminalStatus(status) && conclusion == ConclusionActionRequired
+}
+
+// IsFailureConclusion reports whether conclusion means the run or job failed
+func IsFailureConclusion(conclusion string) bool {
+	switch conclusion {
+	case ConclusionFailure, ConclusionTimedOut, ConclusionStartupFailure:
+		return true
+	}
+	return false
+}
+
+// Normalize canonicalizes the run's status and conclusion
+func (r *GHRun) Normalize() {
+	r.Status = NormalizeStatus(r.Status)
+	r.Conclusion = NormalizeStatus(r.Conclusion)
+}
+
+// IsTerminal reports whether the run has finished
+func (r GHRun) IsTerminal() bool {
+	return IsTerminalStatus(r.Status)
+}
+
+// IsRunning reports whether the run is executing
+func (r GHRun) IsRunning() bool {
+	return IsRunningStatus(r.Status)
+}
+
+// IsSuccess reports whether the run finished successfully
+func (r GHRun) IsSuccess() bool {
+	return r.IsTerminal() && r.Conclusion == ConclusionSuccess
+}
+
+// NeedsAttention reports whether the run is waiting on someone's approval
+func (r GHRun) NeedsAttention() bool {
+	return NeedsAttention(r.Status, r.Conclusion)
+}
+
+// IsFailure reports whether the run finished with a failing conclusion
+func (r GHRun) IsFailure() bool {
+	return r.IsTerminal() && IsFailureConclusion(r.Conclusion)
+}
+
+// QueuedFor returns how long the run has waited to start as of now, or 0
+// once it has started. Runs report no start time, so the wait is counted
+// from their creation.
+func (r GHRun) QueuedFor(now time.Time) time.Duration {
+	if !IsPendingStatus(r.Status) || r.CreatedAt.IsZero() {
+		return 0
+	}
+	return max(0, now.Sub(r.CreatedAt))
+}
+
+// AttemptBadge returns "(attempt N)" for a re-run, which shares its ID with
+// the earlier attempts, and "" otherwise
+func (r GHRun) AttemptBadge() string {
+	if r.Attempt <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("(attempt %d)", r.Attempt)
+}
+
+// Normalize canonicalizes the job's status and conclusion
+func (j *GHJob) Normalize() {
+	j.Status = NormalizeStatus(j.Status)
+	j.Conclusion = NormalizeStatus(j.Conclusion)
+}
+
+// IsTerminal reports whether the job has finished
+func (j GHJob) IsTerminal() bool {
+	return IsTerminalStatus(j.Status)
+}
+
+// IsFailure reports whether the job finished with a failing conclusion
+func (j GHJob) IsFailure() bool {
+	return j.IsTerminal() && IsFailureConclusion(j.Conclusion)
+}
+
+// MatchesWorkflow reports whether the run belongs to the workflow with the
+// given file and display name. Runs only carry the workflow's display name,
+// or its path for workflows without a name, so both are compared.
+func (r GHRun) MatchesWorkflow(file, name string) bool {
+	return strings.EqualFold(r.WorkflowName, name) ||
+		strings.EqualFold(r.WorkflowName, file) ||
+		strings.EqualFold(path.Base(r.WorkflowName), path.Base(file))
+}
+
+// LatestRuns maps each workflow file to its newest run created after cutoff,
+// using names for the workflows' display names. runs must be sorted newest
+// first, as returned by the client. A zero cutoff accepts runs of any age.
+func LatestRuns(files []string, names map[string]string, runs []GHRun, cutoff time.Time) map[string]*GHRun {
+	result := make(map[string]*GHRun)
+	for _, wf := range files {
+		for i := range runs {
+			run := &runs[i]
+			if run.CreatedAt.Before(cutoff) {
+				break
+			}
+			if run.MatchesWorkflow(wf, names[wf]) {
+				result[wf] = run
+				break
+			}
+		}
+	}
+	return result
+}
+
+// RunFilter selects runs by conclusion and head branch. Empty fields match
+// every run.
+type RunFilter struct {
+	Conclusion string
+	// Branch is a branch name or a glob such as release/*
+	Branch string
+}
+
+// Matches reports whether run passes the filter. Only completed runs have a
+// conclusion, so a conclusion filter never matches a run still in progress.
+func (f RunFilter) Matches(run GHRun) bool {
+	if f.Conclusion != "" {
+		if !IsTerminalStatus(NormalizeStatus(run.Status)) ||
+			NormalizeStatus(run.Conclusion) != NormalizeStatus(f.Conclusion) {
+			return false
+		}
+	}
+	if f.Branch != "" && run.HeadBranch != f.Branch {
+		if ok, err := path.Match(f.Branch, run.HeadBranch); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterRuns returns the runs that pass f, keeping their order
+func FilterRuns(runs []GHRun, f RunFilter) []GHRun {
+	var result []GHRun
+	for _, run := range runs {
+		if f.Matches(run) {
+			result = append(result, run)
+		}
+	}
+	return result
+}
+
+// SummarizeAnnotations counts failure annotations as errors and warning
+// annotations as warnings. Notices are not counted.
+func SummarizeAnnotations(annotations []GHAnnotation) AnnotationSummary {
+	var summary AnnotationSummary
+	for _, a := range annotations {
+		switch NormalizeStatus(a.Level) {
+		case "failure":
+			summary.Errors++
+		case "warning":
+			summary.Warnings++
+		}
+	}
+	return summary
+}
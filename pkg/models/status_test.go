@@ -0,0 +1,102 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLatestRuns(t *testing.T) {
+	now := time.Now()
+	runs := []GHRun{
+		{DatabaseID: 4, WorkflowName: "CI", CreatedAt: now.Add(-time.Hour)},
+		{DatabaseID: 3, WorkflowName: ".github/workflows/lint.yml", CreatedAt: now.Add(-2 * time.Hour)},
+		{DatabaseID: 2, WorkflowName: "CI", CreatedAt: now.Add(-3 * time.Hour)},
+		{DatabaseID: 1, WorkflowName: "Deploy", CreatedAt: now.Add(-48 * time.Hour)},
+	}
+	files := []string{"ci.yml", "lint.yml", "deploy.yml", "nightly.yml"}
+	names := map[string]string{"ci.yml": "CI", "lint.yml": "lint.yml", "deploy.yml": "Deploy", "nightly.yml": "Nightly"}
+
+	got := LatestRuns(files, names, runs, now.Add(-24*time.Hour))
+
+	if run := got["ci.yml"]; run == nil || run.DatabaseID != 4 {
+		t.Errorf("expected newest CI run, got %+v", run)
+	}
+	if run := got["lint.yml"]; run == nil || run.DatabaseID != 3 {
+		t.Errorf("expected lint run matched by file, got %+v", run)
+	}
+	if _, ok := got["deploy.yml"]; ok {
+		t.Error("expected deploy to be dormant")
+	}
+	if _, ok := got["nightly.yml"]; ok {
+		t.Error("expected nightly without runs to be dormant")
+	}
+}
+
+func TestSummarizeAnnotations(t *testing.T) {
+	summary := SummarizeAnnotations([]GHAnnotation{
+		{Level: "failure"},
+		{Level: "FAILURE"},
+		{Level: "warning"},
+		{Level: "notice"},
+	})
+	if summary != (AnnotationSummary{Errors: 2, Warnings: 1}) {
+		t.Errorf("SummarizeAnnotations() = %+v, want 2 errors and 1 warning", summary)
+	}
+}
+
+func TestFilterRuns(t *testing.T) {
+	runs := []GHRun{
+		{DatabaseID: 1, Status: "completed", Conclusion: "failure", HeadBranch: "main"},
+		{DatabaseID: 2, Status: "completed", Conclusion: "success", HeadBranch: "release/1.2"},
+		{DatabaseID: 3, Status: "COMPLETED", Conclusion: "Failure", HeadBranch: "release/1.3"},
+		{DatabaseID: 4, Status: "in_progress", HeadBranch: "main"},
+	}
+
+	tests := []struct {
+		name   string
+		filter RunFilter
+		want   []int
+	}{
+		{name: "no filter", want: []int{1, 2, 3, 4}},
+		{name: "conclusion ignores case", filter: RunFilter{Conclusion: "failure"}, want: []int{1, 3}},
+		{name: "exact branch", filter: RunFilter{Branch: "main"}, want: []int{1, 4}},
+		{name: "branch glob", filter: RunFilter{Branch: "release/*"}, want: []int{2, 3}},
+		{name: "both", filter: RunFilter{Conclusion: "failure", Branch: "release/*"}, want: []int{3}},
+		{name: "no match", filter: RunFilter{Conclusion: "cancelled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, run := range FilterRuns(runs, tt.filter) {
+				got = append(got, run.DatabaseID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FilterRuns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueuedFor(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-5 * time.Minute)
+
+	tests := []struct {
+		run  GHRun
+		want time.Duration
+	}{
+		{GHRun{Status: StatusQueued, CreatedAt: created}, 5 * time.Minute},
+		{GHRun{Status: StatusWaiting, CreatedAt: created}, 5 * time.Minute},
+		{GHRun{Status: StatusInProgress, CreatedAt: created}, 0},
+		{GHRun{Status: StatusCompleted, CreatedAt: created}, 0},
+		{GHRun{Status: StatusQueued}, 0},
+		{GHRun{Status: StatusQueued, CreatedAt: now.Add(time.Minute)}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.run.QueuedFor(now); got != tt.want {
+			t.Errorf("QueuedFor(%s created %v) = %v, want %v", tt.run.Status, tt.run.CreatedAt, got, tt.want)
+		}
+	}
+}